@@ -0,0 +1,1408 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: stock/v1/stock.proto
+
+package stockv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// StockFilter mirrors the query parameters of GET /api/v1/stocks
+type StockFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tickers to include (exact match) and exclude
+	Ticker    []string `protobuf:"bytes,1,rep,name=ticker,proto3" json:"ticker,omitempty"`
+	TickerNot []string `protobuf:"bytes,2,rep,name=ticker_not,json=tickerNot,proto3" json:"ticker_not,omitempty"`
+	// Company name substring
+	Company string `protobuf:"bytes,3,opt,name=company,proto3" json:"company,omitempty"`
+	// Brokerages to include (fuzzy match) and exclude (substring match)
+	Brokerage    []string `protobuf:"bytes,4,rep,name=brokerage,proto3" json:"brokerage,omitempty"`
+	BrokerageNot []string `protobuf:"bytes,5,rep,name=brokerage_not,json=brokerageNot,proto3" json:"brokerage_not,omitempty"`
+	// Actions to include (exact match) and exclude
+	Action    []string `protobuf:"bytes,6,rep,name=action,proto3" json:"action,omitempty"`
+	ActionNot []string `protobuf:"bytes,7,rep,name=action_not,json=actionNot,proto3" json:"action_not,omitempty"`
+	// Canonical action category, e.g. "upgrade" or "target_raised"
+	ActionCategory string `protobuf:"bytes,8,opt,name=action_category,json=actionCategory,proto3" json:"action_category,omitempty"`
+	// Previous ratings to include (exact match) and exclude
+	RatingFrom    []string `protobuf:"bytes,9,rep,name=rating_from,json=ratingFrom,proto3" json:"rating_from,omitempty"`
+	RatingFromNot []string `protobuf:"bytes,10,rep,name=rating_from_not,json=ratingFromNot,proto3" json:"rating_from_not,omitempty"`
+	// New ratings to include (exact match) and exclude
+	RatingTo    []string `protobuf:"bytes,11,rep,name=rating_to,json=ratingTo,proto3" json:"rating_to,omitempty"`
+	RatingToNot []string `protobuf:"bytes,12,rep,name=rating_to_not,json=ratingToNot,proto3" json:"rating_to_not,omitempty"`
+	// Canonical bucket of the new rating, e.g. "buy" or "strong_buy"
+	RatingBucket       string   `protobuf:"bytes,13,opt,name=rating_bucket,json=ratingBucket,proto3" json:"rating_bucket,omitempty"`
+	TargetToMin        *float64 `protobuf:"fixed64,14,opt,name=target_to_min,json=targetToMin,proto3,oneof" json:"target_to_min,omitempty"`
+	TargetToMax        *float64 `protobuf:"fixed64,15,opt,name=target_to_max,json=targetToMax,proto3,oneof" json:"target_to_max,omitempty"`
+	TargetChangePctMin *float64 `protobuf:"fixed64,16,opt,name=target_change_pct_min,json=targetChangePctMin,proto3,oneof" json:"target_change_pct_min,omitempty"`
+	// RFC 3339 timestamp, YYYY-MM-DD date or relative time such as "30d"
+	TimeFrom string `protobuf:"bytes,17,opt,name=time_from,json=timeFrom,proto3" json:"time_from,omitempty"`
+	TimeTo   string `protobuf:"bytes,18,opt,name=time_to,json=timeTo,proto3" json:"time_to,omitempty"`
+	// Filter expression, as in the q query parameter
+	Q             string `protobuf:"bytes,19,opt,name=q,proto3" json:"q,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockFilter) Reset() {
+	*x = StockFilter{}
+	mi := &file_stock_v1_stock_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockFilter) ProtoMessage() {}
+
+func (x *StockFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockFilter.ProtoReflect.Descriptor instead.
+func (*StockFilter) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StockFilter) GetTicker() []string {
+	if x != nil {
+		return x.Ticker
+	}
+	return nil
+}
+
+func (x *StockFilter) GetTickerNot() []string {
+	if x != nil {
+		return x.TickerNot
+	}
+	return nil
+}
+
+func (x *StockFilter) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *StockFilter) GetBrokerage() []string {
+	if x != nil {
+		return x.Brokerage
+	}
+	return nil
+}
+
+func (x *StockFilter) GetBrokerageNot() []string {
+	if x != nil {
+		return x.BrokerageNot
+	}
+	return nil
+}
+
+func (x *StockFilter) GetAction() []string {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+func (x *StockFilter) GetActionNot() []string {
+	if x != nil {
+		return x.ActionNot
+	}
+	return nil
+}
+
+func (x *StockFilter) GetActionCategory() string {
+	if x != nil {
+		return x.ActionCategory
+	}
+	return ""
+}
+
+func (x *StockFilter) GetRatingFrom() []string {
+	if x != nil {
+		return x.RatingFrom
+	}
+	return nil
+}
+
+func (x *StockFilter) GetRatingFromNot() []string {
+	if x != nil {
+		return x.RatingFromNot
+	}
+	return nil
+}
+
+func (x *StockFilter) GetRatingTo() []string {
+	if x != nil {
+		return x.RatingTo
+	}
+	return nil
+}
+
+func (x *StockFilter) GetRatingToNot() []string {
+	if x != nil {
+		return x.RatingToNot
+	}
+	return nil
+}
+
+func (x *StockFilter) GetRatingBucket() string {
+	if x != nil {
+		return x.RatingBucket
+	}
+	return ""
+}
+
+func (x *StockFilter) GetTargetToMin() float64 {
+	if x != nil && x.TargetToMin != nil {
+		return *x.TargetToMin
+	}
+	return 0
+}
+
+func (x *StockFilter) GetTargetToMax() float64 {
+	if x != nil && x.TargetToMax != nil {
+		return *x.TargetToMax
+	}
+	return 0
+}
+
+func (x *StockFilter) GetTargetChangePctMin() float64 {
+	if x != nil && x.TargetChangePctMin != nil {
+		return *x.TargetChangePctMin
+	}
+	return 0
+}
+
+func (x *StockFilter) GetTimeFrom() string {
+	if x != nil {
+		return x.TimeFrom
+	}
+	return ""
+}
+
+func (x *StockFilter) GetTimeTo() string {
+	if x != nil {
+		return x.TimeTo
+	}
+	return ""
+}
+
+func (x *StockFilter) GetQ() string {
+	if x != nil {
+		return x.Q
+	}
+	return ""
+}
+
+type ListStocksRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Filter *StockFilter           `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// One of ticker, company, time, rating_to, action, brokerage, target_to (default time)
+	SortBy string `protobuf:"bytes,2,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	// asc or desc (default desc)
+	SortOrder string `protobuf:"bytes,3,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	// Maximum number of stocks to stream; 0 streams every matching stock
+	Limit         int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32 `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStocksRequest) Reset() {
+	*x = ListStocksRequest{}
+	mi := &file_stock_v1_stock_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStocksRequest) ProtoMessage() {}
+
+func (x *ListStocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStocksRequest.ProtoReflect.Descriptor instead.
+func (*ListStocksRequest) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListStocksRequest) GetFilter() *StockFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListStocksRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListStocksRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *ListStocksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListStocksRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type GetStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockRequest) Reset() {
+	*x = GetStockRequest{}
+	mi := &file_stock_v1_stock_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockRequest) ProtoMessage() {}
+
+func (x *GetStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockRequest.ProtoReflect.Descriptor instead.
+func (*GetStockRequest) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetStockRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetTickerHistoryRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Ticker string                 `protobuf:"bytes,1,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	// Same formats as StockFilter.time_from and time_to
+	TimeFrom      string `protobuf:"bytes,2,opt,name=time_from,json=timeFrom,proto3" json:"time_from,omitempty"`
+	TimeTo        string `protobuf:"bytes,3,opt,name=time_to,json=timeTo,proto3" json:"time_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTickerHistoryRequest) Reset() {
+	*x = GetTickerHistoryRequest{}
+	mi := &file_stock_v1_stock_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTickerHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTickerHistoryRequest) ProtoMessage() {}
+
+func (x *GetTickerHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTickerHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetTickerHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetTickerHistoryRequest) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *GetTickerHistoryRequest) GetTimeFrom() string {
+	if x != nil {
+		return x.TimeFrom
+	}
+	return ""
+}
+
+func (x *GetTickerHistoryRequest) GetTimeTo() string {
+	if x != nil {
+		return x.TimeTo
+	}
+	return ""
+}
+
+type GetRecommendationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of recommendations to stream (default 10)
+	Limit         int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecommendationsRequest) Reset() {
+	*x = GetRecommendationsRequest{}
+	mi := &file_stock_v1_stock_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecommendationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecommendationsRequest) ProtoMessage() {}
+
+func (x *GetRecommendationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecommendationsRequest.ProtoReflect.Descriptor instead.
+func (*GetRecommendationsRequest) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetRecommendationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListBrokeragesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBrokeragesRequest) Reset() {
+	*x = ListBrokeragesRequest{}
+	mi := &file_stock_v1_stock_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBrokeragesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBrokeragesRequest) ProtoMessage() {}
+
+func (x *ListBrokeragesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBrokeragesRequest.ProtoReflect.Descriptor instead.
+func (*ListBrokeragesRequest) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{5}
+}
+
+type ListActionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListActionsRequest) Reset() {
+	*x = ListActionsRequest{}
+	mi := &file_stock_v1_stock_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActionsRequest) ProtoMessage() {}
+
+func (x *ListActionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActionsRequest.ProtoReflect.Descriptor instead.
+func (*ListActionsRequest) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{6}
+}
+
+type ListRatingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRatingsRequest) Reset() {
+	*x = ListRatingsRequest{}
+	mi := &file_stock_v1_stock_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRatingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRatingsRequest) ProtoMessage() {}
+
+func (x *ListRatingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRatingsRequest.ProtoReflect.Descriptor instead.
+func (*ListRatingsRequest) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{7}
+}
+
+type TriggerSyncRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerSyncRequest) Reset() {
+	*x = TriggerSyncRequest{}
+	mi := &file_stock_v1_stock_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerSyncRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerSyncRequest) ProtoMessage() {}
+
+func (x *TriggerSyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerSyncRequest.ProtoReflect.Descriptor instead.
+func (*TriggerSyncRequest) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{8}
+}
+
+type TriggerSyncResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SyncedCount   int32                  `protobuf:"varint,1,opt,name=synced_count,json=syncedCount,proto3" json:"synced_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerSyncResponse) Reset() {
+	*x = TriggerSyncResponse{}
+	mi := &file_stock_v1_stock_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerSyncResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerSyncResponse) ProtoMessage() {}
+
+func (x *TriggerSyncResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerSyncResponse.ProtoReflect.Descriptor instead.
+func (*TriggerSyncResponse) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TriggerSyncResponse) GetSyncedCount() int32 {
+	if x != nil {
+		return x.SyncedCount
+	}
+	return 0
+}
+
+type Stock struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ticker           string                 `protobuf:"bytes,2,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	Company          string                 `protobuf:"bytes,3,opt,name=company,proto3" json:"company,omitempty"`
+	TargetFrom       string                 `protobuf:"bytes,4,opt,name=target_from,json=targetFrom,proto3" json:"target_from,omitempty"`
+	TargetTo         string                 `protobuf:"bytes,5,opt,name=target_to,json=targetTo,proto3" json:"target_to,omitempty"`
+	TargetFromAmount *float64               `protobuf:"fixed64,6,opt,name=target_from_amount,json=targetFromAmount,proto3,oneof" json:"target_from_amount,omitempty"`
+	TargetToAmount   *float64               `protobuf:"fixed64,7,opt,name=target_to_amount,json=targetToAmount,proto3,oneof" json:"target_to_amount,omitempty"`
+	TargetCurrency   string                 `protobuf:"bytes,8,opt,name=target_currency,json=targetCurrency,proto3" json:"target_currency,omitempty"`
+	ActionId         *int64                 `protobuf:"varint,9,opt,name=action_id,json=actionId,proto3,oneof" json:"action_id,omitempty"`
+	Action           string                 `protobuf:"bytes,10,opt,name=action,proto3" json:"action,omitempty"`
+	ActionCategory   string                 `protobuf:"bytes,11,opt,name=action_category,json=actionCategory,proto3" json:"action_category,omitempty"`
+	ActionDirection  string                 `protobuf:"bytes,12,opt,name=action_direction,json=actionDirection,proto3" json:"action_direction,omitempty"`
+	BrokerageId      *int64                 `protobuf:"varint,13,opt,name=brokerage_id,json=brokerageId,proto3,oneof" json:"brokerage_id,omitempty"`
+	Brokerage        string                 `protobuf:"bytes,14,opt,name=brokerage,proto3" json:"brokerage,omitempty"`
+	RatingFromId     *int64                 `protobuf:"varint,15,opt,name=rating_from_id,json=ratingFromId,proto3,oneof" json:"rating_from_id,omitempty"`
+	RatingFrom       string                 `protobuf:"bytes,16,opt,name=rating_from,json=ratingFrom,proto3" json:"rating_from,omitempty"`
+	RatingFromBucket string                 `protobuf:"bytes,17,opt,name=rating_from_bucket,json=ratingFromBucket,proto3" json:"rating_from_bucket,omitempty"`
+	RatingFromScore  *float64               `protobuf:"fixed64,18,opt,name=rating_from_score,json=ratingFromScore,proto3,oneof" json:"rating_from_score,omitempty"`
+	RatingToId       *int64                 `protobuf:"varint,19,opt,name=rating_to_id,json=ratingToId,proto3,oneof" json:"rating_to_id,omitempty"`
+	RatingTo         string                 `protobuf:"bytes,20,opt,name=rating_to,json=ratingTo,proto3" json:"rating_to,omitempty"`
+	RatingToBucket   string                 `protobuf:"bytes,21,opt,name=rating_to_bucket,json=ratingToBucket,proto3" json:"rating_to_bucket,omitempty"`
+	RatingToScore    *float64               `protobuf:"fixed64,22,opt,name=rating_to_score,json=ratingToScore,proto3,oneof" json:"rating_to_score,omitempty"`
+	Time             *timestamppb.Timestamp `protobuf:"bytes,23,opt,name=time,proto3" json:"time,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,24,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,25,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Stock) Reset() {
+	*x = Stock{}
+	mi := &file_stock_v1_stock_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stock) ProtoMessage() {}
+
+func (x *Stock) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stock.ProtoReflect.Descriptor instead.
+func (*Stock) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Stock) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Stock) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *Stock) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *Stock) GetTargetFrom() string {
+	if x != nil {
+		return x.TargetFrom
+	}
+	return ""
+}
+
+func (x *Stock) GetTargetTo() string {
+	if x != nil {
+		return x.TargetTo
+	}
+	return ""
+}
+
+func (x *Stock) GetTargetFromAmount() float64 {
+	if x != nil && x.TargetFromAmount != nil {
+		return *x.TargetFromAmount
+	}
+	return 0
+}
+
+func (x *Stock) GetTargetToAmount() float64 {
+	if x != nil && x.TargetToAmount != nil {
+		return *x.TargetToAmount
+	}
+	return 0
+}
+
+func (x *Stock) GetTargetCurrency() string {
+	if x != nil {
+		return x.TargetCurrency
+	}
+	return ""
+}
+
+func (x *Stock) GetActionId() int64 {
+	if x != nil && x.ActionId != nil {
+		return *x.ActionId
+	}
+	return 0
+}
+
+func (x *Stock) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *Stock) GetActionCategory() string {
+	if x != nil {
+		return x.ActionCategory
+	}
+	return ""
+}
+
+func (x *Stock) GetActionDirection() string {
+	if x != nil {
+		return x.ActionDirection
+	}
+	return ""
+}
+
+func (x *Stock) GetBrokerageId() int64 {
+	if x != nil && x.BrokerageId != nil {
+		return *x.BrokerageId
+	}
+	return 0
+}
+
+func (x *Stock) GetBrokerage() string {
+	if x != nil {
+		return x.Brokerage
+	}
+	return ""
+}
+
+func (x *Stock) GetRatingFromId() int64 {
+	if x != nil && x.RatingFromId != nil {
+		return *x.RatingFromId
+	}
+	return 0
+}
+
+func (x *Stock) GetRatingFrom() string {
+	if x != nil {
+		return x.RatingFrom
+	}
+	return ""
+}
+
+func (x *Stock) GetRatingFromBucket() string {
+	if x != nil {
+		return x.RatingFromBucket
+	}
+	return ""
+}
+
+func (x *Stock) GetRatingFromScore() float64 {
+	if x != nil && x.RatingFromScore != nil {
+		return *x.RatingFromScore
+	}
+	return 0
+}
+
+func (x *Stock) GetRatingToId() int64 {
+	if x != nil && x.RatingToId != nil {
+		return *x.RatingToId
+	}
+	return 0
+}
+
+func (x *Stock) GetRatingTo() string {
+	if x != nil {
+		return x.RatingTo
+	}
+	return ""
+}
+
+func (x *Stock) GetRatingToBucket() string {
+	if x != nil {
+		return x.RatingToBucket
+	}
+	return ""
+}
+
+func (x *Stock) GetRatingToScore() float64 {
+	if x != nil && x.RatingToScore != nil {
+		return *x.RatingToScore
+	}
+	return 0
+}
+
+func (x *Stock) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Stock) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Stock) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type StockRecommendation struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Stock                 *Stock                 `protobuf:"bytes,1,opt,name=stock,proto3" json:"stock,omitempty"`
+	Score                 float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Reason                string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	TargetIncreasePercent float64                `protobuf:"fixed64,4,opt,name=target_increase_percent,json=targetIncreasePercent,proto3" json:"target_increase_percent,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *StockRecommendation) Reset() {
+	*x = StockRecommendation{}
+	mi := &file_stock_v1_stock_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockRecommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockRecommendation) ProtoMessage() {}
+
+func (x *StockRecommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockRecommendation.ProtoReflect.Descriptor instead.
+func (*StockRecommendation) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StockRecommendation) GetStock() *Stock {
+	if x != nil {
+		return x.Stock
+	}
+	return nil
+}
+
+func (x *StockRecommendation) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *StockRecommendation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *StockRecommendation) GetTargetIncreasePercent() float64 {
+	if x != nil {
+		return x.TargetIncreasePercent
+	}
+	return 0
+}
+
+type Brokerage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Brokerage) Reset() {
+	*x = Brokerage{}
+	mi := &file_stock_v1_stock_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Brokerage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Brokerage) ProtoMessage() {}
+
+func (x *Brokerage) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Brokerage.ProtoReflect.Descriptor instead.
+func (*Brokerage) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Brokerage) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Brokerage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Brokerage) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Brokerage) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Action struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Category      string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	Direction     string                 `protobuf:"bytes,4,opt,name=direction,proto3" json:"direction,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Action) Reset() {
+	*x = Action{}
+	mi := &file_stock_v1_stock_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Action) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Action) ProtoMessage() {}
+
+func (x *Action) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Action.ProtoReflect.Descriptor instead.
+func (*Action) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Action) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Action) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Action) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Action) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *Action) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Action) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Rating struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Term          string                 `protobuf:"bytes,2,opt,name=term,proto3" json:"term,omitempty"`
+	Bucket        string                 `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Score         *float64               `protobuf:"fixed64,4,opt,name=score,proto3,oneof" json:"score,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rating) Reset() {
+	*x = Rating{}
+	mi := &file_stock_v1_stock_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rating) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rating) ProtoMessage() {}
+
+func (x *Rating) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rating.ProtoReflect.Descriptor instead.
+func (*Rating) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Rating) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Rating) GetTerm() string {
+	if x != nil {
+		return x.Term
+	}
+	return ""
+}
+
+func (x *Rating) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *Rating) GetScore() float64 {
+	if x != nil && x.Score != nil {
+		return *x.Score
+	}
+	return 0
+}
+
+func (x *Rating) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Rating) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+var File_stock_v1_stock_proto protoreflect.FileDescriptor
+
+const file_stock_v1_stock_proto_rawDesc = "" +
+	"\n" +
+	"\x14stock/v1/stock.proto\x12\bstock.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xbc\x05\n" +
+	"\vStockFilter\x12\x16\n" +
+	"\x06ticker\x18\x01 \x03(\tR\x06ticker\x12\x1d\n" +
+	"\n" +
+	"ticker_not\x18\x02 \x03(\tR\ttickerNot\x12\x18\n" +
+	"\acompany\x18\x03 \x01(\tR\acompany\x12\x1c\n" +
+	"\tbrokerage\x18\x04 \x03(\tR\tbrokerage\x12#\n" +
+	"\rbrokerage_not\x18\x05 \x03(\tR\fbrokerageNot\x12\x16\n" +
+	"\x06action\x18\x06 \x03(\tR\x06action\x12\x1d\n" +
+	"\n" +
+	"action_not\x18\a \x03(\tR\tactionNot\x12'\n" +
+	"\x0faction_category\x18\b \x01(\tR\x0eactionCategory\x12\x1f\n" +
+	"\vrating_from\x18\t \x03(\tR\n" +
+	"ratingFrom\x12&\n" +
+	"\x0frating_from_not\x18\n" +
+	" \x03(\tR\rratingFromNot\x12\x1b\n" +
+	"\trating_to\x18\v \x03(\tR\bratingTo\x12\"\n" +
+	"\rrating_to_not\x18\f \x03(\tR\vratingToNot\x12#\n" +
+	"\rrating_bucket\x18\r \x01(\tR\fratingBucket\x12'\n" +
+	"\rtarget_to_min\x18\x0e \x01(\x01H\x00R\vtargetToMin\x88\x01\x01\x12'\n" +
+	"\rtarget_to_max\x18\x0f \x01(\x01H\x01R\vtargetToMax\x88\x01\x01\x126\n" +
+	"\x15target_change_pct_min\x18\x10 \x01(\x01H\x02R\x12targetChangePctMin\x88\x01\x01\x12\x1b\n" +
+	"\ttime_from\x18\x11 \x01(\tR\btimeFrom\x12\x17\n" +
+	"\atime_to\x18\x12 \x01(\tR\x06timeTo\x12\f\n" +
+	"\x01q\x18\x13 \x01(\tR\x01qB\x10\n" +
+	"\x0e_target_to_minB\x10\n" +
+	"\x0e_target_to_maxB\x18\n" +
+	"\x16_target_change_pct_min\"\xa8\x01\n" +
+	"\x11ListStocksRequest\x12-\n" +
+	"\x06filter\x18\x01 \x01(\v2\x15.stock.v1.StockFilterR\x06filter\x12\x17\n" +
+	"\asort_by\x18\x02 \x01(\tR\x06sortBy\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\x03 \x01(\tR\tsortOrder\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\"!\n" +
+	"\x0fGetStockRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"g\n" +
+	"\x17GetTickerHistoryRequest\x12\x16\n" +
+	"\x06ticker\x18\x01 \x01(\tR\x06ticker\x12\x1b\n" +
+	"\ttime_from\x18\x02 \x01(\tR\btimeFrom\x12\x17\n" +
+	"\atime_to\x18\x03 \x01(\tR\x06timeTo\"1\n" +
+	"\x19GetRecommendationsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"\x17\n" +
+	"\x15ListBrokeragesRequest\"\x14\n" +
+	"\x12ListActionsRequest\"\x14\n" +
+	"\x12ListRatingsRequest\"\x14\n" +
+	"\x12TriggerSyncRequest\"8\n" +
+	"\x13TriggerSyncResponse\x12!\n" +
+	"\fsynced_count\x18\x01 \x01(\x05R\vsyncedCount\"\xeb\b\n" +
+	"\x05Stock\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06ticker\x18\x02 \x01(\tR\x06ticker\x12\x18\n" +
+	"\acompany\x18\x03 \x01(\tR\acompany\x12\x1f\n" +
+	"\vtarget_from\x18\x04 \x01(\tR\n" +
+	"targetFrom\x12\x1b\n" +
+	"\ttarget_to\x18\x05 \x01(\tR\btargetTo\x121\n" +
+	"\x12target_from_amount\x18\x06 \x01(\x01H\x00R\x10targetFromAmount\x88\x01\x01\x12-\n" +
+	"\x10target_to_amount\x18\a \x01(\x01H\x01R\x0etargetToAmount\x88\x01\x01\x12'\n" +
+	"\x0ftarget_currency\x18\b \x01(\tR\x0etargetCurrency\x12 \n" +
+	"\taction_id\x18\t \x01(\x03H\x02R\bactionId\x88\x01\x01\x12\x16\n" +
+	"\x06action\x18\n" +
+	" \x01(\tR\x06action\x12'\n" +
+	"\x0faction_category\x18\v \x01(\tR\x0eactionCategory\x12)\n" +
+	"\x10action_direction\x18\f \x01(\tR\x0factionDirection\x12&\n" +
+	"\fbrokerage_id\x18\r \x01(\x03H\x03R\vbrokerageId\x88\x01\x01\x12\x1c\n" +
+	"\tbrokerage\x18\x0e \x01(\tR\tbrokerage\x12)\n" +
+	"\x0erating_from_id\x18\x0f \x01(\x03H\x04R\fratingFromId\x88\x01\x01\x12\x1f\n" +
+	"\vrating_from\x18\x10 \x01(\tR\n" +
+	"ratingFrom\x12,\n" +
+	"\x12rating_from_bucket\x18\x11 \x01(\tR\x10ratingFromBucket\x12/\n" +
+	"\x11rating_from_score\x18\x12 \x01(\x01H\x05R\x0fratingFromScore\x88\x01\x01\x12%\n" +
+	"\frating_to_id\x18\x13 \x01(\x03H\x06R\n" +
+	"ratingToId\x88\x01\x01\x12\x1b\n" +
+	"\trating_to\x18\x14 \x01(\tR\bratingTo\x12(\n" +
+	"\x10rating_to_bucket\x18\x15 \x01(\tR\x0eratingToBucket\x12+\n" +
+	"\x0frating_to_score\x18\x16 \x01(\x01H\aR\rratingToScore\x88\x01\x01\x12.\n" +
+	"\x04time\x18\x17 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x129\n" +
+	"\n" +
+	"created_at\x18\x18 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x19 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x15\n" +
+	"\x13_target_from_amountB\x13\n" +
+	"\x11_target_to_amountB\f\n" +
+	"\n" +
+	"_action_idB\x0f\n" +
+	"\r_brokerage_idB\x11\n" +
+	"\x0f_rating_from_idB\x14\n" +
+	"\x12_rating_from_scoreB\x0f\n" +
+	"\r_rating_to_idB\x12\n" +
+	"\x10_rating_to_score\"\xa2\x01\n" +
+	"\x13StockRecommendation\x12%\n" +
+	"\x05stock\x18\x01 \x01(\v2\x0f.stock.v1.StockR\x05stock\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x126\n" +
+	"\x17target_increase_percent\x18\x04 \x01(\x01R\x15targetIncreasePercent\"\xa5\x01\n" +
+	"\tBrokerage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xdc\x01\n" +
+	"\x06Action\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12\x1c\n" +
+	"\tdirection\x18\x04 \x01(\tR\tdirection\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xdf\x01\n" +
+	"\x06Rating\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04term\x18\x02 \x01(\tR\x04term\x12\x16\n" +
+	"\x06bucket\x18\x03 \x01(\tR\x06bucket\x12\x19\n" +
+	"\x05score\x18\x04 \x01(\x01H\x00R\x05score\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\b\n" +
+	"\x06_score2\xc2\x04\n" +
+	"\fStockService\x12<\n" +
+	"\n" +
+	"ListStocks\x12\x1b.stock.v1.ListStocksRequest\x1a\x0f.stock.v1.Stock0\x01\x126\n" +
+	"\bGetStock\x12\x19.stock.v1.GetStockRequest\x1a\x0f.stock.v1.Stock\x12H\n" +
+	"\x10GetTickerHistory\x12!.stock.v1.GetTickerHistoryRequest\x1a\x0f.stock.v1.Stock0\x01\x12Z\n" +
+	"\x12GetRecommendations\x12#.stock.v1.GetRecommendationsRequest\x1a\x1d.stock.v1.StockRecommendation0\x01\x12H\n" +
+	"\x0eListBrokerages\x12\x1f.stock.v1.ListBrokeragesRequest\x1a\x13.stock.v1.Brokerage0\x01\x12?\n" +
+	"\vListActions\x12\x1c.stock.v1.ListActionsRequest\x1a\x10.stock.v1.Action0\x01\x12?\n" +
+	"\vListRatings\x12\x1c.stock.v1.ListRatingsRequest\x1a\x10.stock.v1.Rating0\x01\x12J\n" +
+	"\vTriggerSync\x12\x1c.stock.v1.TriggerSyncRequest\x1a\x1d.stock.v1.TriggerSyncResponseB9Z7github.com/company/stock-api/api/proto/stock/v1;stockv1b\x06proto3"
+
+var (
+	file_stock_v1_stock_proto_rawDescOnce sync.Once
+	file_stock_v1_stock_proto_rawDescData []byte
+)
+
+func file_stock_v1_stock_proto_rawDescGZIP() []byte {
+	file_stock_v1_stock_proto_rawDescOnce.Do(func() {
+		file_stock_v1_stock_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_stock_v1_stock_proto_rawDesc), len(file_stock_v1_stock_proto_rawDesc)))
+	})
+	return file_stock_v1_stock_proto_rawDescData
+}
+
+var file_stock_v1_stock_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_stock_v1_stock_proto_goTypes = []any{
+	(*StockFilter)(nil),               // 0: stock.v1.StockFilter
+	(*ListStocksRequest)(nil),         // 1: stock.v1.ListStocksRequest
+	(*GetStockRequest)(nil),           // 2: stock.v1.GetStockRequest
+	(*GetTickerHistoryRequest)(nil),   // 3: stock.v1.GetTickerHistoryRequest
+	(*GetRecommendationsRequest)(nil), // 4: stock.v1.GetRecommendationsRequest
+	(*ListBrokeragesRequest)(nil),     // 5: stock.v1.ListBrokeragesRequest
+	(*ListActionsRequest)(nil),        // 6: stock.v1.ListActionsRequest
+	(*ListRatingsRequest)(nil),        // 7: stock.v1.ListRatingsRequest
+	(*TriggerSyncRequest)(nil),        // 8: stock.v1.TriggerSyncRequest
+	(*TriggerSyncResponse)(nil),       // 9: stock.v1.TriggerSyncResponse
+	(*Stock)(nil),                     // 10: stock.v1.Stock
+	(*StockRecommendation)(nil),       // 11: stock.v1.StockRecommendation
+	(*Brokerage)(nil),                 // 12: stock.v1.Brokerage
+	(*Action)(nil),                    // 13: stock.v1.Action
+	(*Rating)(nil),                    // 14: stock.v1.Rating
+	(*timestamppb.Timestamp)(nil),     // 15: google.protobuf.Timestamp
+}
+var file_stock_v1_stock_proto_depIdxs = []int32{
+	0,  // 0: stock.v1.ListStocksRequest.filter:type_name -> stock.v1.StockFilter
+	15, // 1: stock.v1.Stock.time:type_name -> google.protobuf.Timestamp
+	15, // 2: stock.v1.Stock.created_at:type_name -> google.protobuf.Timestamp
+	15, // 3: stock.v1.Stock.updated_at:type_name -> google.protobuf.Timestamp
+	10, // 4: stock.v1.StockRecommendation.stock:type_name -> stock.v1.Stock
+	15, // 5: stock.v1.Brokerage.created_at:type_name -> google.protobuf.Timestamp
+	15, // 6: stock.v1.Brokerage.updated_at:type_name -> google.protobuf.Timestamp
+	15, // 7: stock.v1.Action.created_at:type_name -> google.protobuf.Timestamp
+	15, // 8: stock.v1.Action.updated_at:type_name -> google.protobuf.Timestamp
+	15, // 9: stock.v1.Rating.created_at:type_name -> google.protobuf.Timestamp
+	15, // 10: stock.v1.Rating.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 11: stock.v1.StockService.ListStocks:input_type -> stock.v1.ListStocksRequest
+	2,  // 12: stock.v1.StockService.GetStock:input_type -> stock.v1.GetStockRequest
+	3,  // 13: stock.v1.StockService.GetTickerHistory:input_type -> stock.v1.GetTickerHistoryRequest
+	4,  // 14: stock.v1.StockService.GetRecommendations:input_type -> stock.v1.GetRecommendationsRequest
+	5,  // 15: stock.v1.StockService.ListBrokerages:input_type -> stock.v1.ListBrokeragesRequest
+	6,  // 16: stock.v1.StockService.ListActions:input_type -> stock.v1.ListActionsRequest
+	7,  // 17: stock.v1.StockService.ListRatings:input_type -> stock.v1.ListRatingsRequest
+	8,  // 18: stock.v1.StockService.TriggerSync:input_type -> stock.v1.TriggerSyncRequest
+	10, // 19: stock.v1.StockService.ListStocks:output_type -> stock.v1.Stock
+	10, // 20: stock.v1.StockService.GetStock:output_type -> stock.v1.Stock
+	10, // 21: stock.v1.StockService.GetTickerHistory:output_type -> stock.v1.Stock
+	11, // 22: stock.v1.StockService.GetRecommendations:output_type -> stock.v1.StockRecommendation
+	12, // 23: stock.v1.StockService.ListBrokerages:output_type -> stock.v1.Brokerage
+	13, // 24: stock.v1.StockService.ListActions:output_type -> stock.v1.Action
+	14, // 25: stock.v1.StockService.ListRatings:output_type -> stock.v1.Rating
+	9,  // 26: stock.v1.StockService.TriggerSync:output_type -> stock.v1.TriggerSyncResponse
+	19, // [19:27] is the sub-list for method output_type
+	11, // [11:19] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_stock_v1_stock_proto_init() }
+func file_stock_v1_stock_proto_init() {
+	if File_stock_v1_stock_proto != nil {
+		return
+	}
+	file_stock_v1_stock_proto_msgTypes[0].OneofWrappers = []any{}
+	file_stock_v1_stock_proto_msgTypes[10].OneofWrappers = []any{}
+	file_stock_v1_stock_proto_msgTypes[14].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stock_v1_stock_proto_rawDesc), len(file_stock_v1_stock_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_stock_v1_stock_proto_goTypes,
+		DependencyIndexes: file_stock_v1_stock_proto_depIdxs,
+		MessageInfos:      file_stock_v1_stock_proto_msgTypes,
+	}.Build()
+	File_stock_v1_stock_proto = out.File
+	file_stock_v1_stock_proto_goTypes = nil
+	file_stock_v1_stock_proto_depIdxs = nil
+}
@@ -0,0 +1,428 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: stock/v1/stock.proto
+
+package stockv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	StockService_ListStocks_FullMethodName         = "/stock.v1.StockService/ListStocks"
+	StockService_GetStock_FullMethodName           = "/stock.v1.StockService/GetStock"
+	StockService_GetTickerHistory_FullMethodName   = "/stock.v1.StockService/GetTickerHistory"
+	StockService_GetRecommendations_FullMethodName = "/stock.v1.StockService/GetRecommendations"
+	StockService_ListBrokerages_FullMethodName     = "/stock.v1.StockService/ListBrokerages"
+	StockService_ListActions_FullMethodName        = "/stock.v1.StockService/ListActions"
+	StockService_ListRatings_FullMethodName        = "/stock.v1.StockService/ListRatings"
+	StockService_TriggerSync_FullMethodName        = "/stock.v1.StockService/TriggerSync"
+)
+
+// StockServiceClient is the client API for StockService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// StockService exposes the stock data served by the REST API with typed messages.
+// List calls stream one message per item.
+type StockServiceClient interface {
+	// ListStocks streams the latest stock per ticker matching the filter
+	ListStocks(ctx context.Context, in *ListStocksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Stock], error)
+	// GetStock returns a single stock event by ID
+	GetStock(ctx context.Context, in *GetStockRequest, opts ...grpc.CallOption) (*Stock, error)
+	// GetTickerHistory streams every stock event for a ticker, newest first
+	GetTickerHistory(ctx context.Context, in *GetTickerHistoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Stock], error)
+	// GetRecommendations streams the best-scored stocks, best first
+	GetRecommendations(ctx context.Context, in *GetRecommendationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StockRecommendation], error)
+	// ListBrokerages streams all brokerages by name
+	ListBrokerages(ctx context.Context, in *ListBrokeragesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Brokerage], error)
+	// ListActions streams all analyst actions by name
+	ListActions(ctx context.Context, in *ListActionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Action], error)
+	// ListRatings streams all rating terms
+	ListRatings(ctx context.Context, in *ListRatingsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Rating], error)
+	// TriggerSync fetches all stocks from the external API and stores them
+	TriggerSync(ctx context.Context, in *TriggerSyncRequest, opts ...grpc.CallOption) (*TriggerSyncResponse, error)
+}
+
+type stockServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStockServiceClient(cc grpc.ClientConnInterface) StockServiceClient {
+	return &stockServiceClient{cc}
+}
+
+func (c *stockServiceClient) ListStocks(ctx context.Context, in *ListStocksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Stock], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StockService_ServiceDesc.Streams[0], StockService_ListStocks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListStocksRequest, Stock]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockService_ListStocksClient = grpc.ServerStreamingClient[Stock]
+
+func (c *stockServiceClient) GetStock(ctx context.Context, in *GetStockRequest, opts ...grpc.CallOption) (*Stock, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Stock)
+	err := c.cc.Invoke(ctx, StockService_GetStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) GetTickerHistory(ctx context.Context, in *GetTickerHistoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Stock], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StockService_ServiceDesc.Streams[1], StockService_GetTickerHistory_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetTickerHistoryRequest, Stock]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockService_GetTickerHistoryClient = grpc.ServerStreamingClient[Stock]
+
+func (c *stockServiceClient) GetRecommendations(ctx context.Context, in *GetRecommendationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StockRecommendation], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StockService_ServiceDesc.Streams[2], StockService_GetRecommendations_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetRecommendationsRequest, StockRecommendation]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockService_GetRecommendationsClient = grpc.ServerStreamingClient[StockRecommendation]
+
+func (c *stockServiceClient) ListBrokerages(ctx context.Context, in *ListBrokeragesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Brokerage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StockService_ServiceDesc.Streams[3], StockService_ListBrokerages_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListBrokeragesRequest, Brokerage]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockService_ListBrokeragesClient = grpc.ServerStreamingClient[Brokerage]
+
+func (c *stockServiceClient) ListActions(ctx context.Context, in *ListActionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Action], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StockService_ServiceDesc.Streams[4], StockService_ListActions_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListActionsRequest, Action]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockService_ListActionsClient = grpc.ServerStreamingClient[Action]
+
+func (c *stockServiceClient) ListRatings(ctx context.Context, in *ListRatingsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Rating], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StockService_ServiceDesc.Streams[5], StockService_ListRatings_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListRatingsRequest, Rating]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockService_ListRatingsClient = grpc.ServerStreamingClient[Rating]
+
+func (c *stockServiceClient) TriggerSync(ctx context.Context, in *TriggerSyncRequest, opts ...grpc.CallOption) (*TriggerSyncResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TriggerSyncResponse)
+	err := c.cc.Invoke(ctx, StockService_TriggerSync_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StockServiceServer is the server API for StockService service.
+// All implementations must embed UnimplementedStockServiceServer
+// for forward compatibility.
+//
+// StockService exposes the stock data served by the REST API with typed messages.
+// List calls stream one message per item.
+type StockServiceServer interface {
+	// ListStocks streams the latest stock per ticker matching the filter
+	ListStocks(*ListStocksRequest, grpc.ServerStreamingServer[Stock]) error
+	// GetStock returns a single stock event by ID
+	GetStock(context.Context, *GetStockRequest) (*Stock, error)
+	// GetTickerHistory streams every stock event for a ticker, newest first
+	GetTickerHistory(*GetTickerHistoryRequest, grpc.ServerStreamingServer[Stock]) error
+	// GetRecommendations streams the best-scored stocks, best first
+	GetRecommendations(*GetRecommendationsRequest, grpc.ServerStreamingServer[StockRecommendation]) error
+	// ListBrokerages streams all brokerages by name
+	ListBrokerages(*ListBrokeragesRequest, grpc.ServerStreamingServer[Brokerage]) error
+	// ListActions streams all analyst actions by name
+	ListActions(*ListActionsRequest, grpc.ServerStreamingServer[Action]) error
+	// ListRatings streams all rating terms
+	ListRatings(*ListRatingsRequest, grpc.ServerStreamingServer[Rating]) error
+	// TriggerSync fetches all stocks from the external API and stores them
+	TriggerSync(context.Context, *TriggerSyncRequest) (*TriggerSyncResponse, error)
+	mustEmbedUnimplementedStockServiceServer()
+}
+
+// UnimplementedStockServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStockServiceServer struct{}
+
+func (UnimplementedStockServiceServer) ListStocks(*ListStocksRequest, grpc.ServerStreamingServer[Stock]) error {
+	return status.Errorf(codes.Unimplemented, "method ListStocks not implemented")
+}
+func (UnimplementedStockServiceServer) GetStock(context.Context, *GetStockRequest) (*Stock, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStock not implemented")
+}
+func (UnimplementedStockServiceServer) GetTickerHistory(*GetTickerHistoryRequest, grpc.ServerStreamingServer[Stock]) error {
+	return status.Errorf(codes.Unimplemented, "method GetTickerHistory not implemented")
+}
+func (UnimplementedStockServiceServer) GetRecommendations(*GetRecommendationsRequest, grpc.ServerStreamingServer[StockRecommendation]) error {
+	return status.Errorf(codes.Unimplemented, "method GetRecommendations not implemented")
+}
+func (UnimplementedStockServiceServer) ListBrokerages(*ListBrokeragesRequest, grpc.ServerStreamingServer[Brokerage]) error {
+	return status.Errorf(codes.Unimplemented, "method ListBrokerages not implemented")
+}
+func (UnimplementedStockServiceServer) ListActions(*ListActionsRequest, grpc.ServerStreamingServer[Action]) error {
+	return status.Errorf(codes.Unimplemented, "method ListActions not implemented")
+}
+func (UnimplementedStockServiceServer) ListRatings(*ListRatingsRequest, grpc.ServerStreamingServer[Rating]) error {
+	return status.Errorf(codes.Unimplemented, "method ListRatings not implemented")
+}
+func (UnimplementedStockServiceServer) TriggerSync(context.Context, *TriggerSyncRequest) (*TriggerSyncResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerSync not implemented")
+}
+func (UnimplementedStockServiceServer) mustEmbedUnimplementedStockServiceServer() {}
+func (UnimplementedStockServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeStockServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StockServiceServer will
+// result in compilation errors.
+type UnsafeStockServiceServer interface {
+	mustEmbedUnimplementedStockServiceServer()
+}
+
+func RegisterStockServiceServer(s grpc.ServiceRegistrar, srv StockServiceServer) {
+	// If the following call pancis, it indicates UnimplementedStockServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StockService_ServiceDesc, srv)
+}
+
+func _StockService_ListStocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListStocksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StockServiceServer).ListStocks(m, &grpc.GenericServerStream[ListStocksRequest, Stock]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockService_ListStocksServer = grpc.ServerStreamingServer[Stock]
+
+func _StockService_GetStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).GetStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_GetStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).GetStock(ctx, req.(*GetStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_GetTickerHistory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetTickerHistoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StockServiceServer).GetTickerHistory(m, &grpc.GenericServerStream[GetTickerHistoryRequest, Stock]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockService_GetTickerHistoryServer = grpc.ServerStreamingServer[Stock]
+
+func _StockService_GetRecommendations_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetRecommendationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StockServiceServer).GetRecommendations(m, &grpc.GenericServerStream[GetRecommendationsRequest, StockRecommendation]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockService_GetRecommendationsServer = grpc.ServerStreamingServer[StockRecommendation]
+
+func _StockService_ListBrokerages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListBrokeragesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StockServiceServer).ListBrokerages(m, &grpc.GenericServerStream[ListBrokeragesRequest, Brokerage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockService_ListBrokeragesServer = grpc.ServerStreamingServer[Brokerage]
+
+func _StockService_ListActions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListActionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StockServiceServer).ListActions(m, &grpc.GenericServerStream[ListActionsRequest, Action]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockService_ListActionsServer = grpc.ServerStreamingServer[Action]
+
+func _StockService_ListRatings_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRatingsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StockServiceServer).ListRatings(m, &grpc.GenericServerStream[ListRatingsRequest, Rating]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockService_ListRatingsServer = grpc.ServerStreamingServer[Rating]
+
+func _StockService_TriggerSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerSyncRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).TriggerSync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_TriggerSync_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).TriggerSync(ctx, req.(*TriggerSyncRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StockService_ServiceDesc is the grpc.ServiceDesc for StockService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StockService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "stock.v1.StockService",
+	HandlerType: (*StockServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStock",
+			Handler:    _StockService_GetStock_Handler,
+		},
+		{
+			MethodName: "TriggerSync",
+			Handler:    _StockService_TriggerSync_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListStocks",
+			Handler:       _StockService_ListStocks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetTickerHistory",
+			Handler:       _StockService_GetTickerHistory_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetRecommendations",
+			Handler:       _StockService_GetRecommendations_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListBrokerages",
+			Handler:       _StockService_ListBrokerages_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListActions",
+			Handler:       _StockService_ListActions_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListRatings",
+			Handler:       _StockService_ListRatings_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "stock/v1/stock.proto",
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/company/stock-api/internal/config"
+	"github.com/company/stock-api/internal/domain"
+	"github.com/company/stock-api/internal/export"
+	"github.com/company/stock-api/internal/repository/cockroachdb"
+	"github.com/company/stock-api/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// runCommand runs the subcommand named by args[0] instead of the server, reporting whether there was one
+func runCommand(cfg *config.Config, log *zap.Logger, args []string) (bool, error) {
+	if len(args) == 0 {
+		return false, nil
+	}
+
+	switch args[0] {
+	case "export-parquet":
+		return true, runParquetExport(cfg, log, args[1:])
+	case "import-prices":
+		return true, runPriceImport(cfg, log, args[1:])
+	case "backtest":
+		return true, runBacktest(cfg, log, args[1:])
+	default:
+		return true, fmt.Errorf("unknown command %q (available: export-parquet, import-prices, backtest)", args[0])
+	}
+}
+
+// runParquetExport implements the export-parquet subcommand:
+//
+//	stock-api export-parquet -out DIR [-since WATERMARK] [-full]
+//
+// It writes month-partitioned Parquet files to DIR. Without -since it continues from the watermark
+// stored in DIR by the previous run; -full exports every event instead.
+func runParquetExport(cfg *config.Config, log *zap.Logger, args []string) error {
+	flags := flag.NewFlagSet("export-parquet", flag.ContinueOnError)
+	out := flags.String("out", "", "directory to write month=YYYY-MM/ partitions and the watermark to (required)")
+	sinceValue := flags.String("since", "", "only export events stored after this watermark (RFC 3339); defaults to the watermark in -out")
+	full := flags.Bool("full", false, "export every event, ignoring the watermark in -out")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if *out == "" {
+		return errors.New("-out is required")
+	}
+	if *full && *sinceValue != "" {
+		return errors.New("-full and -since cannot be combined")
+	}
+
+	var since *time.Time
+	switch {
+	case *sinceValue != "":
+		watermark, err := export.ParseWatermark(*sinceValue)
+		if err != nil {
+			return err
+		}
+		since = &watermark
+	case !*full:
+		watermark, err := export.ReadWatermark(*out)
+		if err != nil {
+			return err
+		}
+		since = watermark
+	}
+
+	db, err := cockroachdb.NewConnection(&cfg.Database, cfg.Search.SimilarityThreshold)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	if err := cockroachdb.InitSchema(db); err != nil {
+		return fmt.Errorf("failed to initialize database schema: %w", err)
+	}
+
+	stockRepo := cockroachdb.NewStockRepository(db, nil, nil, nil)
+	stockUseCase := usecase.NewStockUseCase(stockRepo, nil, nil, nil, nil, nil, nil, log)
+
+	sink, err := export.NewDirSink(*out)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	until, err := stockUseCase.ExportWatermark(ctx)
+	if err != nil {
+		sink.Abort()
+		return err
+	}
+	window := export.NewWindow(since, until)
+	log.Info("Exporting stock events to Parquet", zap.String("out", *out), zap.Timep("since", since), zap.Time("until", window.Until))
+
+	summary, err := export.WriteParquet(ctx, stockUseCase.StreamEvents, window, sink)
+	if err != nil {
+		sink.Abort()
+		return err
+	}
+	if err := sink.Commit(); err != nil {
+		sink.Abort()
+		return err
+	}
+
+	for _, file := range summary.Files {
+		log.Info("Wrote Parquet file", zap.String("file", file.Name), zap.Int64("rows", file.Rows))
+	}
+	log.Info("Parquet export finished",
+		zap.Int64("rows", summary.Rows),
+		zap.Int("files", len(summary.Files)),
+		zap.String("watermark", export.FormatWatermark(summary.Watermark)))
+
+	return nil
+}
+
+// runPriceImport implements the import-prices subcommand:
+//
+//	stock-api import-prices -file FILE
+//
+// It loads daily price bars from a CSV file (or stdin for -file -) with a header naming the date, ticker
+// and close columns, plus optional open, high, low, volume and currency columns.
+func runPriceImport(cfg *config.Config, log *zap.Logger, args []string) error {
+	flags := flag.NewFlagSet("import-prices", flag.ContinueOnError)
+	file := flags.String("file", "", "CSV file to import, or - for stdin (required)")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if *file == "" {
+		return errors.New("-file is required")
+	}
+
+	input := os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return fmt.Errorf("failed to open price file: %w", err)
+		}
+		defer f.Close()
+		input = f
+	}
+
+	db, err := cockroachdb.NewConnection(&cfg.Database, cfg.Search.SimilarityThreshold)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	if err := cockroachdb.InitSchema(db); err != nil {
+		return fmt.Errorf("failed to initialize database schema: %w", err)
+	}
+
+	priceUC := usecase.NewPriceUseCase(cockroachdb.NewPriceBarRepository(db), log)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	count, err := priceUC.ImportCSV(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Price import finished", zap.String("file", *file), zap.Int("bars", count))
+	return nil
+}
+
+// runBacktest implements the backtest subcommand:
+//
+//	stock-api backtest -from DATE -to DATE [-interval week] [-horizon 30d] [-limit 10] [-strategy balanced] [-profile FILE] [-format json|csv] [-out FILE]
+//
+// It replays the recommendations on every date from -from to -to and writes the hit rates and score-decile
+// statistics to -out, or to stdout without it. -profile scores with another scoring profile than
+// SCORING_PROFILE_FILE, to compare tunings before deploying them.
+func runBacktest(cfg *config.Config, log *zap.Logger, args []string) error {
+	flags := flag.NewFlagSet("backtest", flag.ContinueOnError)
+	fromValue := flags.String("from", "", "first date to score at (RFC 3339, YYYY-MM-DD or relative like 26w) (required)")
+	toValue := flags.String("to", "", "last date to score at (RFC 3339, YYYY-MM-DD or relative like 30d) (required)")
+	intervalValue := flags.String("interval", "week", "time between scored dates (day, week, month)")
+	horizonValue := flags.String("horizon", "30d", "how long to follow each stock, like 30d or 12w")
+	limit := flags.Int("limit", 10, "number of top-scored stocks per date counted as picks")
+	strategy := flags.String("strategy", usecase.DefaultStrategy, "scoring strategy (balanced, momentum, consensus, contrarian)")
+	profile := flags.String("profile", cfg.Scoring.ProfileFile, "scoring profile file (YAML or JSON); defaults to SCORING_PROFILE_FILE")
+	format := flags.String("format", "json", "output format (json, csv)")
+	out := flags.String("out", "", "file to write the result to; defaults to stdout")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if *fromValue == "" || *toValue == "" {
+		return errors.New("-from and -to are required")
+	}
+	if *format != "json" && *format != "csv" {
+		return fmt.Errorf("unknown format %q (allowed: json, csv)", *format)
+	}
+
+	now := time.Now()
+	from, err := usecase.ParseTimeBound(*fromValue, now)
+	if err != nil {
+		return fmt.Errorf("-from: %w", err)
+	}
+	to, err := usecase.ParseTimeBound(*toValue, now)
+	if err != nil {
+		return fmt.Errorf("-to: %w", err)
+	}
+	horizon, err := usecase.ParseRelativeDuration(*horizonValue)
+	if err != nil {
+		return fmt.Errorf("-horizon: %w", err)
+	}
+	interval, ok := domain.ParseAnalyticsInterval(*intervalValue)
+	if !ok {
+		return fmt.Errorf("unknown interval %q (allowed: day, week, month)", *intervalValue)
+	}
+	scoring, err := usecase.NewScoringProfileStore(*profile, log)
+	if err != nil {
+		return fmt.Errorf("-profile: %w", err)
+	}
+
+	db, err := cockroachdb.NewConnection(&cfg.Database, cfg.Search.SimilarityThreshold)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	if err := cockroachdb.InitSchema(db); err != nil {
+		return fmt.Errorf("failed to initialize database schema: %w", err)
+	}
+
+	brokerageRepo := cockroachdb.NewBrokerageRepository(db)
+	actionRepo := cockroachdb.NewActionRepository(db)
+	ratingRepo := cockroachdb.NewRatingRepository(db)
+	stockRepo := cockroachdb.NewStockRepository(db, brokerageRepo, actionRepo, ratingRepo)
+	priceUC := usecase.NewPriceUseCase(cockroachdb.NewPriceBarRepository(db), log)
+	stockUseCase := usecase.NewStockUseCase(stockRepo, nil, nil, nil, nil, priceUC, scoring, log)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	result, err := stockUseCase.Backtest(ctx, domain.BacktestQuery{
+		From:     from,
+		To:       to,
+		Interval: interval,
+		Horizon:  horizon,
+		Limit:    *limit,
+		Strategy: *strategy,
+	})
+	if err != nil {
+		return err
+	}
+
+	output := os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		output = f
+	}
+
+	if *format == "csv" {
+		err = usecase.WriteBacktestCSV(output, result)
+	} else {
+		encoder := json.NewEncoder(output)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(result)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write backtest result: %w", err)
+	}
+
+	log.Info("Backtest finished",
+		zap.Int("dates", len(result.Dates)),
+		zap.Int("picks", result.Picks.Scored),
+		zap.Float64p("pick_hit_rate", result.Picks.HitRate),
+		zap.Float64p("universe_hit_rate", result.Universe.HitRate))
+	return nil
+}
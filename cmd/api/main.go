@@ -5,11 +5,17 @@
 //	@description	API for managing stock data from external sources
 //	@host			localhost:8080
 //	@BasePath		/
+//
+//	@securityDefinitions.apikey	AdminAPIKey
+//	@in							header
+//	@name						Authorization
+//	@description				Admin API key, sent as "Bearer <key>"
 package main
 
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -19,6 +25,9 @@ import (
 	_ "github.com/company/stock-api/docs"
 	"github.com/company/stock-api/internal/client"
 	"github.com/company/stock-api/internal/config"
+	"github.com/company/stock-api/internal/domain"
+	"github.com/company/stock-api/internal/graphql"
+	"github.com/company/stock-api/internal/grpcapi"
 	"github.com/company/stock-api/internal/handler"
 	"github.com/company/stock-api/internal/repository/cockroachdb"
 	"github.com/company/stock-api/internal/router"
@@ -43,12 +52,20 @@ func main() {
 	}
 	defer log.Sync()
 
+	// Subcommands such as export-parquet and import-prices run instead of the server
+	if ran, err := runCommand(cfg, log, os.Args[1:]); ran {
+		if err != nil {
+			log.Fatal("Command failed", zap.String("command", os.Args[1]), zap.Error(err))
+		}
+		return
+	}
+
 	log.Info("Starting Stock API service",
 		zap.String("env", cfg.Server.Env),
 		zap.String("port", cfg.Server.Port))
 
 	// Initialize database connection
-	db, err := cockroachdb.NewConnection(&cfg.Database)
+	db, err := cockroachdb.NewConnection(&cfg.Database, cfg.Search.SimilarityThreshold)
 	if err != nil {
 		log.Fatal("Failed to connect to database", zap.Error(err))
 	}
@@ -67,6 +84,9 @@ func main() {
 	brokerageRepo := cockroachdb.NewBrokerageRepository(db)
 	actionRepo := cockroachdb.NewActionRepository(db)
 	ratingRepo := cockroachdb.NewRatingRepository(db)
+	fxRateRepo := cockroachdb.NewFXRateRepository(db)
+	priceBarRepo := cockroachdb.NewPriceBarRepository(db)
+	searchRepo := cockroachdb.NewSearchRepository(db)
 	stockRepo := cockroachdb.NewStockRepository(db, brokerageRepo, actionRepo, ratingRepo)
 
 	// Initialize API client
@@ -74,15 +94,67 @@ func main() {
 
 	// Initialize use cases
 	brokerageUC := usecase.NewBrokerageUseCase(brokerageRepo, log)
-	actionUC := usecase.NewActionUseCase(actionRepo, log)
+	var actionRules []domain.ActionRule
+	if cfg.Taxonomy.ActionRulesFile != "" {
+		actionRules, err = usecase.LoadActionRules(cfg.Taxonomy.ActionRulesFile)
+		if err != nil {
+			log.Fatal("Failed to load action rules", zap.Error(err))
+		}
+	}
+	actionUC := usecase.NewActionUseCase(actionRepo, actionRules, log)
 	ratingUC := usecase.NewRatingUseCase(ratingRepo, log)
-	stockUseCase := usecase.NewStockUseCase(stockRepo, stockAPIClient, brokerageUC, actionUC, ratingUC, log)
+	fxUC := usecase.NewFXUseCase(fxRateRepo, log)
+	priceUC := usecase.NewPriceUseCase(priceBarRepo, log)
+	searchUC := usecase.NewSearchUseCase(searchRepo, cfg.Search.SimilarityThreshold, log)
+	scoring, err := usecase.NewScoringProfileStore(cfg.Scoring.ProfileFile, log)
+	if err != nil {
+		log.Fatal("Failed to load scoring profile", zap.Error(err))
+	}
+	stockUseCase := usecase.NewStockUseCase(stockRepo, stockAPIClient, brokerageUC, actionUC, ratingUC, priceUC, scoring, log)
+
+	// Reload the scoring profile when its file changes or on SIGHUP
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	defer stopWatching()
+	go scoring.Watch(watchCtx, cfg.Scoring.ReloadInterval)
+	go func() {
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		for range hup {
+			if err := scoring.Reload(); err != nil {
+				log.Error("Failed to reload scoring profile; keeping the active profile", zap.Error(err))
+			}
+		}
+	}()
+
+	// Classify actions stored before the action taxonomy existed
+	if _, err := actionUC.ClassifyUncategorized(context.Background()); err != nil {
+		log.Warn("Failed to classify existing actions", zap.Error(err))
+	}
+
+	// Map known rating terms onto the canonical rating scale
+	if _, err := ratingUC.SeedDefaultScale(context.Background()); err != nil {
+		log.Warn("Failed to seed default rating scale", zap.Error(err))
+	}
+
+	// Parse numeric price targets for stocks stored before they existed
+	if _, err := stockUseCase.BackfillTargetAmounts(context.Background()); err != nil {
+		log.Warn("Failed to backfill numeric price targets", zap.Error(err))
+	}
 
 	// Initialize handler
-	stockHandler := handler.NewStockHandler(stockUseCase, brokerageUC, actionUC, ratingUC, log)
+	stockHandler := handler.NewStockHandler(stockUseCase, brokerageUC, actionUC, ratingUC, fxUC, priceUC, searchUC, log)
+
+	graphqlHandler, err := graphql.NewHandler(
+		graphql.NewResolver(stockUseCase, brokerageUC, actionUC, ratingUC),
+		graphql.Limits{MaxDepth: cfg.GraphQL.MaxDepth, MaxComplexity: cfg.GraphQL.MaxComplexity},
+		log,
+	)
+	if err != nil {
+		log.Fatal("Failed to initialize GraphQL handler", zap.Error(err))
+	}
 
 	// Setup router
-	r := router.SetupRouter(stockHandler, log)
+	r := router.SetupRouter(stockHandler, graphqlHandler, cfg.Server.AdminAPIKey, log)
 
 	// Configure HTTP server
 	addr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
@@ -102,6 +174,20 @@ func main() {
 		}
 	}()
 
+	// Start gRPC server on its own port
+	grpcAddr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.GRPCPort)
+	grpcListener, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		log.Fatal("Failed to listen for gRPC", zap.String("address", grpcAddr), zap.Error(err))
+	}
+	grpcServer := grpcapi.NewServer(grpcapi.NewStockServer(stockUseCase, brokerageUC, actionUC, ratingUC, log), log)
+	go func() {
+		log.Info("gRPC server started", zap.String("address", grpcAddr))
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			log.Fatal("Failed to start gRPC server", zap.Error(err))
+		}
+	}()
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -113,9 +199,23 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// Let in-flight gRPC calls finish, cancelling them if they outlast the timeout
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatal("Server forced to shutdown", zap.Error(err))
 	}
 
+	select {
+	case <-grpcStopped:
+	case <-ctx.Done():
+		log.Warn("gRPC server forced to stop")
+		grpcServer.Stop()
+	}
+
 	log.Info("Server exited")
 }
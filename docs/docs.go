@@ -17,7 +17,7 @@ const docTemplate = `{
     "paths": {
         "/api/v1/actions": {
             "get": {
-                "description": "Retrieves all analyst actions",
+                "description": "Retrieves all analyst actions with their canonical category and direction",
                 "consumes": [
                     "application/json"
                 ],
@@ -92,11 +92,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/brokerages": {
-            "get": {
-                "description": "Retrieves all brokerage firms",
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminAPIKey": []
+                    }
+                ],
+                "description": "Assigns a canonical category (upgrade, downgrade, initiate, reiterate, target_raised, target_lowered, other) and a direction (up, down, neutral) to an action. The direction defaults to the category's direction.",
                 "consumes": [
                     "application/json"
                 ],
@@ -104,9 +107,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "brokerages"
+                    "actions"
+                ],
+                "summary": "Set the category of an action",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Action ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Category and optional direction",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateActionCategoryRequest"
+                        }
+                    }
                 ],
-                "summary": "Get all brokerages",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -114,6 +135,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.Response"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -123,9 +162,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/brokerages/{id}": {
+        "/api/v1/analytics/events": {
             "get": {
-                "description": "Retrieves a single brokerage firm by ID",
+                "description": "Counts the events matching the filters per day, week or month, optionally split by action, action category, brokerage, rating_to or ticker, with the average target change per bucket. Buckets without events are filled with zero counts. Takes the same filters as GET /api/v1/stocks; every matching event is counted unless view is given.",
                 "consumes": [
                     "application/json"
                 ],
@@ -133,23 +172,165 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "brokerages"
+                    "analytics"
                 ],
-                "summary": "Get a brokerage by ID",
+                "summary": "Get time-bucketed event analytics",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Split the counts into one series per value (action, action_category, brokerage, rating_to, ticker)",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "week",
+                        "description": "Bucket width in UTC; weeks start on Monday (day, week, month)",
+                        "name": "interval",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Brokerage ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 20,
+                        "description": "Maximum number of series, largest first (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "history",
+                        "description": "Events to count (history, latest, by_brokerage)",
+                        "name": "view",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by ticker; repeat or comma-separate for several (use not_ticker or ticker!= to exclude)",
+                        "name": "ticker",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by company name (partial match)",
+                        "name": "company",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by brokerage name (partial match); repeat or comma-separate for several (use not_brokerage or brokerage!= to exclude)",
+                        "name": "brokerage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by action; repeat or comma-separate for several (use not_action or action!= to exclude)",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by action category (upgrade, downgrade, initiate, reiterate, target_raised, target_lowered, other)",
+                        "name": "action_category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by rating_from; repeat or comma-separate for several (use not_rating_from or rating_from!= to exclude)",
+                        "name": "rating_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by rating_to; repeat or comma-separate for several (use not_rating_to or rating_to!= to exclude)",
+                        "name": "rating_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by canonical bucket of rating_to (strong_sell, sell, hold, buy, strong_buy)",
+                        "name": "rating_bucket",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum numeric target_to",
+                        "name": "target_to_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum numeric target_to",
+                        "name": "target_to_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum percentage change from target_from to target_to",
+                        "name": "target_change_pct_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events at or after this time; also the first bucket (RFC 3339, YYYY-MM-DD or relative like 7d)",
+                        "name": "time_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events at or before this time; also the last bucket (RFC 3339, YYYY-MM-DD or relative like 7d)",
+                        "name": "time_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events at or before this time (RFC 3339, YYYY-MM-DD or relative like 30d)",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression, e.g. rating_to in ('Buy','Outperform') and target_change_pct \u003e 15 and time \u003e now-30d",
+                        "name": "q",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.EventAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -158,12 +339,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handler.Response"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -173,9 +348,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/ratings": {
+        "/api/v1/analytics/rating-transitions": {
             "get": {
-                "description": "Retrieves all rating terms with brokerage information",
+                "description": "Counts the events matching the filters per rating_from (rows) and rating_to (columns), as canonical buckets or raw terms, with the non-empty cells listed most frequent first and classified as upgrade, downgrade or unchanged. Takes the same filters as GET /api/v1/stocks; every matching event is counted unless view is given.",
                 "consumes": [
                     "application/json"
                 ],
@@ -183,12 +358,106 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ratings"
+                    "analytics"
+                ],
+                "summary": "Get the rating transition matrix",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "bucket",
+                        "description": "Compare canonical buckets or raw rating terms (bucket, term)",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "history",
+                        "description": "Events to count (history, latest, by_brokerage)",
+                        "name": "view",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by ticker; repeat or comma-separate for several (use not_ticker or ticker!= to exclude)",
+                        "name": "ticker",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by brokerage name (partial match); repeat or comma-separate for several (use not_brokerage or brokerage!= to exclude)",
+                        "name": "brokerage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by action; repeat or comma-separate for several (use not_action or action!= to exclude)",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by action category (upgrade, downgrade, initiate, reiterate, target_raised, target_lowered, other)",
+                        "name": "action_category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events at or after this time (RFC 3339, YYYY-MM-DD or relative like 7d)",
+                        "name": "time_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events at or before this time (RFC 3339, YYYY-MM-DD or relative like 7d)",
+                        "name": "time_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events at or before this time (RFC 3339, YYYY-MM-DD or relative like 30d)",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression, e.g. rating_to in ('Buy','Outperform') and target_change_pct \u003e 15 and time \u003e now-30d",
+                        "name": "q",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all ratings",
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.RatingTransitionMatrix"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/handler.Response"
                         }
@@ -202,9 +471,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/ratings/{id}": {
+        "/api/v1/brokerages": {
             "get": {
-                "description": "Retrieves a single rating by ID",
+                "description": "Retrieves all brokerage firms",
                 "consumes": [
                     "application/json"
                 ],
@@ -212,18 +481,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ratings"
-                ],
-                "summary": "Get a rating by ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Rating ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "brokerages"
                 ],
+                "summary": "Get all brokerages",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -231,18 +491,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.Response"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handler.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handler.Response"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -252,9 +500,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/recommendations": {
+        "/api/v1/brokerages/leaderboard": {
             "get": {
-                "description": "Analyzes stock data and returns the best investment recommendations based on ratings, actions, target prices, and recency",
+                "description": "Ranks brokerages by their number of events within the window, with the tickers they covered, their upgrades and downgrades, average target change and latest event",
                 "consumes": [
                     "application/json"
                 ],
@@ -262,14 +510,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stocks"
+                    "brokerages"
                 ],
-                "summary": "Get stock recommendations",
+                "summary": "Get the brokerage activity leaderboard",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "default": "30d",
+                        "description": "Start of the window, relative to now like 30d or 12w, or an RFC 3339 time or YYYY-MM-DD date",
+                        "name": "window",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "default": 10,
-                        "description": "Number of recommendations to return",
+                        "default": 20,
+                        "description": "Number of brokerages to return (max 100)",
                         "name": "limit",
                         "in": "query"
                     }
@@ -277,6 +532,27 @@ const docTemplate = `{
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.BrokerageLeaderboardEntry"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/handler.Response"
                         }
@@ -290,9 +566,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/stock/{ticker}": {
+        "/api/v1/brokerages/{id}": {
             "get": {
-                "description": "Retrieves all stock records for a given ticker symbol, ordered by time (newest first)",
+                "description": "Retrieves a single brokerage firm by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -300,14 +576,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stocks"
+                    "brokerages"
                 ],
-                "summary": "Get all historical versions of a stock by ticker",
+                "summary": "Get a brokerage by ID",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Stock ticker symbol (e.g., AAPL, GOOGL)",
-                        "name": "ticker",
+                        "type": "integer",
+                        "description": "Brokerage ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -319,6 +595,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.Response"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -334,9 +616,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/stocks": {
+        "/api/v1/brokerages/{id}/profile": {
             "get": {
-                "description": "Retrieves stocks with optional filtering and pagination",
+                "description": "Retrieves a brokerage with its activity over its full history (tickers covered, events by action category, upgrade/downgrade ratio, average target change, first and last event) and its most recent events",
                 "consumes": [
                     "application/json"
                 ],
@@ -344,80 +626,217 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stocks"
+                    "brokerages"
                 ],
-                "summary": "Get stocks",
+                "summary": "Get a brokerage's profile",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Filter by ticker",
-                        "name": "ticker",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Brokerage ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Filter by company name (partial match)",
-                        "name": "company",
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of recent events to return (max 100)",
+                        "name": "recent",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.BrokerageProfile"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Filter by brokerage name (partial match)",
-                        "name": "brokerage",
-                        "in": "query"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Filter by action",
-                        "name": "action",
-                        "in": "query"
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/fx-rates": {
+            "get": {
+                "description": "Retrieves stored FX rates (value of one unit of currency in USD per date), newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fx"
+                ],
+                "summary": "Get FX rates",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by rating_from",
-                        "name": "rating_from",
+                        "description": "Only rates for this currency",
+                        "name": "currency",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Filter by rating_to",
-                        "name": "rating_to",
-                        "in": "query"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
                     {
-                        "type": "string",
-                        "default": "time",
-                        "description": "Sort by field (ticker, company, time, rating_to, action)",
-                        "name": "sortBy",
-                        "in": "query"
-                    },
+                        "AdminAPIKey": []
+                    }
+                ],
+                "description": "Loads FX rates from a CSV body (Content-Type text/csv, header \"date,currency,usd_rate\") or a JSON array. Rates for an existing currency and date are replaced.",
+                "consumes": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fx"
+                ],
+                "summary": "Import FX rates",
+                "parameters": [
                     {
-                        "type": "string",
-                        "default": "desc",
-                        "description": "Sort order (asc, desc)",
-                        "name": "sortOrder",
-                        "in": "query"
+                        "description": "FX rates",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.FXRate"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
                     },
-                    {
-                        "type": "integer",
-                        "default": 50,
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/prices": {
+            "post": {
+                "security": [
                     {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Number of items to skip",
-                        "name": "offset",
-                        "in": "query"
+                        "AdminAPIKey": []
+                    }
+                ],
+                "description": "Loads daily price bars from a CSV body (Content-Type text/csv, header with date, ticker and close, plus optional open, high, low, volume and currency) or a JSON array. Bars for an existing ticker and date are replaced.",
+                "consumes": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Import daily price bars",
+                "parameters": [
+                    {
+                        "description": "Price bars",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.PriceBar"
+                            }
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.PaginatedResponse"
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
                         }
                     },
                     "500": {
@@ -429,9 +848,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/stocks/sync": {
-            "post": {
-                "description": "Fetches all stocks from the external API and stores them in the database",
+        "/api/v1/ratings": {
+            "get": {
+                "description": "Retrieves all rating terms with their canonical bucket and score",
                 "consumes": [
                     "application/json"
                 ],
@@ -439,9 +858,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stocks"
+                    "ratings"
                 ],
-                "summary": "Sync stocks from external API",
+                "summary": "Get all ratings",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -458,9 +877,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/stocks/{id}": {
+        "/api/v1/ratings/quality": {
             "get": {
-                "description": "Retrieves a single stock by its ID",
+                "description": "Lists rating terms that are not mapped to a canonical bucket, with how many events use them",
                 "consumes": [
                     "application/json"
                 ],
@@ -468,13 +887,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stocks"
+                    "ratings"
                 ],
-                "summary": "Get stock by ID",
+                "summary": "Rating data quality report",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/ratings/{id}": {
+            "get": {
+                "description": "Retrieves a single rating by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ratings"
+                ],
+                "summary": "Get a rating by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Stock ID",
+                        "description": "Rating ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -506,11 +954,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/health": {
-            "get": {
-                "description": "Check if the API is healthy",
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminAPIKey": []
+                    }
+                ],
+                "description": "Assigns a canonical bucket (strong_sell, sell, hold, buy, strong_buy) and a 1-5 score to a rating term. The score defaults to the bucket's position on the scale.",
                 "consumes": [
                     "application/json"
                 ],
@@ -518,23 +969,2436 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "system"
+                    "ratings"
+                ],
+                "summary": "Map a rating onto the canonical scale",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rating ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Canonical bucket and optional score",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateRatingScaleRequest"
+                        }
+                    }
                 ],
-                "summary": "Health check",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/handler.Response"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handler.MetaData": {
-            "type": "object",
+        },
+        "/api/v1/recommendations": {
+            "get": {
+                "description": "Analyzes stock data and returns the best investment recommendations based on ratings, actions, target prices, and recency.\nEach recommendation breaks its score down into factors with their raw value, score, weight and contribution.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get stock recommendations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of recommendations to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. ticker,rating_to,target_to (default: all)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Score the stocks as they were at this time, with recency relative to it (RFC 3339, YYYY-MM-DD or relative like 30d)",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Convert price targets to this reporting currency (e.g. USD)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "balanced",
+                        "description": "Scoring strategy (balanced, momentum, consensus, contrarian); see /api/v1/recommendations/strategies",
+                        "name": "strategy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Include the profile rule each factor score came from",
+                        "name": "explain",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recommendations/backtest": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAPIKey": []
+                    }
+                ],
+                "description": "Replays the recommendations on every date from from to to, scoring only the events available at each date, and follows every scored stock for the horizon. A stock is a hit when later upgrades and target raises by other brokerages outnumber their downgrades and target cuts, and a miss when they are outnumbered. Returns the hit rates of the top picks and of every scored stock, statistics per score decile, and the picks themselves; format=csv returns only the statistics. Requires the admin API key, since every date is a full scoring pass; the backtest CLI subcommand runs it offline.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Backtest the recommendation algorithm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First date to score at (RFC 3339, YYYY-MM-DD or relative like 26w); defaults to 26 weeks before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last date to score at (RFC 3339, YYYY-MM-DD or relative like 30d); defaults to one horizon before now",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "week",
+                        "description": "Time between scored dates (day, week, month)",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "30d",
+                        "description": "How long to follow each stock, like 30d or 12w (max 365d)",
+                        "name": "horizon",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of top-scored stocks per date counted as picks (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "balanced",
+                        "description": "Scoring strategy to backtest (balanced, momentum, consensus, contrarian)",
+                        "name": "strategy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "json",
+                        "description": "Response format (json, csv)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.BacktestResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recommendations/profile": {
+            "get": {
+                "description": "Returns the factor weights, action scores, recency buckets and brokerage tiers recommendations are currently scored with, the file they were loaded from ('default' for the built-in profile) and when",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get the active scoring profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ActiveScoringProfile"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recommendations/strategies": {
+            "get": {
+                "description": "Lists the scoring strategies /api/v1/recommendations and the backtest accept in strategy, and which one is the default",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "List recommendation strategies",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.ScoringStrategyInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search": {
+            "get": {
+                "description": "Free-text search ranked by trigram similarity. Each result names the best matching field and highlights the match with \u003cmark\u003e tags.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search tickers, companies and brokerages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text (at most 100 characters)",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of results (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search/suggest": {
+            "get": {
+                "description": "Prefix completions for search boxes: tickers first, then company and brokerage names matching at the start of a word",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Typeahead suggestions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Prefix typed so far",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of suggestions (max 25)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stock/{ticker}": {
+            "get": {
+                "description": "Retrieves all stock records for a given ticker symbol, ordered by time (newest first)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get all historical versions of a stock by ticker",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock ticker symbol (e.g., AAPL, GOOGL)",
+                        "name": "ticker",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events at or after this time (RFC 3339, YYYY-MM-DD or relative like 7d)",
+                        "name": "time_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. ticker,rating_to,target_to (default: all)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events at or before this time (RFC 3339, YYYY-MM-DD or relative like 7d)",
+                        "name": "time_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Convert price targets to this reporting currency (e.g. USD)",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stock/{ticker}/consensus": {
+            "get": {
+                "description": "Summarizes the latest rating and the latest price target of each brokerage covering the ticker within the window: rating distribution by canonical bucket, mean/median/high/low price target with standard deviation, number of covering brokerages, and net upgrades minus downgrades over the window.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get the analyst consensus for a ticker",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock ticker symbol (e.g., AAPL, GOOGL)",
+                        "name": "ticker",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "90d",
+                        "description": "Start of the window, relative to now like 90d or 12w, or an RFC 3339 time or YYYY-MM-DD date",
+                        "name": "window",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Consensus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stock/{ticker}/prices": {
+            "get": {
+                "description": "Retrieves the daily open, high, low and close of a ticker, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get daily price bars for a ticker",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock ticker symbol (e.g., AAPL, GOOGL)",
+                        "name": "ticker",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only bars on or after this date (RFC 3339, YYYY-MM-DD or relative like 90d)",
+                        "name": "time_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only bars on or before this date (RFC 3339, YYYY-MM-DD or relative like 7d)",
+                        "name": "time_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 250,
+                        "description": "Maximum number of bars (max 5000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.PriceBar"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stock/{ticker}/timeline": {
+            "get": {
+                "description": "Retrieves a ticker's events, each annotated with what changed since the previous event by any brokerage (since_previous) and by the same brokerage (since_brokerage): rating movement, target delta and percentage change, and action. Filters select which events are returned; changes are always relative to the full history. An unknown ticker returns 404; filters matching none of a known ticker's events return an empty page.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get a ticker's timeline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock ticker symbol (e.g., AAPL, GOOGL)",
+                        "name": "ticker",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by brokerage name (partial match); repeat or comma-separate for several (use not_brokerage or brokerage!= to exclude)",
+                        "name": "brokerage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events at or after this time (RFC 3339, YYYY-MM-DD or relative like 7d)",
+                        "name": "time_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events at or before this time (RFC 3339, YYYY-MM-DD or relative like 7d)",
+                        "name": "time_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort order by time (asc, desc)",
+                        "name": "sortOrder",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.TimelineEvent"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks": {
+            "get": {
+                "description": "Retrieves the latest stock per ticker (or per ticker and brokerage with view=by_brokerage) with optional filtering and pagination. Time filters select which events are considered before picking the latest one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get stocks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "latest",
+                        "description": "Rows to return: latest (latest event per ticker), by_brokerage (latest event per ticker and brokerage) or history (every event)",
+                        "name": "view",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by ticker; repeat or comma-separate for several (use not_ticker or ticker!= to exclude)",
+                        "name": "ticker",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by company name (partial match)",
+                        "name": "company",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by brokerage name (partial match); repeat or comma-separate for several (use not_brokerage or brokerage!= to exclude)",
+                        "name": "brokerage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by action; repeat or comma-separate for several (use not_action or action!= to exclude)",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by action category (upgrade, downgrade, initiate, reiterate, target_raised, target_lowered, other)",
+                        "name": "action_category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by rating_from; repeat or comma-separate for several (use not_rating_from or rating_from!= to exclude)",
+                        "name": "rating_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by rating_to; repeat or comma-separate for several (use not_rating_to or rating_to!= to exclude)",
+                        "name": "rating_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by canonical bucket of rating_to (strong_sell, sell, hold, buy, strong_buy)",
+                        "name": "rating_bucket",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum numeric target_to",
+                        "name": "target_to_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum numeric target_to",
+                        "name": "target_to_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum percentage change from target_from to target_to",
+                        "name": "target_change_pct_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events at or after this time (RFC 3339, YYYY-MM-DD or relative like 7d)",
+                        "name": "time_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events at or before this time (RFC 3339, YYYY-MM-DD or relative like 7d)",
+                        "name": "time_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Show the data as it was at this time: only events at or before it are considered (RFC 3339, YYYY-MM-DD or relative like 30d)",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression, e.g. rating_to in ('Buy','Outperform') and target_change_pct \u003e 15 and time \u003e now-30d",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. ticker,rating_to,target_to (default: all)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Convert price targets to this reporting currency (e.g. USD)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "time",
+                        "description": "Sort by field (ticker, company, time, rating_to, action)",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort order (asc, desc)",
+                        "name": "sortOrder",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/export": {
+            "get": {
+                "description": "Streams every stock matching the filters as CSV or NDJSON, without pagination. view=latest exports the latest event per ticker, view=by_brokerage the latest event per ticker and brokerage, view=history every matching event. Takes the same filters as GET /api/v1/stocks.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Export stocks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Export format (csv, ndjson)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "latest",
+                        "description": "Events to export (latest, by_brokerage, history)",
+                        "name": "view",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by ticker; repeat or comma-separate for several (use not_ticker or ticker!= to exclude)",
+                        "name": "ticker",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by company name (partial match)",
+                        "name": "company",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by brokerage name (partial match); repeat or comma-separate for several (use not_brokerage or brokerage!= to exclude)",
+                        "name": "brokerage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by action; repeat or comma-separate for several (use not_action or action!= to exclude)",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by action category (upgrade, downgrade, initiate, reiterate, target_raised, target_lowered, other)",
+                        "name": "action_category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by rating_from; repeat or comma-separate for several (use not_rating_from or rating_from!= to exclude)",
+                        "name": "rating_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by rating_to; repeat or comma-separate for several (use not_rating_to or rating_to!= to exclude)",
+                        "name": "rating_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by canonical bucket of rating_to (strong_sell, sell, hold, buy, strong_buy)",
+                        "name": "rating_bucket",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum numeric target_to",
+                        "name": "target_to_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum numeric target_to",
+                        "name": "target_to_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum percentage change from target_from to target_to",
+                        "name": "target_change_pct_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events at or after this time (RFC 3339, YYYY-MM-DD or relative like 7d)",
+                        "name": "time_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events at or before this time (RFC 3339, YYYY-MM-DD or relative like 7d)",
+                        "name": "time_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Show the data as it was at this time: only events at or before it are considered (RFC 3339, YYYY-MM-DD or relative like 30d)",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression, e.g. rating_to in ('Buy','Outperform') and target_change_pct \u003e 15 and time \u003e now-30d",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields (columns) to export (default: all except converted)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "time",
+                        "description": "Sort by field (ticker, company, time, rating_to, action)",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort order (asc, desc)",
+                        "name": "sortOrder",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of rows (default: all)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of rows to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV or NDJSON rows",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/export/parquet": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAPIKey": []
+                    }
+                ],
+                "description": "Streams a zip archive of Parquet files with every stored stock event and its brokerage, action and rating names, partitioned by month of the event time (month=YYYY-MM/). Pass the watermark of the previous export as since to only export events stored after it. The archive ends with a _watermark file, also returned in the X-Export-Watermark header. Requires the admin API key.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Export stock events as Parquet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only events stored after this watermark (RFC 3339)",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Zip archive of Parquet files",
+                        "schema": {
+                            "type": "file"
+                        },
+                        "headers": {
+                            "X-Export-Watermark": {
+                                "type": "string",
+                                "description": "Watermark to pass as since on the next export"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/sync": {
+            "post": {
+                "description": "Fetches all stocks from the external API and stores them in the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Sync stocks from external API",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/{id}": {
+            "get": {
+                "description": "Retrieves a single stock by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get stock by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Stock ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/graphql": {
+            "post": {
+                "description": "Executes a GraphQL query over stocks, brokerages, actions, ratings and recommendations.\nQueries exceeding the configured depth or complexity limits are rejected with 400.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graphql"
+                ],
+                "summary": "GraphQL endpoint",
+                "parameters": [
+                    {
+                        "description": "GraphQL request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/graphql.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Check if the API is healthy",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "system"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.ActionCategory": {
+            "type": "string",
+            "enum": [
+                "upgrade",
+                "downgrade",
+                "initiate",
+                "reiterate",
+                "target_raised",
+                "target_lowered",
+                "other"
+            ],
+            "x-enum-varnames": [
+                "ActionCategoryUpgrade",
+                "ActionCategoryDowngrade",
+                "ActionCategoryInitiate",
+                "ActionCategoryReiterate",
+                "ActionCategoryTargetRaised",
+                "ActionCategoryTargetLowered",
+                "ActionCategoryOther"
+            ]
+        },
+        "domain.ActionDirection": {
+            "type": "string",
+            "enum": [
+                "up",
+                "down",
+                "neutral"
+            ],
+            "x-enum-varnames": [
+                "ActionDirectionUp",
+                "ActionDirectionDown",
+                "ActionDirectionNeutral"
+            ]
+        },
+        "domain.ActiveScoringProfile": {
+            "type": "object",
+            "properties": {
+                "loaded_at": {
+                    "type": "string"
+                },
+                "profile": {
+                    "$ref": "#/definitions/domain.ScoringProfile"
+                },
+                "source": {
+                    "description": "Source is the profile file, or \"default\" for the built-in profile",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AnalyticsGroupBy": {
+            "type": "string",
+            "enum": [
+                "action",
+                "action_category",
+                "brokerage",
+                "rating_to",
+                "ticker"
+            ],
+            "x-enum-varnames": [
+                "AnalyticsGroupByAction",
+                "AnalyticsGroupByActionCategory",
+                "AnalyticsGroupByBrokerage",
+                "AnalyticsGroupByRatingTo",
+                "AnalyticsGroupByTicker"
+            ]
+        },
+        "domain.AnalyticsInterval": {
+            "type": "string",
+            "enum": [
+                "day",
+                "week",
+                "month"
+            ],
+            "x-enum-varnames": [
+                "AnalyticsIntervalDay",
+                "AnalyticsIntervalWeek",
+                "AnalyticsIntervalMonth"
+            ]
+        },
+        "domain.BacktestOutcome": {
+            "type": "string",
+            "enum": [
+                "hit",
+                "miss",
+                "neutral",
+                "no_events"
+            ],
+            "x-enum-varnames": [
+                "BacktestOutcomeHit",
+                "BacktestOutcomeMiss",
+                "BacktestOutcomeNeutral",
+                "BacktestOutcomeNoEvents"
+            ]
+        },
+        "domain.BacktestPick": {
+            "type": "object",
+            "properties": {
+                "as_of": {
+                    "type": "string"
+                },
+                "avg_target_change_pct": {
+                    "description": "AvgTargetChangePct averages the target_from to target_to change of the later events with both targets",
+                    "type": "number"
+                },
+                "brokerage": {
+                    "type": "string"
+                },
+                "downgrades": {
+                    "type": "integer"
+                },
+                "later_events": {
+                    "description": "LaterEvents counts the events of other brokerages within the horizon",
+                    "type": "integer"
+                },
+                "outcome": {
+                    "$ref": "#/definitions/domain.BacktestOutcome"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "target_cuts": {
+                    "type": "integer"
+                },
+                "target_raises": {
+                    "type": "integer"
+                },
+                "ticker": {
+                    "type": "string"
+                },
+                "upgrades": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.BacktestResult": {
+            "type": "object",
+            "properties": {
+                "dates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "deciles": {
+                    "description": "Deciles splits every date's scored stocks by score rank; the first decile has the highest scores",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.BacktestStats"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "horizon_days": {
+                    "type": "integer"
+                },
+                "interval": {
+                    "$ref": "#/definitions/domain.AnalyticsInterval"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "pick_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.BacktestPick"
+                    }
+                },
+                "picks": {
+                    "description": "Picks summarizes the top Limit stocks per date, Universe every scored stock",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.BacktestStats"
+                        }
+                    ]
+                },
+                "strategy": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "universe": {
+                    "$ref": "#/definitions/domain.BacktestStats"
+                }
+            }
+        },
+        "domain.BacktestStats": {
+            "type": "object",
+            "properties": {
+                "avg_net_signal": {
+                    "description": "AvgNetSignal averages positive minus negative signals per scored stock",
+                    "type": "number"
+                },
+                "avg_target_change_pct": {
+                    "type": "number"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "hit_rate": {
+                    "description": "HitRate is hits / (hits + misses); nil when there were neither",
+                    "type": "number"
+                },
+                "hits": {
+                    "type": "integer"
+                },
+                "max_score": {
+                    "type": "number"
+                },
+                "min_score": {
+                    "type": "number"
+                },
+                "misses": {
+                    "type": "integer"
+                },
+                "neutral": {
+                    "type": "integer"
+                },
+                "no_events": {
+                    "type": "integer"
+                },
+                "scored": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.BrokerageActivity": {
+            "type": "object",
+            "properties": {
+                "avg_target_change_pct": {
+                    "description": "AvgTargetChangePct averages the change from target_from to target_to of the events with both targets",
+                    "type": "number"
+                },
+                "downgrades": {
+                    "type": "integer"
+                },
+                "events": {
+                    "type": "integer"
+                },
+                "events_by_category": {
+                    "description": "EventsByCategory counts events per action category; events without an action count as other",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "first_seen": {
+                    "type": "string"
+                },
+                "last_seen": {
+                    "type": "string"
+                },
+                "tickers_covered": {
+                    "type": "integer"
+                },
+                "upgrade_downgrade_ratio": {
+                    "description": "UpgradeDowngradeRatio is upgrades divided by downgrades; nil without downgrades",
+                    "type": "number"
+                },
+                "upgrades": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.BrokerageLeaderboardEntry": {
+            "type": "object",
+            "properties": {
+                "avg_target_change_pct": {
+                    "type": "number"
+                },
+                "brokerage_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "downgrades": {
+                    "type": "integer"
+                },
+                "events": {
+                    "type": "integer"
+                },
+                "last_seen": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "tickers_covered": {
+                    "type": "integer"
+                },
+                "upgrades": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.BrokerageProfile": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "activity": {
+                    "$ref": "#/definitions/domain.BrokerageActivity"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "recent_events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.StockWithDetails"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.BrokerageTier": {
+            "type": "object",
+            "properties": {
+                "match": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.Consensus": {
+            "type": "object",
+            "properties": {
+                "covering_brokerages": {
+                    "description": "CoveringBrokerages is the number of brokerages with at least one event in the window",
+                    "type": "integer"
+                },
+                "downgrades": {
+                    "type": "integer"
+                },
+                "mean_rating_score": {
+                    "description": "MeanRatingScore is the mean 1-5 score of the brokerages' latest scored ratings",
+                    "type": "number"
+                },
+                "net_upgrades": {
+                    "type": "integer"
+                },
+                "ratings": {
+                    "description": "Ratings counts the brokerages' latest ratings per canonical bucket; every bucket is present",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "target": {
+                    "description": "Target summarizes the brokerages' latest price targets; nil when no brokerage has one",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.TargetConsensus"
+                        }
+                    ]
+                },
+                "ticker": {
+                    "type": "string"
+                },
+                "unrated": {
+                    "description": "Unrated counts brokerages whose latest rating is not mapped to a bucket",
+                    "type": "integer"
+                },
+                "upgrades": {
+                    "description": "Upgrades and Downgrades count every upgrade and downgrade in the window, by any brokerage",
+                    "type": "integer"
+                },
+                "window_from": {
+                    "type": "string"
+                },
+                "window_to": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ConsensusProfile": {
+            "type": "object",
+            "properties": {
+                "bullish_rating_score": {
+                    "description": "BullishRatingScore is the canonical rating score from which a rating counts as buy or better",
+                    "type": "number"
+                },
+                "min_brokerages": {
+                    "description": "MinBrokerages is how many brokerages must rate a stock",
+                    "type": "integer"
+                },
+                "weights": {
+                    "$ref": "#/definitions/domain.ConsensusWeights"
+                }
+            }
+        },
+        "domain.ConsensusWeights": {
+            "type": "object",
+            "properties": {
+                "agreement": {
+                    "type": "number"
+                },
+                "breadth": {
+                    "type": "number"
+                },
+                "mean_rating": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.ConvertedTargets": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "rate_date": {
+                    "type": "string"
+                },
+                "target_from": {
+                    "type": "number"
+                },
+                "target_to": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.EventAnalytics": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "From and To are the starts of the first and the last bucket",
+                    "type": "string"
+                },
+                "group_by": {
+                    "$ref": "#/definitions/domain.AnalyticsGroupBy"
+                },
+                "groups": {
+                    "description": "Groups is the number of groups before the series were limited to the largest ones",
+                    "type": "integer"
+                },
+                "interval": {
+                    "$ref": "#/definitions/domain.AnalyticsInterval"
+                },
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.EventSeries"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.EventBucket": {
+            "type": "object",
+            "properties": {
+                "avg_target_change_pct": {
+                    "description": "AvgTargetChangePct averages the change from target_from to target_to of the bucket's events with both targets",
+                    "type": "number"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.EventChange": {
+            "type": "object",
+            "properties": {
+                "action_changed": {
+                    "type": "boolean"
+                },
+                "previous_action": {
+                    "type": "string"
+                },
+                "previous_brokerage": {
+                    "type": "string"
+                },
+                "previous_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "previous_time": {
+                    "type": "string"
+                },
+                "rating_changed": {
+                    "type": "boolean"
+                },
+                "rating_from": {
+                    "description": "RatingFrom and RatingTo are the rating_to terms of the earlier and the later event",
+                    "type": "string"
+                },
+                "rating_movement": {
+                    "$ref": "#/definitions/domain.RatingMovement"
+                },
+                "rating_score_delta": {
+                    "type": "number"
+                },
+                "rating_to": {
+                    "type": "string"
+                },
+                "target_change_pct": {
+                    "type": "number"
+                },
+                "target_delta": {
+                    "type": "number"
+                },
+                "target_from": {
+                    "description": "TargetFrom and TargetTo are the numeric target_to of the earlier and the later event.\nThe delta is only set when both targets exist in the same currency.",
+                    "type": "number"
+                },
+                "target_to": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.EventSeries": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.EventBucket"
+                    }
+                },
+                "group": {
+                    "description": "Group is the value of the grouped field; empty for events without one or without grouping",
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.FXRate": {
+            "type": "object",
+            "required": [
+                "currency",
+                "date",
+                "usd_rate"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "usd_rate": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.MomentumProfile": {
+            "type": "object",
+            "properties": {
+                "weights": {
+                    "$ref": "#/definitions/domain.MomentumWeights"
+                },
+                "window_days": {
+                    "description": "WindowDays is how old the latest event of a stock may be",
+                    "type": "number"
+                }
+            }
+        },
+        "domain.MomentumWeights": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "number"
+                },
+                "recency": {
+                    "type": "number"
+                },
+                "target": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.PriceBar": {
+            "type": "object",
+            "required": [
+                "close",
+                "date",
+                "ticker"
+            ],
+            "properties": {
+                "close": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "Currency of the prices; defaults to ReportingBaseCurrency",
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "high": {
+                    "type": "number"
+                },
+                "low": {
+                    "type": "number"
+                },
+                "open": {
+                    "type": "number"
+                },
+                "ticker": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "volume": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.RatingBucket": {
+            "type": "string",
+            "enum": [
+                "strong_sell",
+                "sell",
+                "hold",
+                "buy",
+                "strong_buy"
+            ],
+            "x-enum-varnames": [
+                "RatingBucketStrongSell",
+                "RatingBucketSell",
+                "RatingBucketHold",
+                "RatingBucketBuy",
+                "RatingBucketStrongBuy"
+            ]
+        },
+        "domain.RatingMovement": {
+            "type": "string",
+            "enum": [
+                "upgrade",
+                "downgrade",
+                "unchanged",
+                "unknown"
+            ],
+            "x-enum-varnames": [
+                "RatingMovementUpgrade",
+                "RatingMovementDowngrade",
+                "RatingMovementUnchanged",
+                "RatingMovementUnknown"
+            ]
+        },
+        "domain.RatingTransition": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "movement": {
+                    "$ref": "#/definitions/domain.RatingMovement"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.RatingTransitionMatrix": {
+            "type": "object",
+            "properties": {
+                "changed": {
+                    "type": "integer"
+                },
+                "counts": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "integer",
+                            "format": "int64"
+                        }
+                    }
+                },
+                "downgrades": {
+                    "type": "integer"
+                },
+                "level": {
+                    "$ref": "#/definitions/domain.TransitionLevel"
+                },
+                "missing": {
+                    "description": "Missing counts matching events without a rating_from or rating_to at this level",
+                    "type": "integer"
+                },
+                "ratings": {
+                    "description": "Ratings labels the rows and columns, from most bearish to most bullish",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "transitions": {
+                    "description": "Transitions lists the non-empty cells, most frequent first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RatingTransition"
+                    }
+                },
+                "upgrades": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.RecencyBucket": {
+            "type": "object",
+            "properties": {
+                "max_days": {
+                    "type": "number"
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.ScoringProfile": {
+            "type": "object",
+            "properties": {
+                "action_scores": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                },
+                "brokerage_tiers": {
+                    "description": "BrokerageTiers are tried in order; brokerages matching none score OtherBrokerageScore,\nevents without a brokerage UnknownBrokerageScore",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.BrokerageTier"
+                    }
+                },
+                "consensus": {
+                    "$ref": "#/definitions/domain.ConsensusProfile"
+                },
+                "momentum": {
+                    "description": "Momentum and Consensus tune the strategies of the same name",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.MomentumProfile"
+                        }
+                    ]
+                },
+                "other_brokerage_score": {
+                    "type": "number"
+                },
+                "recency_buckets": {
+                    "description": "RecencyBuckets are ordered by MaxDays; events older than the last bucket score RecencyOlderScore",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RecencyBucket"
+                    }
+                },
+                "recency_older_score": {
+                    "type": "number"
+                },
+                "unknown_brokerage_score": {
+                    "type": "number"
+                },
+                "weights": {
+                    "$ref": "#/definitions/domain.ScoringWeights"
+                }
+            }
+        },
+        "domain.ScoringStrategyInfo": {
+            "type": "object",
+            "properties": {
+                "default": {
+                    "description": "Default is set for the strategy used when a request names none",
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ScoringWeights": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "number"
+                },
+                "brokerage": {
+                    "type": "number"
+                },
+                "implied_upside": {
+                    "type": "number"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "recency": {
+                    "type": "number"
+                },
+                "target": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.StockWithDetails": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "action_category": {
+                    "$ref": "#/definitions/domain.ActionCategory"
+                },
+                "action_direction": {
+                    "$ref": "#/definitions/domain.ActionDirection"
+                },
+                "action_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "brokerage": {
+                    "type": "string"
+                },
+                "brokerage_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "company": {
+                    "type": "string"
+                },
+                "converted": {
+                    "description": "Converted holds the price targets in a requested reporting currency - not persisted to database",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ConvertedTargets"
+                        }
+                    ]
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "rating_from": {
+                    "type": "string"
+                },
+                "rating_from_bucket": {
+                    "$ref": "#/definitions/domain.RatingBucket"
+                },
+                "rating_from_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "rating_from_score": {
+                    "type": "number"
+                },
+                "rating_to": {
+                    "type": "string"
+                },
+                "rating_to_bucket": {
+                    "$ref": "#/definitions/domain.RatingBucket"
+                },
+                "rating_to_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "rating_to_score": {
+                    "type": "number"
+                },
+                "target_currency": {
+                    "type": "string"
+                },
+                "target_from": {
+                    "type": "string"
+                },
+                "target_from_amount": {
+                    "type": "number"
+                },
+                "target_to": {
+                    "type": "string"
+                },
+                "target_to_amount": {
+                    "type": "number"
+                },
+                "ticker": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.TargetConsensus": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "excluded": {
+                    "type": "integer"
+                },
+                "high": {
+                    "type": "number"
+                },
+                "low": {
+                    "type": "number"
+                },
+                "mean": {
+                    "type": "number"
+                },
+                "median": {
+                    "type": "number"
+                },
+                "std_dev": {
+                    "description": "StdDev is the population standard deviation of the targets",
+                    "type": "number"
+                }
+            }
+        },
+        "domain.TimelineEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "action_category": {
+                    "$ref": "#/definitions/domain.ActionCategory"
+                },
+                "action_direction": {
+                    "$ref": "#/definitions/domain.ActionDirection"
+                },
+                "action_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "brokerage": {
+                    "type": "string"
+                },
+                "brokerage_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "company": {
+                    "type": "string"
+                },
+                "converted": {
+                    "description": "Converted holds the price targets in a requested reporting currency - not persisted to database",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ConvertedTargets"
+                        }
+                    ]
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "rating_from": {
+                    "type": "string"
+                },
+                "rating_from_bucket": {
+                    "$ref": "#/definitions/domain.RatingBucket"
+                },
+                "rating_from_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "rating_from_score": {
+                    "type": "number"
+                },
+                "rating_to": {
+                    "type": "string"
+                },
+                "rating_to_bucket": {
+                    "$ref": "#/definitions/domain.RatingBucket"
+                },
+                "rating_to_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "rating_to_score": {
+                    "type": "number"
+                },
+                "since_brokerage": {
+                    "$ref": "#/definitions/domain.EventChange"
+                },
+                "since_previous": {
+                    "$ref": "#/definitions/domain.EventChange"
+                },
+                "target_currency": {
+                    "type": "string"
+                },
+                "target_from": {
+                    "type": "string"
+                },
+                "target_from_amount": {
+                    "type": "number"
+                },
+                "target_to": {
+                    "type": "string"
+                },
+                "target_to_amount": {
+                    "type": "number"
+                },
+                "ticker": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.TransitionLevel": {
+            "type": "string",
+            "enum": [
+                "bucket",
+                "term"
+            ],
+            "x-enum-varnames": [
+                "TransitionLevelBucket",
+                "TransitionLevelTerm"
+            ]
+        },
+        "graphql.Request": {
+            "type": "object",
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "handler.MetaData": {
+            "type": "object",
             "properties": {
                 "limit": {
                     "type": "integer"
@@ -573,6 +3437,46 @@ const docTemplate = `{
                     "type": "boolean"
                 }
             }
+        },
+        "handler.UpdateActionCategoryRequest": {
+            "type": "object",
+            "required": [
+                "category"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "target_raised"
+                },
+                "direction": {
+                    "type": "string",
+                    "example": "up"
+                }
+            }
+        },
+        "handler.UpdateRatingScaleRequest": {
+            "type": "object",
+            "required": [
+                "bucket"
+            ],
+            "properties": {
+                "bucket": {
+                    "type": "string",
+                    "example": "buy"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 4
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "AdminAPIKey": {
+            "description": "Admin API key, sent as \"Bearer \u003ckey\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
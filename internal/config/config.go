@@ -14,14 +14,20 @@ type Config struct {
 	Server   ServerConfig
 	Database DatabaseConfig
 	StockAPI StockAPIConfig
+	Taxonomy TaxonomyConfig
+	Scoring  ScoringConfig
+	Search   SearchConfig
+	GraphQL  GraphQLConfig
 	Log      LogConfig
 }
 
 // ServerConfig holds server-related configuration
 type ServerConfig struct {
-	Port string
-	Host string
-	Env  string
+	Port        string
+	GRPCPort    string
+	Host        string
+	Env         string
+	AdminAPIKey string
 }
 
 // DatabaseConfig holds database-related configuration
@@ -45,6 +51,33 @@ type StockAPIConfig struct {
 	Timeout time.Duration
 }
 
+// TaxonomyConfig holds configuration for classifying analyst actions
+type TaxonomyConfig struct {
+	ActionRulesFile string
+}
+
+// ScoringConfig holds configuration for scoring recommendations
+type ScoringConfig struct {
+	// ProfileFile is a YAML or JSON scoring profile; the built-in profile is used without it
+	ProfileFile string
+	// ReloadInterval is how often the profile file is checked for changes; 0 disables polling
+	ReloadInterval time.Duration
+}
+
+// SearchConfig holds fuzzy search configuration
+type SearchConfig struct {
+	// SimilarityThreshold is the minimum trigram similarity (0-1] for fuzzy matches
+	SimilarityThreshold float64
+}
+
+// GraphQLConfig holds limits for the GraphQL endpoint
+type GraphQLConfig struct {
+	// MaxDepth is the deepest field nesting a query may have
+	MaxDepth int
+	// MaxComplexity caps the estimated number of fields a query resolves
+	MaxComplexity int
+}
+
 // LogConfig holds logging configuration
 type LogConfig struct {
 	Level  string
@@ -58,9 +91,11 @@ func Load() (*Config, error) {
 
 	config := &Config{
 		Server: ServerConfig{
-			Port: getEnv("SERVER_PORT", "8080"),
-			Host: getEnv("SERVER_HOST", "0.0.0.0"),
-			Env:  getEnv("ENV", "development"),
+			Port:        getEnv("SERVER_PORT", "8080"),
+			GRPCPort:    getEnv("GRPC_PORT", "9090"),
+			Host:        getEnv("SERVER_HOST", "0.0.0.0"),
+			Env:         getEnv("ENV", "development"),
+			AdminAPIKey: getEnv("ADMIN_API_KEY", ""),
 		},
 		Database: DatabaseConfig{
 			Host:            getEnv("DB_HOST", "localhost"),
@@ -79,6 +114,20 @@ func Load() (*Config, error) {
 			APIKey:  getEnv("STOCK_API_KEY", ""),
 			Timeout: getEnvAsDuration("STOCK_API_TIMEOUT", 30*time.Second),
 		},
+		Taxonomy: TaxonomyConfig{
+			ActionRulesFile: getEnv("ACTION_RULES_FILE", ""),
+		},
+		Scoring: ScoringConfig{
+			ProfileFile:    getEnv("SCORING_PROFILE_FILE", ""),
+			ReloadInterval: getEnvAsDuration("SCORING_PROFILE_RELOAD_INTERVAL", 30*time.Second),
+		},
+		Search: SearchConfig{
+			SimilarityThreshold: getEnvAsFloat("SEARCH_SIMILARITY_THRESHOLD", 0.3),
+		},
+		GraphQL: GraphQLConfig{
+			MaxDepth:      getEnvAsInt("GRAPHQL_MAX_DEPTH", 10),
+			MaxComplexity: getEnvAsInt("GRAPHQL_MAX_COMPLEXITY", 5000),
+		},
 		Log: LogConfig{
 			Level:  getEnv("LOG_LEVEL", "info"),
 			Format: getEnv("LOG_FORMAT", "json"),
@@ -100,6 +149,18 @@ func (c *Config) Validate() error {
 	if c.Database.Name == "" {
 		return fmt.Errorf("DB_NAME is required")
 	}
+	if c.Search.SimilarityThreshold <= 0 || c.Search.SimilarityThreshold > 1 {
+		return fmt.Errorf("SEARCH_SIMILARITY_THRESHOLD must be greater than 0 and at most 1")
+	}
+	if c.Scoring.ReloadInterval < 0 {
+		return fmt.Errorf("SCORING_PROFILE_RELOAD_INTERVAL must not be negative")
+	}
+	if c.GraphQL.MaxDepth <= 0 {
+		return fmt.Errorf("GRAPHQL_MAX_DEPTH must be positive")
+	}
+	if c.GraphQL.MaxComplexity <= 0 {
+		return fmt.Errorf("GRAPHQL_MAX_COMPLEXITY must be positive")
+	}
 	return nil
 }
 
@@ -132,6 +193,18 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return value
 }
 
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
 func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	valueStr := os.Getenv(key)
 	if valueStr == "" {
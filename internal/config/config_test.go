@@ -50,10 +50,67 @@ func TestLoad(t *testing.T) {
 
 		assert.NoError(t, err)
 		assert.Equal(t, "8080", cfg.Server.Port)
+		assert.Equal(t, "9090", cfg.Server.GRPCPort)
 		assert.Equal(t, "0.0.0.0", cfg.Server.Host)
 		assert.Equal(t, "development", cfg.Server.Env)
 		assert.Equal(t, 25, cfg.Database.MaxConns)
 		assert.Equal(t, 5, cfg.Database.MinConns)
+		assert.Equal(t, 0.3, cfg.Search.SimilarityThreshold)
+		assert.Equal(t, 10, cfg.GraphQL.MaxDepth)
+		assert.Equal(t, 5000, cfg.GraphQL.MaxComplexity)
+		assert.Equal(t, "", cfg.Scoring.ProfileFile)
+		assert.Equal(t, 30*time.Second, cfg.Scoring.ReloadInterval)
+	})
+
+	t.Run("Validation error - negative scoring profile reload interval", func(t *testing.T) {
+		os.Setenv("STOCK_API_KEY", "test_key")
+		os.Setenv("DB_NAME", "test_db")
+		os.Setenv("SCORING_PROFILE_RELOAD_INTERVAL", "-1s")
+		defer func() {
+			os.Unsetenv("STOCK_API_KEY")
+			os.Unsetenv("DB_NAME")
+			os.Unsetenv("SCORING_PROFILE_RELOAD_INTERVAL")
+		}()
+
+		cfg, err := Load()
+
+		assert.Error(t, err)
+		assert.Nil(t, cfg)
+		assert.Contains(t, err.Error(), "SCORING_PROFILE_RELOAD_INTERVAL")
+	})
+
+	t.Run("Validation error - similarity threshold out of range", func(t *testing.T) {
+		os.Setenv("STOCK_API_KEY", "test_key")
+		os.Setenv("DB_NAME", "test_db")
+		os.Setenv("SEARCH_SIMILARITY_THRESHOLD", "1.5")
+		defer func() {
+			os.Unsetenv("STOCK_API_KEY")
+			os.Unsetenv("DB_NAME")
+			os.Unsetenv("SEARCH_SIMILARITY_THRESHOLD")
+		}()
+
+		cfg, err := Load()
+
+		assert.Error(t, err)
+		assert.Nil(t, cfg)
+		assert.Contains(t, err.Error(), "SEARCH_SIMILARITY_THRESHOLD")
+	})
+
+	t.Run("Validation error - non-positive GraphQL depth", func(t *testing.T) {
+		os.Setenv("STOCK_API_KEY", "test_key")
+		os.Setenv("DB_NAME", "test_db")
+		os.Setenv("GRAPHQL_MAX_DEPTH", "0")
+		defer func() {
+			os.Unsetenv("STOCK_API_KEY")
+			os.Unsetenv("DB_NAME")
+			os.Unsetenv("GRAPHQL_MAX_DEPTH")
+		}()
+
+		cfg, err := Load()
+
+		assert.Error(t, err)
+		assert.Nil(t, cfg)
+		assert.Contains(t, err.Error(), "GRAPHQL_MAX_DEPTH")
 	})
 }
 
@@ -128,3 +185,23 @@ func TestGetEnvAsInt(t *testing.T) {
 		assert.Equal(t, 10, value)
 	})
 }
+
+func TestGetEnvAsFloat(t *testing.T) {
+	t.Run("Valid float", func(t *testing.T) {
+		os.Setenv("TEST_FLOAT", "0.45")
+		defer os.Unsetenv("TEST_FLOAT")
+
+		value := getEnvAsFloat("TEST_FLOAT", 0.3)
+
+		assert.Equal(t, 0.45, value)
+	})
+
+	t.Run("Invalid float - use default", func(t *testing.T) {
+		os.Setenv("TEST_FLOAT", "not_a_number")
+		defer os.Unsetenv("TEST_FLOAT")
+
+		value := getEnvAsFloat("TEST_FLOAT", 0.3)
+
+		assert.Equal(t, 0.3, value)
+	})
+}
@@ -2,15 +2,94 @@ package domain
 
 import (
 	"context"
+	"strings"
 	"time"
 )
 
+// ActionCategory is a canonical category for free-text analyst actions
+type ActionCategory string
+
+// Canonical action categories
+const (
+	ActionCategoryUpgrade       ActionCategory = "upgrade"
+	ActionCategoryDowngrade     ActionCategory = "downgrade"
+	ActionCategoryInitiate      ActionCategory = "initiate"
+	ActionCategoryReiterate     ActionCategory = "reiterate"
+	ActionCategoryTargetRaised  ActionCategory = "target_raised"
+	ActionCategoryTargetLowered ActionCategory = "target_lowered"
+	ActionCategoryOther         ActionCategory = "other"
+)
+
+// ActionCategories lists all canonical action categories
+var ActionCategories = []ActionCategory{
+	ActionCategoryUpgrade,
+	ActionCategoryDowngrade,
+	ActionCategoryInitiate,
+	ActionCategoryReiterate,
+	ActionCategoryTargetRaised,
+	ActionCategoryTargetLowered,
+	ActionCategoryOther,
+}
+
+// ParseActionCategory normalizes user input ("Target Raised", "target-raised") into an ActionCategory
+func ParseActionCategory(s string) (ActionCategory, bool) {
+	normalized := strings.ToLower(strings.TrimSpace(s))
+	normalized = strings.NewReplacer(" ", "_", "-", "_").Replace(normalized)
+
+	for _, category := range ActionCategories {
+		if string(category) == normalized {
+			return category, true
+		}
+	}
+	return "", false
+}
+
+// Direction returns whether the category is a positive, negative or neutral signal
+func (c ActionCategory) Direction() ActionDirection {
+	switch c {
+	case ActionCategoryUpgrade, ActionCategoryTargetRaised:
+		return ActionDirectionUp
+	case ActionCategoryDowngrade, ActionCategoryTargetLowered:
+		return ActionDirectionDown
+	default:
+		return ActionDirectionNeutral
+	}
+}
+
+// ActionDirection indicates whether an action is a positive or negative signal
+type ActionDirection string
+
+// Action directions
+const (
+	ActionDirectionUp      ActionDirection = "up"
+	ActionDirectionDown    ActionDirection = "down"
+	ActionDirectionNeutral ActionDirection = "neutral"
+)
+
+// ParseActionDirection validates an action direction
+func ParseActionDirection(s string) (ActionDirection, bool) {
+	switch direction := ActionDirection(strings.ToLower(strings.TrimSpace(s))); direction {
+	case ActionDirectionUp, ActionDirectionDown, ActionDirectionNeutral:
+		return direction, true
+	default:
+		return "", false
+	}
+}
+
+// ActionRule assigns a category to action names that contain all of the given substrings
+type ActionRule struct {
+	Category ActionCategory `json:"category"`
+	Contains []string       `json:"contains"`
+}
+
 // Action represents an analyst action (e.g., upgrade, downgrade)
 type Action struct {
-	ID        int64     `json:"id,string" db:"id"`
-	Name      string    `json:"name" db:"name" binding:"required"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID        int64           `json:"id,string" db:"id"`
+	Name      string          `json:"name" db:"name" binding:"required"`
+	Category  ActionCategory  `json:"category,omitempty" db:"category"`
+	Direction ActionDirection `json:"direction,omitempty" db:"direction"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
 }
 
 // ActionRepository defines the interface for action data persistence
@@ -19,4 +98,6 @@ type ActionRepository interface {
 	FindByID(id int64) (*Action, error)
 	FindByName(name string) (*Action, error)
 	FindAll(ctx context.Context) ([]*Action, error)
+	FindByIDs(ctx context.Context, ids []int64) ([]*Action, error)
+	UpdateCategory(ctx context.Context, action *Action) error
 }
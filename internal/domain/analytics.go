@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// AnalyticsGroupBy selects the field event analytics split their series by
+type AnalyticsGroupBy string
+
+// Analytics groupings; an empty grouping counts all events in one series
+const (
+	AnalyticsGroupByAction         AnalyticsGroupBy = "action"
+	AnalyticsGroupByActionCategory AnalyticsGroupBy = "action_category"
+	AnalyticsGroupByBrokerage      AnalyticsGroupBy = "brokerage"
+	AnalyticsGroupByRatingTo       AnalyticsGroupBy = "rating_to"
+	AnalyticsGroupByTicker         AnalyticsGroupBy = "ticker"
+)
+
+// AnalyticsGroupBys lists the supported groupings
+var AnalyticsGroupBys = []AnalyticsGroupBy{
+	AnalyticsGroupByAction,
+	AnalyticsGroupByActionCategory,
+	AnalyticsGroupByBrokerage,
+	AnalyticsGroupByRatingTo,
+	AnalyticsGroupByTicker,
+}
+
+// ParseAnalyticsGroupBy validates a grouping; an empty value means no grouping
+func ParseAnalyticsGroupBy(s string) (AnalyticsGroupBy, bool) {
+	groupBy := AnalyticsGroupBy(strings.ToLower(strings.TrimSpace(s)))
+	if groupBy == "" {
+		return "", true
+	}
+	for _, supported := range AnalyticsGroupBys {
+		if groupBy == supported {
+			return groupBy, true
+		}
+	}
+	return "", false
+}
+
+// AnalyticsInterval is the width of the time buckets of event analytics
+type AnalyticsInterval string
+
+// Analytics intervals; weeks start on Monday and all buckets are in UTC
+const (
+	AnalyticsIntervalDay   AnalyticsInterval = "day"
+	AnalyticsIntervalWeek  AnalyticsInterval = "week"
+	AnalyticsIntervalMonth AnalyticsInterval = "month"
+)
+
+// ParseAnalyticsInterval validates an interval; an empty value is a week
+func ParseAnalyticsInterval(s string) (AnalyticsInterval, bool) {
+	switch interval := AnalyticsInterval(strings.ToLower(strings.TrimSpace(s))); interval {
+	case "":
+		return AnalyticsIntervalWeek, true
+	case AnalyticsIntervalDay, AnalyticsIntervalWeek, AnalyticsIntervalMonth:
+		return interval, true
+	default:
+		return "", false
+	}
+}
+
+// EventAnalyticsQuery aggregates the events matching Filter per Interval and GroupBy
+type EventAnalyticsQuery struct {
+	Filter   StockFilter
+	GroupBy  AnalyticsGroupBy
+	Interval AnalyticsInterval
+}
+
+// EventAggregate is the count and average target change of the events of one group in one bucket
+type EventAggregate struct {
+	Bucket             time.Time
+	Group              string
+	Count              int64
+	AvgTargetChangePct *float64
+}
+
+// EventBucket is one time bucket of an analytics series
+type EventBucket struct {
+	Start time.Time `json:"start"`
+	Count int64     `json:"count"`
+	// AvgTargetChangePct averages the change from target_from to target_to of the bucket's events with both targets
+	AvgTargetChangePct *float64 `json:"avg_target_change_pct"`
+}
+
+// EventSeries is the buckets of one group, with empty buckets filled with zero counts
+type EventSeries struct {
+	// Group is the value of the grouped field; empty for events without one or without grouping
+	Group   string        `json:"group"`
+	Total   int64         `json:"total"`
+	Buckets []EventBucket `json:"buckets"`
+}
+
+// EventAnalytics is the result of an EventAnalyticsQuery
+type EventAnalytics struct {
+	GroupBy  AnalyticsGroupBy  `json:"group_by,omitempty"`
+	Interval AnalyticsInterval `json:"interval"`
+	// From and To are the starts of the first and the last bucket
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	// Groups is the number of groups before the series were limited to the largest ones
+	Groups int           `json:"groups"`
+	Series []EventSeries `json:"series"`
+}
+
+// TransitionLevel selects whether rating transitions compare vendor terms or canonical buckets
+type TransitionLevel string
+
+// Transition levels
+const (
+	TransitionLevelBucket TransitionLevel = "bucket"
+	TransitionLevelTerm   TransitionLevel = "term"
+)
+
+// ParseTransitionLevel validates a transition level; an empty value compares buckets
+func ParseTransitionLevel(s string) (TransitionLevel, bool) {
+	switch level := TransitionLevel(strings.ToLower(strings.TrimSpace(s))); level {
+	case "":
+		return TransitionLevelBucket, true
+	case TransitionLevelBucket, TransitionLevelTerm:
+		return level, true
+	default:
+		return "", false
+	}
+}
+
+// RatingTransitionQuery counts the rating_from to rating_to transitions of the events matching Filter
+type RatingTransitionQuery struct {
+	Filter StockFilter
+	Level  TransitionLevel
+}
+
+// RatingTransitionCount is the number of events moving from one rating to another. Ratings are terms or
+// buckets depending on the level and empty when the event has none; scores are only set for terms.
+type RatingTransitionCount struct {
+	From      string
+	FromScore *float64
+	To        string
+	ToScore   *float64
+	Count     int64
+}
+
+// RatingTransition is one non-empty cell of a rating transition matrix
+type RatingTransition struct {
+	From     string         `json:"from"`
+	To       string         `json:"to"`
+	Count    int64          `json:"count"`
+	Movement RatingMovement `json:"movement"`
+}
+
+// RatingTransitionMatrix counts events per rating_from (rows) and rating_to (columns)
+type RatingTransitionMatrix struct {
+	Level TransitionLevel `json:"level"`
+	// Ratings labels the rows and columns, from most bearish to most bullish
+	Ratings []string  `json:"ratings"`
+	Counts  [][]int64 `json:"counts"`
+	// Transitions lists the non-empty cells, most frequent first
+	Transitions []RatingTransition `json:"transitions"`
+	Total       int64              `json:"total"`
+	Changed     int64              `json:"changed"`
+	Upgrades    int64              `json:"upgrades"`
+	Downgrades  int64              `json:"downgrades"`
+	// Missing counts matching events without a rating_from or rating_to at this level
+	Missing int64 `json:"missing"`
+}
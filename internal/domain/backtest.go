@@ -0,0 +1,83 @@
+package domain
+
+import "time"
+
+// BacktestQuery replays recommendations every Interval from From to To and follows each scored stock for Horizon
+type BacktestQuery struct {
+	From     time.Time
+	To       time.Time
+	Interval AnalyticsInterval
+	Horizon  time.Duration
+	// Limit is the number of top-scored stocks per date that count as picks
+	Limit int
+	// Strategy names the scoring strategy; empty uses the default strategy
+	Strategy string
+}
+
+// BacktestOutcome classifies what other brokerages did with a stock after it was scored
+type BacktestOutcome string
+
+// Backtest outcomes
+const (
+	// BacktestOutcomeHit means more positive than negative signals followed
+	BacktestOutcomeHit BacktestOutcome = "hit"
+	// BacktestOutcomeMiss means more negative than positive signals followed
+	BacktestOutcomeMiss BacktestOutcome = "miss"
+	// BacktestOutcomeNeutral means later events balanced out or carried no signal
+	BacktestOutcomeNeutral BacktestOutcome = "neutral"
+	// BacktestOutcomeNoEvents means no other brokerage covered the stock within the horizon
+	BacktestOutcomeNoEvents BacktestOutcome = "no_events"
+)
+
+// BacktestPick is one stock picked on one date, with what followed within the horizon.
+// Upgrades and raises are positive signals, downgrades and cuts negative ones.
+type BacktestPick struct {
+	AsOf      time.Time `json:"as_of"`
+	Rank      int       `json:"rank"`
+	Ticker    string    `json:"ticker"`
+	Brokerage string    `json:"brokerage"`
+	Score     float64   `json:"score"`
+	// LaterEvents counts the events of other brokerages within the horizon
+	LaterEvents  int `json:"later_events"`
+	Upgrades     int `json:"upgrades"`
+	Downgrades   int `json:"downgrades"`
+	TargetRaises int `json:"target_raises"`
+	TargetCuts   int `json:"target_cuts"`
+	// AvgTargetChangePct averages the target_from to target_to change of the later events with both targets
+	AvgTargetChangePct *float64        `json:"avg_target_change_pct"`
+	Outcome            BacktestOutcome `json:"outcome"`
+}
+
+// BacktestStats summarizes the outcomes of a group of scored stocks
+type BacktestStats struct {
+	Group    string  `json:"group"`
+	MinScore float64 `json:"min_score"`
+	MaxScore float64 `json:"max_score"`
+	Scored   int     `json:"scored"`
+	Hits     int     `json:"hits"`
+	Misses   int     `json:"misses"`
+	Neutral  int     `json:"neutral"`
+	NoEvents int     `json:"no_events"`
+	// HitRate is hits / (hits + misses); nil when there were neither
+	HitRate *float64 `json:"hit_rate"`
+	// AvgNetSignal averages positive minus negative signals per scored stock
+	AvgNetSignal       float64  `json:"avg_net_signal"`
+	AvgTargetChangePct *float64 `json:"avg_target_change_pct"`
+}
+
+// BacktestResult is the result of a BacktestQuery
+type BacktestResult struct {
+	From        time.Time         `json:"from"`
+	To          time.Time         `json:"to"`
+	Interval    AnalyticsInterval `json:"interval"`
+	HorizonDays int               `json:"horizon_days"`
+	Limit       int               `json:"limit"`
+	Strategy    string            `json:"strategy"`
+	Dates       []time.Time       `json:"dates"`
+	// Picks summarizes the top Limit stocks per date, Universe every scored stock
+	Picks    BacktestStats `json:"picks"`
+	Universe BacktestStats `json:"universe"`
+	// Deciles splits every date's scored stocks by score rank; the first decile has the highest scores
+	Deciles  []BacktestStats `json:"deciles"`
+	PickList []BacktestPick  `json:"pick_list"`
+}
@@ -13,10 +13,51 @@ type Brokerage struct {
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// BrokerageActivity summarizes the stock events published by a brokerage
+type BrokerageActivity struct {
+	Events         int64 `json:"events"`
+	TickersCovered int64 `json:"tickers_covered"`
+	// EventsByCategory counts events per action category; events without an action count as other
+	EventsByCategory map[ActionCategory]int64 `json:"events_by_category"`
+	Upgrades         int64                    `json:"upgrades"`
+	Downgrades       int64                    `json:"downgrades"`
+	// UpgradeDowngradeRatio is upgrades divided by downgrades; nil without downgrades
+	UpgradeDowngradeRatio *float64 `json:"upgrade_downgrade_ratio"`
+	// AvgTargetChangePct averages the change from target_from to target_to of the events with both targets
+	AvgTargetChangePct *float64   `json:"avg_target_change_pct"`
+	FirstSeen          *time.Time `json:"first_seen"`
+	LastSeen           *time.Time `json:"last_seen"`
+}
+
+// BrokerageProfile is a brokerage with its activity over its full history and its most recent events
+type BrokerageProfile struct {
+	*Brokerage
+	Activity     *BrokerageActivity  `json:"activity"`
+	RecentEvents []*StockWithDetails `json:"recent_events"`
+}
+
+// BrokerageLeaderboardEntry is a brokerage's activity within a leaderboard window
+type BrokerageLeaderboardEntry struct {
+	Rank               int       `json:"rank"`
+	BrokerageID        int64     `json:"brokerage_id,string"`
+	Name               string    `json:"name"`
+	Events             int64     `json:"events"`
+	TickersCovered     int64     `json:"tickers_covered"`
+	Upgrades           int64     `json:"upgrades"`
+	Downgrades         int64     `json:"downgrades"`
+	AvgTargetChangePct *float64  `json:"avg_target_change_pct"`
+	LastSeen           time.Time `json:"last_seen"`
+}
+
 // BrokerageRepository defines the interface for brokerage data persistence
 type BrokerageRepository interface {
 	Create(brokerage *Brokerage) error
 	FindByID(id int64) (*Brokerage, error)
 	FindByName(name string) (*Brokerage, error)
 	FindAll(ctx context.Context) ([]*Brokerage, error)
+	FindByIDs(ctx context.Context, ids []int64) ([]*Brokerage, error)
+	FindActivity(ctx context.Context, id int64) (*BrokerageActivity, error)
+	FindRecentEvents(ctx context.Context, id int64, limit int) ([]*StockWithDetails, error)
+	// FindLeaderboard ranks brokerages by their number of events between from and to, most active first
+	FindLeaderboard(ctx context.Context, from, to time.Time, limit int) ([]*BrokerageLeaderboardEntry, error)
 }
@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// Consensus summarizes the current opinion of the brokerages covering a ticker within a window.
+// Each brokerage counts once, with its latest rating and its latest price target in the window.
+type Consensus struct {
+	Ticker     string    `json:"ticker"`
+	WindowFrom time.Time `json:"window_from"`
+	WindowTo   time.Time `json:"window_to"`
+
+	// CoveringBrokerages is the number of brokerages with at least one event in the window
+	CoveringBrokerages int `json:"covering_brokerages"`
+	// Ratings counts the brokerages' latest ratings per canonical bucket; every bucket is present
+	Ratings map[RatingBucket]int `json:"ratings"`
+	// Unrated counts brokerages whose latest rating is not mapped to a bucket
+	Unrated int `json:"unrated"`
+	// MeanRatingScore is the mean 1-5 score of the brokerages' latest scored ratings
+	MeanRatingScore *float64 `json:"mean_rating_score"`
+
+	// Target summarizes the brokerages' latest price targets; nil when no brokerage has one
+	Target *TargetConsensus `json:"target"`
+
+	// Upgrades and Downgrades count every upgrade and downgrade in the window, by any brokerage
+	Upgrades    int `json:"upgrades"`
+	Downgrades  int `json:"downgrades"`
+	NetUpgrades int `json:"net_upgrades"`
+}
+
+// TargetConsensus summarizes price targets in one currency. Targets in other currencies cannot be
+// compared, so only the currency most brokerages use is summarized and the others are counted as excluded.
+type TargetConsensus struct {
+	Currency string  `json:"currency,omitempty"`
+	Count    int     `json:"count"`
+	Mean     float64 `json:"mean"`
+	Median   float64 `json:"median"`
+	High     float64 `json:"high"`
+	Low      float64 `json:"low"`
+	// StdDev is the population standard deviation of the targets
+	StdDev   float64 `json:"std_dev"`
+	Excluded int     `json:"excluded"`
+}
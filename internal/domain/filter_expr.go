@@ -0,0 +1,129 @@
+package domain
+
+import "fmt"
+
+// FilterFieldType is the value type of a field usable in filter expressions
+type FilterFieldType string
+
+// Filter field types
+const (
+	FilterFieldString FilterFieldType = "string"
+	FilterFieldEnum   FilterFieldType = "enum"
+	FilterFieldNumber FilterFieldType = "number"
+	FilterFieldTime   FilterFieldType = "time"
+)
+
+// FilterField describes a field that filter expressions may reference
+type FilterField struct {
+	Type FilterFieldType
+	// Normalize validates and canonicalizes enum values
+	Normalize func(string) (string, bool)
+}
+
+// FilterFields is the whitelist of fields accepted in filter expressions
+var FilterFields = map[string]FilterField{
+	"ticker":             {Type: FilterFieldString},
+	"company":            {Type: FilterFieldString},
+	"brokerage":          {Type: FilterFieldString},
+	"action":             {Type: FilterFieldString},
+	"action_category":    {Type: FilterFieldEnum, Normalize: normalizeActionCategory},
+	"action_direction":   {Type: FilterFieldEnum, Normalize: normalizeActionDirection},
+	"rating_from":        {Type: FilterFieldString},
+	"rating_to":          {Type: FilterFieldString},
+	"rating_from_bucket": {Type: FilterFieldEnum, Normalize: normalizeRatingBucket},
+	"rating_to_bucket":   {Type: FilterFieldEnum, Normalize: normalizeRatingBucket},
+	"rating_from_score":  {Type: FilterFieldNumber},
+	"rating_to_score":    {Type: FilterFieldNumber},
+	"target_from":        {Type: FilterFieldNumber},
+	"target_to":          {Type: FilterFieldNumber},
+	"target_change_pct":  {Type: FilterFieldNumber},
+	"currency":           {Type: FilterFieldString},
+	"time":               {Type: FilterFieldTime},
+}
+
+func normalizeActionCategory(s string) (string, bool) {
+	category, ok := ParseActionCategory(s)
+	return string(category), ok
+}
+
+func normalizeActionDirection(s string) (string, bool) {
+	direction, ok := ParseActionDirection(s)
+	return string(direction), ok
+}
+
+func normalizeRatingBucket(s string) (string, bool) {
+	bucket, ok := ParseRatingBucket(s)
+	return string(bucket), ok
+}
+
+// FilterOp is a comparison operator in filter expressions
+type FilterOp string
+
+// Filter comparison operators
+const (
+	FilterOpEq          FilterOp = "="
+	FilterOpNotEq       FilterOp = "!="
+	FilterOpGt          FilterOp = ">"
+	FilterOpGte         FilterOp = ">="
+	FilterOpLt          FilterOp = "<"
+	FilterOpLte         FilterOp = "<="
+	FilterOpContains    FilterOp = "~"
+	FilterOpNotContains FilterOp = "!~"
+	FilterOpIn          FilterOp = "in"
+	FilterOpNotIn       FilterOp = "not in"
+)
+
+// Negated reports whether the operator excludes matches; negated comparisons keep rows where the field is NULL
+func (op FilterOp) Negated() bool {
+	return op == FilterOpNotEq || op == FilterOpNotContains || op == FilterOpNotIn
+}
+
+// FilterExpr is a node of a parsed, type-checked filter expression
+type FilterExpr interface {
+	filterExpr()
+}
+
+// FilterAnd matches when both operands match
+type FilterAnd struct {
+	Left  FilterExpr
+	Right FilterExpr
+}
+
+// FilterOr matches when either operand matches
+type FilterOr struct {
+	Left  FilterExpr
+	Right FilterExpr
+}
+
+// FilterNot matches when its operand does not match
+type FilterNot struct {
+	Expr FilterExpr
+}
+
+// FilterComparison compares a whitelisted field with literal values.
+// Values hold string, float64 or time.Time depending on the field type; "in" operators have one or more values.
+type FilterComparison struct {
+	Field  string
+	Op     FilterOp
+	Values []interface{}
+}
+
+func (FilterAnd) filterExpr()        {}
+func (FilterOr) filterExpr()         {}
+func (FilterNot) filterExpr()        {}
+func (FilterComparison) filterExpr() {}
+
+// FilterSyntaxError reports an invalid filter expression and the 1-based position where it was detected
+type FilterSyntaxError struct {
+	Position int
+	Message  string
+}
+
+func (e *FilterSyntaxError) Error() string {
+	return fmt.Sprintf("%s: q: %s at position %d", ErrInvalidInput, e.Message, e.Position)
+}
+
+// Unwrap lets errors.Is(err, ErrInvalidInput) match syntax errors
+func (e *FilterSyntaxError) Unwrap() error {
+	return ErrInvalidInput
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ReportingBaseCurrency is the currency all FX rates are quoted against
+const ReportingBaseCurrency = "USD"
+
+// FXRate is the value of one unit of a currency in USD on a given date
+type FXRate struct {
+	Currency  string    `json:"currency" binding:"required"`
+	Date      time.Time `json:"date" binding:"required"`
+	USDRate   float64   `json:"usd_rate" binding:"required"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// ConvertedTargets holds price targets converted to a reporting currency
+type ConvertedTargets struct {
+	Currency   string    `json:"currency"`
+	TargetFrom *float64  `json:"target_from,omitempty"`
+	TargetTo   *float64  `json:"target_to,omitempty"`
+	Rate       float64   `json:"rate"`
+	RateDate   time.Time `json:"rate_date"`
+}
+
+// FXRateRepository defines the interface for FX rate data persistence
+type FXRateRepository interface {
+	Upsert(ctx context.Context, rates []*FXRate) error
+	FindAll(ctx context.Context, currency string) ([]*FXRate, error)
+	FindUpTo(ctx context.Context, currencies []string, until time.Time) ([]*FXRate, error)
+}
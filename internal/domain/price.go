@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// PriceBar is the daily open, high, low and close of a ticker
+type PriceBar struct {
+	Ticker string    `json:"ticker" binding:"required"`
+	Date   time.Time `json:"date" binding:"required"`
+	Open   float64   `json:"open"`
+	High   float64   `json:"high"`
+	Low    float64   `json:"low"`
+	Close  float64   `json:"close" binding:"required"`
+	Volume int64     `json:"volume"`
+	// Currency of the prices; defaults to ReportingBaseCurrency
+	Currency  string    `json:"currency"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// PriceBarRepository defines the interface for price bar data persistence
+type PriceBarRepository interface {
+	Upsert(ctx context.Context, bars []*PriceBar) error
+	// FindByTicker returns the bars of a ticker within the range, newest first
+	FindByTicker(ctx context.Context, ticker string, timeRange TimeRange, limit int) ([]*PriceBar, error)
+	// FindLatest returns the latest bar of each ticker dated on or after from's date and before until's date,
+	// so a bar is only used once its day has closed
+	FindLatest(ctx context.Context, tickers []string, from, until time.Time) ([]*PriceBar, error)
+}
@@ -2,15 +2,79 @@ package domain
 
 import (
 	"context"
+	"strings"
 	"time"
 )
 
+// RatingBucket is a canonical, vendor-independent rating category
+type RatingBucket string
+
+// Canonical rating buckets, from most bearish to most bullish
+const (
+	RatingBucketStrongSell RatingBucket = "strong_sell"
+	RatingBucketSell       RatingBucket = "sell"
+	RatingBucketHold       RatingBucket = "hold"
+	RatingBucketBuy        RatingBucket = "buy"
+	RatingBucketStrongBuy  RatingBucket = "strong_buy"
+)
+
+// RatingBuckets lists all canonical buckets in ascending order
+var RatingBuckets = []RatingBucket{
+	RatingBucketStrongSell,
+	RatingBucketSell,
+	RatingBucketHold,
+	RatingBucketBuy,
+	RatingBucketStrongBuy,
+}
+
+// NeutralRatingScore is the score used for ratings that are not mapped to a bucket
+const NeutralRatingScore = 3.0
+
+// ParseRatingBucket normalizes user input ("Strong Buy", "strong-buy") into a RatingBucket
+func ParseRatingBucket(s string) (RatingBucket, bool) {
+	normalized := strings.ToLower(strings.TrimSpace(s))
+	normalized = strings.NewReplacer(" ", "_", "-", "_").Replace(normalized)
+
+	for _, bucket := range RatingBuckets {
+		if string(bucket) == normalized {
+			return bucket, true
+		}
+	}
+	return "", false
+}
+
+// DefaultScore returns the bucket's position on the 1-5 rating scale
+func (b RatingBucket) DefaultScore() float64 {
+	for i, bucket := range RatingBuckets {
+		if bucket == b {
+			return float64(i + 1)
+		}
+	}
+	return NeutralRatingScore
+}
+
 // Rating represents a stock rating term (independent of brokerage)
 type Rating struct {
-	ID        int64     `json:"id,string" db:"id"`
-	Term      string    `json:"term" db:"term" binding:"required"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID        int64        `json:"id,string" db:"id"`
+	Term      string       `json:"term" db:"term" binding:"required"`
+	Bucket    RatingBucket `json:"bucket,omitempty" db:"bucket"`
+	Score     *float64     `json:"score,omitempty" db:"score"`
+	CreatedAt time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// UnmappedRating is a rating term without a canonical bucket, with the number of events using it
+type UnmappedRating struct {
+	ID         int64  `json:"id,string"`
+	Term       string `json:"term"`
+	UsageCount int64  `json:"usage_count"`
+}
+
+// RatingQualityReport summarizes how well stored rating terms map onto the canonical scale
+type RatingQualityReport struct {
+	TotalTerms    int               `json:"total_terms"`
+	MappedTerms   int               `json:"mapped_terms"`
+	UnmappedTerms []*UnmappedRating `json:"unmapped_terms"`
 }
 
 // RatingRepository defines the interface for rating data persistence
@@ -19,4 +83,7 @@ type RatingRepository interface {
 	FindByID(id int64) (*Rating, error)
 	FindByTerm(term string) (*Rating, error)
 	FindAll(ctx context.Context) ([]*Rating, error)
+	FindByIDs(ctx context.Context, ids []int64) ([]*Rating, error)
+	FindUnmapped(ctx context.Context) ([]*UnmappedRating, error)
+	UpdateScale(ctx context.Context, rating *Rating) error
 }
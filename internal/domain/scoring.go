@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ScoringWeights weighs the factor scores of a recommendation. All weights sum to 1, and every stock is scored
+// against all of them: a stock without a recent close scores 0 on ImpliedUpside, so scores with and without
+// prices stay comparable.
+type ScoringWeights struct {
+	Action        float64 `json:"action" yaml:"action"`
+	Rating        float64 `json:"rating" yaml:"rating"`
+	Target        float64 `json:"target" yaml:"target"`
+	Recency       float64 `json:"recency" yaml:"recency"`
+	Brokerage     float64 `json:"brokerage" yaml:"brokerage"`
+	ImpliedUpside float64 `json:"implied_upside" yaml:"implied_upside"`
+}
+
+// Base returns the sum of the weights of the factors every stock is scored on
+func (w ScoringWeights) Base() float64 {
+	return w.Action + w.Rating + w.Target + w.Recency + w.Brokerage
+}
+
+// Total returns the sum of all weights, which must add up to 1
+func (w ScoringWeights) Total() float64 {
+	return w.Base() + w.ImpliedUpside
+}
+
+// RecencyBucket scores events at most MaxDays old
+type RecencyBucket struct {
+	MaxDays float64 `json:"max_days" yaml:"max_days"`
+	Score   float64 `json:"score" yaml:"score"`
+}
+
+// BrokerageTier scores brokerages whose lowercased name contains any of Match
+type BrokerageTier struct {
+	Name  string   `json:"name" yaml:"name"`
+	Score float64  `json:"score" yaml:"score"`
+	Match []string `json:"match" yaml:"match"`
+}
+
+// ScoringProfile holds the weights and factor tables recommendations are scored with.
+// Factor scores range from 0 to 10.
+type ScoringProfile struct {
+	Weights      ScoringWeights             `json:"weights" yaml:"weights"`
+	ActionScores map[ActionCategory]float64 `json:"action_scores" yaml:"action_scores"`
+	// RecencyBuckets are ordered by MaxDays; events older than the last bucket score RecencyOlderScore
+	RecencyBuckets    []RecencyBucket `json:"recency_buckets" yaml:"recency_buckets"`
+	RecencyOlderScore float64         `json:"recency_older_score" yaml:"recency_older_score"`
+	// BrokerageTiers are tried in order; brokerages matching none score OtherBrokerageScore,
+	// events without a brokerage UnknownBrokerageScore
+	BrokerageTiers        []BrokerageTier `json:"brokerage_tiers" yaml:"brokerage_tiers"`
+	OtherBrokerageScore   float64         `json:"other_brokerage_score" yaml:"other_brokerage_score"`
+	UnknownBrokerageScore float64         `json:"unknown_brokerage_score" yaml:"unknown_brokerage_score"`
+	// Momentum and Consensus tune the strategies of the same name
+	Momentum  MomentumProfile  `json:"momentum" yaml:"momentum"`
+	Consensus ConsensusProfile `json:"consensus" yaml:"consensus"`
+}
+
+// MomentumWeights weighs the factor scores of the momentum strategy; they sum to 1
+type MomentumWeights struct {
+	Action  float64 `json:"action" yaml:"action"`
+	Target  float64 `json:"target" yaml:"target"`
+	Recency float64 `json:"recency" yaml:"recency"`
+}
+
+// MomentumProfile tunes the momentum strategy
+type MomentumProfile struct {
+	// WindowDays is how old the latest event of a stock may be
+	WindowDays float64         `json:"window_days" yaml:"window_days"`
+	Weights    MomentumWeights `json:"weights" yaml:"weights"`
+}
+
+// ConsensusWeights weighs the factor scores of the consensus strategy; they sum to 1
+type ConsensusWeights struct {
+	Agreement  float64 `json:"agreement" yaml:"agreement"`
+	MeanRating float64 `json:"mean_rating" yaml:"mean_rating"`
+	Breadth    float64 `json:"breadth" yaml:"breadth"`
+}
+
+// ConsensusProfile tunes the consensus strategy
+type ConsensusProfile struct {
+	// MinBrokerages is how many brokerages must rate a stock
+	MinBrokerages int `json:"min_brokerages" yaml:"min_brokerages"`
+	// BullishRatingScore is the canonical rating score from which a rating counts as buy or better
+	BullishRatingScore float64          `json:"bullish_rating_score" yaml:"bullish_rating_score"`
+	Weights            ConsensusWeights `json:"weights" yaml:"weights"`
+}
+
+// ActionScore returns the score of an action category and the profile entry it came from.
+// Categories missing from the profile score as other.
+func (p *ScoringProfile) ActionScore(category ActionCategory) (float64, string) {
+	if score, ok := p.ActionScores[category]; ok {
+		return score, fmt.Sprintf("action_scores.%s", category)
+	}
+	return p.ActionScores[ActionCategoryOther], fmt.Sprintf("action_scores.%s (no score for %q)", ActionCategoryOther, category)
+}
+
+// RecencyScore returns the score of an event daysSince days old and the bucket it fell in
+func (p *ScoringProfile) RecencyScore(daysSince float64) (float64, string) {
+	for i, bucket := range p.RecencyBuckets {
+		if daysSince <= bucket.MaxDays {
+			return bucket.Score, fmt.Sprintf("recency_buckets[%d]: at most %g days", i, bucket.MaxDays)
+		}
+	}
+	return p.RecencyOlderScore, "recency_older_score: older than the last bucket"
+}
+
+// BrokerageScore returns the score of the first tier matching the brokerage name and the rule that matched
+func (p *ScoringProfile) BrokerageScore(brokerage string) (float64, string) {
+	brokerage = strings.ToLower(strings.TrimSpace(brokerage))
+	if brokerage == "" {
+		return p.UnknownBrokerageScore, "unknown_brokerage_score: no brokerage"
+	}
+
+	for _, tier := range p.BrokerageTiers {
+		for _, match := range tier.Match {
+			if strings.Contains(brokerage, strings.ToLower(match)) {
+				return tier.Score, fmt.Sprintf("brokerage_tiers.%s: matches %q", tier.Name, match)
+			}
+		}
+	}
+	return p.OtherBrokerageScore, "other_brokerage_score: no tier matches"
+}
+
+// ActiveScoringProfile is the scoring profile recommendations are currently scored with
+type ActiveScoringProfile struct {
+	// Source is the profile file, or "default" for the built-in profile
+	Source   string          `json:"source"`
+	LoadedAt time.Time       `json:"loaded_at"`
+	Profile  *ScoringProfile `json:"profile"`
+}
+
+// ScoringStrategyInfo describes a strategy recommendations can be scored with
+type ScoringStrategyInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Default is set for the strategy used when a request names none
+	Default bool `json:"default"`
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "context"
+
+// SearchResultType identifies what a search result refers to
+type SearchResultType string
+
+// Search result types
+const (
+	SearchResultStock     SearchResultType = "stock"
+	SearchResultBrokerage SearchResultType = "brokerage"
+)
+
+// SearchResult is a ranked match for a free-text search across tickers, companies and brokerages
+type SearchResult struct {
+	Type        SearchResultType `json:"type"`
+	Ticker      string           `json:"ticker,omitempty"`
+	Company     string           `json:"company,omitempty"`
+	BrokerageID int64            `json:"brokerage_id,omitempty,string"`
+	Brokerage   string           `json:"brokerage,omitempty"`
+	// MatchedField is the field with the best similarity: ticker, company or brokerage
+	MatchedField string  `json:"matched_field"`
+	Score        float64 `json:"score"`
+	// Highlight is the matched field's value, HTML-escaped, with matches wrapped in <mark> tags
+	Highlight string `json:"highlight"`
+}
+
+// SearchSuggestion is a prefix completion for search boxes
+type SearchSuggestion struct {
+	Field  string `json:"field"`
+	Value  string `json:"value"`
+	Ticker string `json:"ticker,omitempty"`
+}
+
+// SearchRepository defines the interface for search queries
+type SearchRepository interface {
+	Search(ctx context.Context, query string, threshold float64, limit int) ([]*SearchResult, error)
+	Suggest(ctx context.Context, prefix string, limit int) ([]*SearchSuggestion, error)
+}
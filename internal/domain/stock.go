@@ -2,23 +2,28 @@ package domain
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 )
 
 // Stock represents a stock rating/target information from brokerages
 type Stock struct {
-	ID           int64     `json:"id,string" db:"id"`
-	Ticker       string    `json:"ticker" db:"ticker" binding:"required"`
-	TargetFrom   string    `json:"target_from" db:"target_from"`
-	TargetTo     string    `json:"target_to" db:"target_to"`
-	Company      string    `json:"company" db:"company" binding:"required"`
-	ActionID     int64     `json:"action_id,string" db:"action_id"`
-	BrokerageID  int64     `json:"brokerage_id,string" db:"brokerage_id"`
-	RatingFromID int64     `json:"rating_from_id,string" db:"rating_from_id"`
-	RatingToID   int64     `json:"rating_to_id,string" db:"rating_to_id"`
-	Time         time.Time `json:"time" db:"time"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID               int64     `json:"id,string" db:"id"`
+	Ticker           string    `json:"ticker" db:"ticker" binding:"required"`
+	TargetFrom       string    `json:"target_from" db:"target_from"`
+	TargetTo         string    `json:"target_to" db:"target_to"`
+	TargetFromAmount *float64  `json:"target_from_amount,omitempty" db:"target_from_amount"`
+	TargetToAmount   *float64  `json:"target_to_amount,omitempty" db:"target_to_amount"`
+	TargetCurrency   string    `json:"target_currency,omitempty" db:"target_currency"`
+	Company          string    `json:"company" db:"company" binding:"required"`
+	ActionID         int64     `json:"action_id,string" db:"action_id"`
+	BrokerageID      int64     `json:"brokerage_id,string" db:"brokerage_id"`
+	RatingFromID     int64     `json:"rating_from_id,string" db:"rating_from_id"`
+	RatingToID       int64     `json:"rating_to_id,string" db:"rating_to_id"`
+	Time             time.Time `json:"time" db:"time"`
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
 
 	// Temporary fields for API client - not persisted to database
 	Action     string `json:"-" db:"-"`
@@ -29,22 +34,34 @@ type Stock struct {
 
 // StockWithDetails represents a stock with joined details from related tables
 type StockWithDetails struct {
-	ID             int64     `json:"id,string" db:"id"`
-	Ticker         string    `json:"ticker" db:"ticker"`
-	TargetFrom     string    `json:"target_from" db:"target_from"`
-	TargetTo       string    `json:"target_to" db:"target_to"`
-	Company        string    `json:"company" db:"company"`
-	ActionID       *int64    `json:"action_id,string,omitempty" db:"action_id"`
-	ActionName     string    `json:"action,omitempty" db:"action_name"`
-	BrokerageID    *int64    `json:"brokerage_id,string,omitempty" db:"brokerage_id"`
-	BrokerageName  string    `json:"brokerage,omitempty" db:"brokerage_name"`
-	RatingFromID   *int64    `json:"rating_from_id,string,omitempty" db:"rating_from_id"`
-	RatingFromTerm string    `json:"rating_from,omitempty" db:"rating_from_term"`
-	RatingToID     *int64    `json:"rating_to_id,string,omitempty" db:"rating_to_id"`
-	RatingToTerm   string    `json:"rating_to,omitempty" db:"rating_to_term"`
-	Time           time.Time `json:"time" db:"time"`
-	CreatedAt      time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+	ID               int64           `json:"id,string" db:"id"`
+	Ticker           string          `json:"ticker" db:"ticker"`
+	TargetFrom       string          `json:"target_from" db:"target_from"`
+	TargetTo         string          `json:"target_to" db:"target_to"`
+	TargetFromAmount *float64        `json:"target_from_amount,omitempty" db:"target_from_amount"`
+	TargetToAmount   *float64        `json:"target_to_amount,omitempty" db:"target_to_amount"`
+	TargetCurrency   string          `json:"target_currency,omitempty" db:"target_currency"`
+	Company          string          `json:"company" db:"company"`
+	ActionID         *int64          `json:"action_id,string,omitempty" db:"action_id"`
+	ActionName       string          `json:"action,omitempty" db:"action_name"`
+	ActionCategory   ActionCategory  `json:"action_category,omitempty" db:"action_category"`
+	ActionDirection  ActionDirection `json:"action_direction,omitempty" db:"action_direction"`
+	BrokerageID      *int64          `json:"brokerage_id,string,omitempty" db:"brokerage_id"`
+	BrokerageName    string          `json:"brokerage,omitempty" db:"brokerage_name"`
+	RatingFromID     *int64          `json:"rating_from_id,string,omitempty" db:"rating_from_id"`
+	RatingFromTerm   string          `json:"rating_from,omitempty" db:"rating_from_term"`
+	RatingFromBucket RatingBucket    `json:"rating_from_bucket,omitempty" db:"rating_from_bucket"`
+	RatingFromScore  *float64        `json:"rating_from_score,omitempty" db:"rating_from_score"`
+	RatingToID       *int64          `json:"rating_to_id,string,omitempty" db:"rating_to_id"`
+	RatingToTerm     string          `json:"rating_to,omitempty" db:"rating_to_term"`
+	RatingToBucket   RatingBucket    `json:"rating_to_bucket,omitempty" db:"rating_to_bucket"`
+	RatingToScore    *float64        `json:"rating_to_score,omitempty" db:"rating_to_score"`
+	Time             time.Time       `json:"time" db:"time"`
+	CreatedAt        time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at" db:"updated_at"`
+
+	// Converted holds the price targets in a requested reporting currency - not persisted to database
+	Converted *ConvertedTargets `json:"converted,omitempty" db:"-"`
 }
 
 // StockRecommendation represents a stock with its recommendation score
@@ -53,20 +70,171 @@ type StockRecommendation struct {
 	Score          float64           `json:"score"`
 	Reason         string            `json:"reason"`
 	TargetIncrease float64           `json:"target_increase_percent,omitempty"`
+	// ImpliedUpside is the percentage from the last close to target_to, set when a recent close exists
+	ImpliedUpside *float64   `json:"implied_upside_percent,omitempty"`
+	LastClose     *float64   `json:"last_close,omitempty"`
+	LastCloseDate *time.Time `json:"last_close_date,omitempty"`
+	// Factors break the score down; their contributions add up to it
+	Factors []ScoreFactor `json:"factors"`
+}
+
+// ScoreFactor is one factor's part in a recommendation score
+type ScoreFactor struct {
+	Name string `json:"name"`
+	// Value is the raw input, like the action category, the target change percent or the days since the event
+	Value interface{} `json:"value"`
+	// Score is the value normalized to the 0-10 factor scale (target changes and upside go down to -10)
+	Score        float64 `json:"score"`
+	Weight       float64 `json:"weight"`
+	Contribution float64 `json:"contribution"`
+	// Rule names the scoring rule that produced Score; only set when the recommendations are explained
+	Rule string `json:"rule,omitempty"`
+}
+
+// RecommendationQuery selects how many stocks to recommend and how to score them
+type RecommendationQuery struct {
+	// Limit caps the recommendations; 0 returns every scored stock
+	Limit int
+	// Fields limits the loaded stock fields (nil loads all); fields used for scoring are always loaded
+	Fields []string
+	// AsOf scores the stocks as they were at that time (nil for now), including their recency
+	AsOf *time.Time
+	// Strategy names the scoring strategy; empty uses the default strategy
+	Strategy string
+	// Explain keeps the rule behind every factor score
+	Explain bool
+}
+
+// TimeRange bounds event times; a nil bound is open
+type TimeRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// StockView selects which events of each ticker a stock query returns
+type StockView string
+
+// Stock views
+const (
+	// StockViewLatest returns the latest event per ticker (the default)
+	StockViewLatest StockView = "latest"
+	// StockViewHistory returns every event
+	StockViewHistory StockView = "history"
+	// StockViewByBrokerage returns the latest event per ticker and brokerage, so covering brokerages
+	// with different opinions do not hide each other
+	StockViewByBrokerage StockView = "by_brokerage"
+)
+
+// ParseStockView validates a view name; an empty name is the latest view
+func ParseStockView(s string) (StockView, bool) {
+	switch view := StockView(strings.ToLower(strings.TrimSpace(s))); view {
+	case "":
+		return StockViewLatest, true
+	case StockViewLatest, StockViewHistory, StockViewByBrokerage:
+		return view, true
+	default:
+		return "", false
+	}
+}
+
+// MaxFilterValues caps the number of values accepted by a single ValueFilter list
+const MaxFilterValues = 100
+
+// ValueFilter matches a field against values to include (any of) and values to exclude (none of)
+type ValueFilter struct {
+	In    []string
+	NotIn []string
+}
+
+// IsEmpty reports whether the filter has no values
+func (f ValueFilter) IsEmpty() bool {
+	return len(f.In) == 0 && len(f.NotIn) == 0
+}
+
+// Validate rejects lists that are too long and values that are both included and excluded
+func (f ValueFilter) Validate(name string) error {
+	if len(f.In) > MaxFilterValues || len(f.NotIn) > MaxFilterValues {
+		return fmt.Errorf("%w: %s accepts at most %d values", ErrInvalidInput, name, MaxFilterValues)
+	}
+
+	for _, excluded := range f.NotIn {
+		for _, included := range f.In {
+			if strings.EqualFold(excluded, included) {
+				return fmt.Errorf("%w: %s %q is both included and excluded", ErrInvalidInput, name, excluded)
+			}
+		}
+	}
+
+	return nil
 }
 
 // StockFilter represents filters for querying stocks
 type StockFilter struct {
-	Ticker     string
-	Company    string
-	Brokerage  string
-	Action     string
-	RatingFrom string
-	RatingTo   string
-	SortBy     string
-	SortOrder  string
-	Limit      int
-	Offset     int
+	Ticker             ValueFilter
+	Company            string
+	Brokerage          ValueFilter
+	Action             ValueFilter
+	ActionCategory     ActionCategory
+	RatingFrom         ValueFilter
+	RatingTo           ValueFilter
+	RatingBucket       RatingBucket
+	TargetToMin        *float64
+	TargetToMax        *float64
+	TargetChangePctMin *float64
+	Time               TimeRange
+	// AsOf restricts the query to events at or before it, showing the data as it was then; nil means now
+	AsOf      *time.Time
+	Expr      FilterExpr
+	View      StockView
+	Fields    []string
+	SortBy    string
+	SortOrder string
+	Limit     int
+	Offset    int
+}
+
+// Validate checks that the filter's values can be combined
+func (f StockFilter) Validate() error {
+	valueFilters := []struct {
+		name   string
+		filter ValueFilter
+	}{
+		{"ticker", f.Ticker},
+		{"brokerage", f.Brokerage},
+		{"action", f.Action},
+		{"rating_from", f.RatingFrom},
+		{"rating_to", f.RatingTo},
+	}
+	for _, vf := range valueFilters {
+		if err := vf.filter.Validate(vf.name); err != nil {
+			return err
+		}
+	}
+
+	// Exact-match fields cannot be narrowed further by exclusions, so both lists together are a mistake
+	for _, vf := range valueFilters {
+		if vf.name != "brokerage" && len(vf.filter.In) > 0 && len(vf.filter.NotIn) > 0 {
+			return fmt.Errorf("%w: %s cannot be both included and excluded in one request", ErrInvalidInput, vf.name)
+		}
+	}
+
+	if f.TargetToMin != nil && f.TargetToMax != nil && *f.TargetToMin > *f.TargetToMax {
+		return fmt.Errorf("%w: target_to_min must not exceed target_to_max", ErrInvalidInput)
+	}
+
+	if _, ok := ParseStockView(string(f.View)); !ok {
+		return fmt.Errorf("%w: unknown view %q", ErrInvalidInput, f.View)
+	}
+
+	return nil
+}
+
+// EventWindow selects stock events by when they were stored, so exports can resume from a watermark
+type EventWindow struct {
+	// Since excludes events stored at or before it; nil starts with the first event
+	Since *time.Time
+	// Until includes events stored at or before it
+	Until time.Time
 }
 
 // StockRepository defines the interface for stock data persistence
@@ -74,8 +242,17 @@ type StockRepository interface {
 	CreateBatch(stocks []*Stock) error
 	FindByID(id int64) (*StockWithDetails, error)
 	FindAll(filter StockFilter) ([]*StockWithDetails, error)
-	FindByTicker(ticker string) ([]*StockWithDetails, error)
+	FindByTicker(ticker string, timeRange TimeRange, fields []string) ([]*StockWithDetails, error)
 	Count(filter StockFilter) (int64, error)
+	Stream(ctx context.Context, filter StockFilter, fn func(*StockWithDetails) error) error
+	StreamEvents(ctx context.Context, window EventWindow, fn func(*StockWithDetails) error) error
+	CurrentTime(ctx context.Context) (time.Time, error)
+	FindTimeline(ctx context.Context, ticker string, filter TimelineFilter) ([]*TimelineEntry, error)
+	CountTimeline(ctx context.Context, ticker string, filter TimelineFilter) (int64, error)
+	AggregateEvents(ctx context.Context, query EventAnalyticsQuery) ([]*EventAggregate, error)
+	AggregateRatingTransitions(ctx context.Context, query RatingTransitionQuery) ([]*RatingTransitionCount, error)
+	FindPendingTargetBackfill(ctx context.Context, afterID int64, limit int) ([]*Stock, error)
+	UpdateTargetAmounts(ctx context.Context, stocks []*Stock) error
 }
 
 // StockAPIClient defines the interface for fetching stocks from external API
@@ -0,0 +1,88 @@
+package domain
+
+import "strconv"
+
+// StockFields lists the JSON fields of StockWithDetails that can be requested with fields=, in response order
+var StockFields = []string{
+	"id",
+	"ticker",
+	"target_from",
+	"target_to",
+	"target_from_amount",
+	"target_to_amount",
+	"target_currency",
+	"company",
+	"action_id",
+	"action",
+	"action_category",
+	"action_direction",
+	"brokerage_id",
+	"brokerage",
+	"rating_from_id",
+	"rating_from",
+	"rating_from_bucket",
+	"rating_from_score",
+	"rating_to_id",
+	"rating_to",
+	"rating_to_bucket",
+	"rating_to_score",
+	"time",
+	"created_at",
+	"updated_at",
+	"converted",
+}
+
+// stockFieldValues returns the JSON value of each field, matching the struct tags of StockWithDetails
+var stockFieldValues = map[string]func(s *StockWithDetails) interface{}{
+	"id":                 func(s *StockWithDetails) interface{} { return strconv.FormatInt(s.ID, 10) },
+	"ticker":             func(s *StockWithDetails) interface{} { return s.Ticker },
+	"target_from":        func(s *StockWithDetails) interface{} { return s.TargetFrom },
+	"target_to":          func(s *StockWithDetails) interface{} { return s.TargetTo },
+	"target_from_amount": func(s *StockWithDetails) interface{} { return s.TargetFromAmount },
+	"target_to_amount":   func(s *StockWithDetails) interface{} { return s.TargetToAmount },
+	"target_currency":    func(s *StockWithDetails) interface{} { return s.TargetCurrency },
+	"company":            func(s *StockWithDetails) interface{} { return s.Company },
+	"action_id":          func(s *StockWithDetails) interface{} { return formatOptionalID(s.ActionID) },
+	"action":             func(s *StockWithDetails) interface{} { return s.ActionName },
+	"action_category":    func(s *StockWithDetails) interface{} { return s.ActionCategory },
+	"action_direction":   func(s *StockWithDetails) interface{} { return s.ActionDirection },
+	"brokerage_id":       func(s *StockWithDetails) interface{} { return formatOptionalID(s.BrokerageID) },
+	"brokerage":          func(s *StockWithDetails) interface{} { return s.BrokerageName },
+	"rating_from_id":     func(s *StockWithDetails) interface{} { return formatOptionalID(s.RatingFromID) },
+	"rating_from":        func(s *StockWithDetails) interface{} { return s.RatingFromTerm },
+	"rating_from_bucket": func(s *StockWithDetails) interface{} { return s.RatingFromBucket },
+	"rating_from_score":  func(s *StockWithDetails) interface{} { return s.RatingFromScore },
+	"rating_to_id":       func(s *StockWithDetails) interface{} { return formatOptionalID(s.RatingToID) },
+	"rating_to":          func(s *StockWithDetails) interface{} { return s.RatingToTerm },
+	"rating_to_bucket":   func(s *StockWithDetails) interface{} { return s.RatingToBucket },
+	"rating_to_score":    func(s *StockWithDetails) interface{} { return s.RatingToScore },
+	"time":               func(s *StockWithDetails) interface{} { return s.Time },
+	"created_at":         func(s *StockWithDetails) interface{} { return s.CreatedAt },
+	"updated_at":         func(s *StockWithDetails) interface{} { return s.UpdatedAt },
+	"converted":          func(s *StockWithDetails) interface{} { return s.Converted },
+}
+
+// IsStockField reports whether name can be requested with fields=
+func IsStockField(name string) bool {
+	_, ok := stockFieldValues[name]
+	return ok
+}
+
+// Project returns only the requested fields, keyed by their JSON names. Requested fields are
+// always present, with null or an empty value when the stock has none.
+func (s *StockWithDetails) Project(fields []string) map[string]interface{} {
+	projected := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		if value, ok := stockFieldValues[field]; ok {
+			projected[field] = value(s)
+		}
+	}
+	return projected
+}
+
+func formatOptionalID(id *int64) interface{} {
+	if id == nil {
+		return nil
+	}
+	return strconv.FormatInt(*id, 10)
+}
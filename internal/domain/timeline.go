@@ -0,0 +1,71 @@
+package domain
+
+import "time"
+
+// RatingMovement describes how a rating moved between two events
+type RatingMovement string
+
+// Rating movements; unknown means one of the ratings has no score
+const (
+	RatingMovementUpgrade   RatingMovement = "upgrade"
+	RatingMovementDowngrade RatingMovement = "downgrade"
+	RatingMovementUnchanged RatingMovement = "unchanged"
+	RatingMovementUnknown   RatingMovement = "unknown"
+)
+
+// EventChange describes what changed between an earlier event of the same ticker and a later one
+type EventChange struct {
+	PreviousID        int64     `json:"previous_id,string"`
+	PreviousTime      time.Time `json:"previous_time"`
+	PreviousBrokerage string    `json:"previous_brokerage,omitempty"`
+
+	// RatingFrom and RatingTo are the rating_to terms of the earlier and the later event
+	RatingFrom       string         `json:"rating_from,omitempty"`
+	RatingTo         string         `json:"rating_to,omitempty"`
+	RatingChanged    bool           `json:"rating_changed"`
+	RatingMovement   RatingMovement `json:"rating_movement"`
+	RatingScoreDelta *float64       `json:"rating_score_delta,omitempty"`
+
+	// TargetFrom and TargetTo are the numeric target_to of the earlier and the later event.
+	// The delta is only set when both targets exist in the same currency.
+	TargetFrom      *float64 `json:"target_from,omitempty"`
+	TargetTo        *float64 `json:"target_to,omitempty"`
+	TargetDelta     *float64 `json:"target_delta,omitempty"`
+	TargetChangePct *float64 `json:"target_change_pct,omitempty"`
+
+	PreviousAction string `json:"previous_action,omitempty"`
+	ActionChanged  bool   `json:"action_changed"`
+}
+
+// TimelineEvent is a stock event annotated with what changed since the previous event of the ticker
+// by any brokerage and since the previous event by the same brokerage. Either change is nil when
+// there is no such earlier event.
+type TimelineEvent struct {
+	*StockWithDetails
+	SincePrevious  *EventChange `json:"since_previous"`
+	SinceBrokerage *EventChange `json:"since_brokerage"`
+}
+
+// TimelineEntry is a stock event with the earlier events a TimelineEvent is diffed against
+type TimelineEntry struct {
+	Stock *StockWithDetails
+	// Previous is the previous event of the ticker by any brokerage
+	Previous *StockWithDetails
+	// PreviousByBrokerage is the previous event of the ticker by the same brokerage
+	PreviousByBrokerage *StockWithDetails
+}
+
+// TimelineFilter selects and pages the events of a ticker's timeline. Filters only select which
+// events are returned; changes are always relative to the ticker's full history.
+type TimelineFilter struct {
+	Brokerage ValueFilter
+	Time      TimeRange
+	SortOrder string
+	Limit     int
+	Offset    int
+}
+
+// Validate checks that the filter's values can be combined
+func (f TimelineFilter) Validate() error {
+	return f.Brokerage.Validate("brokerage")
+}
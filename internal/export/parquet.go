@@ -0,0 +1,248 @@
+// Package export writes stock events as Parquet files for analytics pipelines.
+// Files are partitioned by month of the event time (month=YYYY-MM/), so DuckDB, pandas and Spark
+// can read an export directory as one hive-partitioned dataset.
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/parquet-go/parquet-go"
+)
+
+// Parquet file layout
+const (
+	// rowGroupSize is the number of rows buffered in memory before a row group is written
+	rowGroupSize = 64 * 1024
+	// writeBatchSize is the number of rows handed to the Parquet writer at once
+	writeBatchSize = 1024
+	// WatermarkFile stores the watermark of the last export next to its partitions
+	WatermarkFile = "_watermark"
+	// watermarkLayout formats watermarks; fileStampLayout names the files of an export after its watermark
+	watermarkLayout = time.RFC3339Nano
+	fileStampLayout = "20060102T150405.000000Z"
+)
+
+// EventSource streams the stock events stored within a window, oldest event first
+type EventSource func(ctx context.Context, window domain.EventWindow, fn func(*domain.StockWithDetails) error) error
+
+// Sink creates the files of an export; name is a slash-separated path relative to the export root
+type Sink interface {
+	Create(name string) (io.WriteCloser, error)
+}
+
+// File describes one Parquet file written by an export
+type File struct {
+	Name  string `json:"name"`
+	Month string `json:"month"`
+	Rows  int64  `json:"rows"`
+}
+
+// Summary describes a finished export
+type Summary struct {
+	// Watermark is the end of the exported window; pass it as the next export's since to continue
+	Watermark time.Time `json:"watermark"`
+	Rows      int64     `json:"rows"`
+	Files     []File    `json:"files"`
+}
+
+// eventRow is the Parquet schema of an exported stock event. Names are dictionary-encoded,
+// amounts and scores are nullable doubles and times are UTC timestamps.
+type eventRow struct {
+	ID               int64     `parquet:"id"`
+	Ticker           string    `parquet:"ticker,dict"`
+	Company          string    `parquet:"company,dict"`
+	TargetFrom       *string   `parquet:"target_from,optional"`
+	TargetTo         *string   `parquet:"target_to,optional"`
+	TargetFromAmount *float64  `parquet:"target_from_amount,optional"`
+	TargetToAmount   *float64  `parquet:"target_to_amount,optional"`
+	TargetCurrency   *string   `parquet:"target_currency,optional,dict"`
+	ActionID         *int64    `parquet:"action_id,optional"`
+	Action           *string   `parquet:"action,optional,dict"`
+	ActionCategory   *string   `parquet:"action_category,optional,dict"`
+	ActionDirection  *string   `parquet:"action_direction,optional,dict"`
+	BrokerageID      *int64    `parquet:"brokerage_id,optional"`
+	Brokerage        *string   `parquet:"brokerage,optional,dict"`
+	RatingFromID     *int64    `parquet:"rating_from_id,optional"`
+	RatingFrom       *string   `parquet:"rating_from,optional,dict"`
+	RatingFromBucket *string   `parquet:"rating_from_bucket,optional,dict"`
+	RatingFromScore  *float64  `parquet:"rating_from_score,optional"`
+	RatingToID       *int64    `parquet:"rating_to_id,optional"`
+	RatingTo         *string   `parquet:"rating_to,optional,dict"`
+	RatingToBucket   *string   `parquet:"rating_to_bucket,optional,dict"`
+	RatingToScore    *float64  `parquet:"rating_to_score,optional"`
+	Time             time.Time `parquet:"time,timestamp(microsecond)"`
+	CreatedAt        time.Time `parquet:"created_at,timestamp(microsecond)"`
+	UpdatedAt        time.Time `parquet:"updated_at,timestamp(microsecond)"`
+}
+
+func toEventRow(stock *domain.StockWithDetails) eventRow {
+	return eventRow{
+		ID:               stock.ID,
+		Ticker:           stock.Ticker,
+		Company:          stock.Company,
+		TargetFrom:       optionalString(stock.TargetFrom),
+		TargetTo:         optionalString(stock.TargetTo),
+		TargetFromAmount: stock.TargetFromAmount,
+		TargetToAmount:   stock.TargetToAmount,
+		TargetCurrency:   optionalString(stock.TargetCurrency),
+		ActionID:         stock.ActionID,
+		Action:           optionalString(stock.ActionName),
+		ActionCategory:   optionalString(string(stock.ActionCategory)),
+		ActionDirection:  optionalString(string(stock.ActionDirection)),
+		BrokerageID:      stock.BrokerageID,
+		Brokerage:        optionalString(stock.BrokerageName),
+		RatingFromID:     stock.RatingFromID,
+		RatingFrom:       optionalString(stock.RatingFromTerm),
+		RatingFromBucket: optionalString(string(stock.RatingFromBucket)),
+		RatingFromScore:  stock.RatingFromScore,
+		RatingToID:       stock.RatingToID,
+		RatingTo:         optionalString(stock.RatingToTerm),
+		RatingToBucket:   optionalString(string(stock.RatingToBucket)),
+		RatingToScore:    stock.RatingToScore,
+		Time:             stock.Time.UTC(),
+		CreatedAt:        stock.CreatedAt.UTC(),
+		UpdatedAt:        stock.UpdatedAt.UTC(),
+	}
+}
+
+// optionalString stores empty strings as nulls
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// partitionWriter writes the rows of one month to one Parquet file
+type partitionWriter struct {
+	file   File
+	output io.WriteCloser
+	writer *parquet.GenericWriter[eventRow]
+	rows   []eventRow
+}
+
+func (p *partitionWriter) write(row eventRow) error {
+	p.rows = append(p.rows, row)
+	p.file.Rows++
+	if len(p.rows) < writeBatchSize {
+		return nil
+	}
+	return p.flushRows()
+}
+
+func (p *partitionWriter) flushRows() error {
+	if _, err := p.writer.Write(p.rows); err != nil {
+		return err
+	}
+	p.rows = p.rows[:0]
+	return nil
+}
+
+func (p *partitionWriter) close() error {
+	if err := p.flushRows(); err != nil {
+		p.output.Close()
+		return err
+	}
+	if err := p.writer.Close(); err != nil {
+		p.output.Close()
+		return err
+	}
+	return p.output.Close()
+}
+
+// WriteParquet writes every event the source returns for the window to month partitions in sink,
+// followed by the window's watermark in WatermarkFile. Files are named after the watermark, so
+// incremental exports add files to existing partitions instead of replacing them.
+func WriteParquet(ctx context.Context, source EventSource, window domain.EventWindow, sink Sink) (*Summary, error) {
+	summary := &Summary{Watermark: window.Until, Files: []File{}}
+	stamp := window.Until.UTC().Format(fileStampLayout)
+
+	var current *partitionWriter
+	closeCurrent := func() error {
+		if current == nil {
+			return nil
+		}
+		err := current.close()
+		summary.Files = append(summary.Files, current.file)
+		current = nil
+		return err
+	}
+
+	err := source(ctx, window, func(stock *domain.StockWithDetails) error {
+		month := stock.Time.UTC().Format("2006-01")
+		if current == nil || current.file.Month != month {
+			// Events arrive in time order, so each month is written once
+			if err := closeCurrent(); err != nil {
+				return err
+			}
+			name := fmt.Sprintf("month=%s/stocks-%s.parquet", month, stamp)
+			output, err := sink.Create(name)
+			if err != nil {
+				return err
+			}
+			current = &partitionWriter{
+				file:   File{Name: name, Month: month},
+				output: output,
+				writer: parquet.NewGenericWriter[eventRow](output,
+					parquet.Compression(&parquet.Snappy),
+					parquet.MaxRowsPerRowGroup(rowGroupSize),
+					parquet.KeyValueMetadata("watermark", FormatWatermark(window.Until)),
+				),
+			}
+		}
+
+		summary.Rows++
+		return current.write(toEventRow(stock))
+	})
+	if err != nil {
+		if current != nil {
+			current.output.Close()
+		}
+		return nil, err
+	}
+	if err := closeCurrent(); err != nil {
+		return nil, fmt.Errorf("failed to write parquet file: %w", err)
+	}
+
+	if err := writeWatermark(sink, window.Until); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+func writeWatermark(sink Sink, watermark time.Time) error {
+	output, err := sink.Create(WatermarkFile)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(output, FormatWatermark(watermark)+"\n"); err != nil {
+		output.Close()
+		return fmt.Errorf("failed to write watermark: %w", err)
+	}
+	return output.Close()
+}
+
+// FormatWatermark renders a watermark as RFC 3339 with nanoseconds in UTC
+func FormatWatermark(watermark time.Time) string {
+	return watermark.UTC().Format(watermarkLayout)
+}
+
+// ParseWatermark parses a watermark written by FormatWatermark or any RFC 3339 time
+func ParseWatermark(value string) (time.Time, error) {
+	watermark, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: watermark must be an RFC 3339 time", domain.ErrInvalidInput)
+	}
+	return watermark.UTC(), nil
+}
+
+// NewWindow returns the window of events stored after since (nil for all events) up to until.
+// until is truncated to the database's microsecond precision so the watermark matches stored times exactly.
+func NewWindow(since *time.Time, until time.Time) domain.EventWindow {
+	return domain.EventWindow{Since: since, Until: until.UTC().Truncate(time.Microsecond)}
+}
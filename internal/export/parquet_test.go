@@ -0,0 +1,225 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+)
+
+// memorySink keeps the files of an export in memory
+type memorySink struct {
+	files map[string]*bytes.Buffer
+	order []string
+}
+
+func newMemorySink() *memorySink {
+	return &memorySink{files: map[string]*bytes.Buffer{}}
+}
+
+func (s *memorySink) Create(name string) (io.WriteCloser, error) {
+	buffer := &bytes.Buffer{}
+	s.files[name] = buffer
+	s.order = append(s.order, name)
+	return nopCloser{buffer}, nil
+}
+
+// sliceSource serves events from a slice, checking the window it is asked for
+func sliceSource(t *testing.T, want domain.EventWindow, stocks []*domain.StockWithDetails) EventSource {
+	return func(ctx context.Context, window domain.EventWindow, fn func(*domain.StockWithDetails) error) error {
+		assert.Equal(t, want, window)
+		for _, stock := range stocks {
+			if err := fn(stock); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func readRows(t *testing.T, data []byte) []eventRow {
+	rows, err := parquet.Read[eventRow](bytes.NewReader(data), int64(len(data)))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return rows
+}
+
+func testEvents() []*domain.StockWithDetails {
+	amount := 250.0
+	brokerageID := int64(7)
+	return []*domain.StockWithDetails{
+		{ID: 1, Ticker: "AAPL", Company: "Apple Inc.", TargetTo: "$250.00", TargetToAmount: &amount, TargetCurrency: "USD",
+			BrokerageID: &brokerageID, BrokerageName: "Goldman Sachs", RatingToTerm: "Buy", RatingToBucket: domain.RatingBucketBuy,
+			Time: time.Date(2024, 4, 30, 23, 0, 0, 0, time.UTC)},
+		{ID: 2, Ticker: "MSFT", Company: "Microsoft", Time: time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)},
+		{ID: 3, Ticker: "AAPL", Company: "Apple Inc.", Time: time.Date(2024, 5, 20, 9, 30, 0, 0, time.UTC)},
+	}
+}
+
+func TestWriteParquet(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	window := NewWindow(&since, time.Date(2024, 6, 1, 12, 0, 0, 123456789, time.UTC))
+	sink := newMemorySink()
+
+	summary, err := WriteParquet(context.Background(), sliceSource(t, window, testEvents()), window, sink)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), summary.Rows)
+	assert.Equal(t, time.Date(2024, 6, 1, 12, 0, 0, 123456000, time.UTC), summary.Watermark, "watermark is truncated to microseconds")
+	assert.Equal(t, []File{
+		{Name: "month=2024-04/stocks-20240601T120000.123456Z.parquet", Month: "2024-04", Rows: 1},
+		{Name: "month=2024-05/stocks-20240601T120000.123456Z.parquet", Month: "2024-05", Rows: 2},
+	}, summary.Files)
+	assert.Equal(t, []string{summary.Files[0].Name, summary.Files[1].Name, WatermarkFile}, sink.order)
+	assert.Equal(t, "2024-06-01T12:00:00.123456Z\n", sink.files[WatermarkFile].String())
+
+	april := readRows(t, sink.files[summary.Files[0].Name].Bytes())
+	if assert.Len(t, april, 1) {
+		assert.Equal(t, "AAPL", april[0].Ticker)
+		assert.Equal(t, 250.0, *april[0].TargetToAmount)
+		assert.Equal(t, "Goldman Sachs", *april[0].Brokerage)
+		assert.Equal(t, int64(7), *april[0].BrokerageID)
+		assert.Equal(t, "buy", *april[0].RatingToBucket)
+		assert.Nil(t, april[0].RatingFrom, "empty names are stored as nulls")
+		assert.True(t, april[0].Time.Equal(time.Date(2024, 4, 30, 23, 0, 0, 0, time.UTC)))
+	}
+
+	may := readRows(t, sink.files[summary.Files[1].Name].Bytes())
+	if assert.Len(t, may, 2) {
+		assert.Equal(t, []int64{2, 3}, []int64{may[0].ID, may[1].ID})
+		assert.Nil(t, may[0].TargetToAmount)
+	}
+}
+
+func TestWriteParquet_Schema(t *testing.T) {
+	window := NewWindow(nil, time.Now())
+	sink := newMemorySink()
+
+	summary, err := WriteParquet(context.Background(), sliceSource(t, window, testEvents()[:1]), window, sink)
+	assert.NoError(t, err)
+
+	data := sink.files[summary.Files[0].Name].Bytes()
+	file, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	columns := map[string]parquet.Field{}
+	for _, field := range file.Schema().Fields() {
+		columns[field.Name()] = field
+	}
+	assert.Equal(t, parquet.Timestamp(parquet.Microsecond).Type().String(), columns["time"].Type().String())
+	assert.Equal(t, parquet.DoubleType, columns["target_to_amount"].Type())
+	assert.True(t, columns["target_to_amount"].Optional())
+	assert.Equal(t, &parquet.RLEDictionary, columns["brokerage"].Encoding())
+	assert.Equal(t, &parquet.RLEDictionary, columns["ticker"].Encoding())
+
+	watermark, ok := file.Lookup("watermark")
+	assert.True(t, ok)
+	assert.Equal(t, FormatWatermark(window.Until), watermark)
+}
+
+func TestWriteParquet_NoEvents(t *testing.T) {
+	window := NewWindow(nil, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	sink := newMemorySink()
+
+	summary, err := WriteParquet(context.Background(), sliceSource(t, window, nil), window, sink)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), summary.Rows)
+	assert.Empty(t, summary.Files)
+	assert.Equal(t, []string{WatermarkFile}, sink.order, "the watermark still advances")
+}
+
+func TestWriteParquet_SourceError(t *testing.T) {
+	window := NewWindow(nil, time.Now())
+	sink := newMemorySink()
+	source := func(ctx context.Context, window domain.EventWindow, fn func(*domain.StockWithDetails) error) error {
+		fn(testEvents()[0])
+		return errors.New("database error")
+	}
+
+	summary, err := WriteParquet(context.Background(), source, window, sink)
+
+	assert.Error(t, err)
+	assert.Nil(t, summary)
+	assert.NotContains(t, sink.files, WatermarkFile)
+}
+
+func TestDirSink(t *testing.T) {
+	dir := t.TempDir()
+	window := NewWindow(nil, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	t.Run("Abort leaves nothing behind", func(t *testing.T) {
+		sink, err := NewDirSink(dir)
+		assert.NoError(t, err)
+		source := func(ctx context.Context, window domain.EventWindow, fn func(*domain.StockWithDetails) error) error {
+			fn(testEvents()[0])
+			return errors.New("database error")
+		}
+
+		_, err = WriteParquet(context.Background(), source, window, sink)
+		assert.Error(t, err)
+		sink.Abort()
+
+		matches, _ := filepath.Glob(filepath.Join(dir, "*", "*"))
+		assert.Empty(t, matches)
+		watermark, err := ReadWatermark(dir)
+		assert.NoError(t, err)
+		assert.Nil(t, watermark)
+	})
+
+	t.Run("Commit publishes partitions and the watermark", func(t *testing.T) {
+		sink, err := NewDirSink(dir)
+		assert.NoError(t, err)
+
+		summary, err := WriteParquet(context.Background(), sliceSource(t, window, testEvents()), window, sink)
+		assert.NoError(t, err)
+		assert.NoError(t, sink.Commit())
+
+		for _, file := range summary.Files {
+			_, err := os.Stat(filepath.Join(dir, filepath.FromSlash(file.Name)))
+			assert.NoError(t, err)
+		}
+		watermark, err := ReadWatermark(dir)
+		assert.NoError(t, err)
+		if assert.NotNil(t, watermark) {
+			assert.Equal(t, window.Until, *watermark)
+		}
+	})
+}
+
+func TestZipSink(t *testing.T) {
+	window := NewWindow(nil, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	var archive bytes.Buffer
+	sink := NewZipSink(&archive)
+
+	summary, err := WriteParquet(context.Background(), sliceSource(t, window, testEvents()), window, sink)
+	assert.NoError(t, err)
+	assert.NoError(t, sink.Close())
+
+	reader, err := zip.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	var names []string
+	for _, entry := range reader.File {
+		names = append(names, entry.Name)
+	}
+	assert.Equal(t, []string{summary.Files[0].Name, summary.Files[1].Name, WatermarkFile}, names)
+
+	entry, err := reader.File[1].Open()
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(entry)
+	assert.Len(t, readRows(t, data), 2)
+}
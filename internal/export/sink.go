@@ -0,0 +1,111 @@
+package export
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DirSink writes an export into a directory. Files are staged under temporary names and only
+// replace their final names on Commit, so a failed export leaves no partial files or watermark behind.
+type DirSink struct {
+	dir    string
+	staged []string
+}
+
+// NewDirSink creates a DirSink writing into dir, creating it if needed
+func NewDirSink(dir string) (*DirSink, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+	return &DirSink{dir: dir}, nil
+}
+
+// Create implements Sink
+func (s *DirSink) Create(name string) (io.WriteCloser, error) {
+	path := filepath.Join(s.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create partition directory: %w", err)
+	}
+
+	file, err := os.Create(path + ".tmp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create export file: %w", err)
+	}
+	s.staged = append(s.staged, path)
+	return file, nil
+}
+
+// Commit moves every staged file to its final name
+func (s *DirSink) Commit() error {
+	for _, path := range s.staged {
+		if err := os.Rename(path+".tmp", path); err != nil {
+			return fmt.Errorf("failed to commit export file: %w", err)
+		}
+	}
+	s.staged = nil
+	return nil
+}
+
+// Abort removes every staged file
+func (s *DirSink) Abort() {
+	for _, path := range s.staged {
+		os.Remove(path + ".tmp")
+	}
+	s.staged = nil
+}
+
+// ReadWatermark returns the watermark of the last export committed to dir, or nil if there is none
+func ReadWatermark(dir string) (*time.Time, error) {
+	data, err := os.ReadFile(filepath.Join(dir, WatermarkFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read watermark: %w", err)
+	}
+
+	watermark, err := ParseWatermark(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, err
+	}
+	return &watermark, nil
+}
+
+// ZipSink writes an export as a zip archive, one entry per file, streaming entries as they are written
+type ZipSink struct {
+	writer *zip.Writer
+}
+
+// NewZipSink creates a ZipSink writing to w
+func NewZipSink(w io.Writer) *ZipSink {
+	return &ZipSink{writer: zip.NewWriter(w)}
+}
+
+// Create implements Sink. Entries must be written one at a time, which WriteParquet does.
+func (s *ZipSink) Create(name string) (io.WriteCloser, error) {
+	entry, err := s.writer.Create(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zip entry: %w", err)
+	}
+	return nopCloser{entry}, nil
+}
+
+// Close writes the zip central directory
+func (s *ZipSink) Close() error {
+	return s.writer.Close()
+}
+
+// nopCloser closes nothing; zip entries end when the next one is created
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,123 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/gin-gonic/gin"
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"go.uber.org/zap"
+)
+
+// Request is a GraphQL request, sent as a JSON body or as GET query parameters
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Handler serves GraphQL requests over HTTP
+type Handler struct {
+	schema   gql.Schema
+	resolver *Resolver
+	limits   Limits
+	logger   *zap.Logger
+}
+
+// NewHandler builds the schema and creates a new Handler
+func NewHandler(resolver *Resolver, limits Limits, logger *zap.Logger) (*Handler, error) {
+	schema, err := NewSchema(resolver)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build GraphQL schema: %w", err)
+	}
+
+	return &Handler{
+		schema:   schema,
+		resolver: resolver,
+		limits:   limits,
+		logger:   logger,
+	}, nil
+}
+
+// Serve godoc
+// @Summary GraphQL endpoint
+// @Description Executes a GraphQL query over stocks, brokerages, actions, ratings and recommendations.
+// @Description Queries exceeding the configured depth or complexity limits are rejected with 400.
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Param request body Request true "GraphQL request"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /graphql [post]
+func (h *Handler) Serve(c *gin.Context) {
+	request, err := h.parseRequest(c)
+	if err != nil {
+		h.respondWithErrors(c, http.StatusBadRequest, gqlerrors.FormatErrors(err))
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(request.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		h.respondWithErrors(c, http.StatusBadRequest, gqlerrors.FormatErrors(err))
+		return
+	}
+
+	validation := gql.ValidateDocument(&h.schema, doc, nil)
+	if !validation.IsValid {
+		h.respondWithErrors(c, http.StatusBadRequest, validation.Errors)
+		return
+	}
+
+	if err := checkLimits(h.schema, doc, request.Variables, h.limits); err != nil {
+		h.respondWithErrors(c, http.StatusBadRequest, gqlerrors.FormatErrors(err))
+		return
+	}
+
+	ctx := c.Request.Context()
+	loaders := NewLoaders(ctx, h.resolver.brokerageUC, h.resolver.actionUC, h.resolver.ratingUC)
+	result := gql.Execute(gql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: request.OperationName,
+		Args:          request.Variables,
+		Context:       withLoaders(ctx, loaders),
+	})
+	if result.HasErrors() {
+		h.logger.Warn("GraphQL query returned errors", zap.Any("errors", result.Errors))
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// parseRequest reads the request from the JSON body of a POST or the query parameters of a GET
+func (h *Handler) parseRequest(c *gin.Context) (*Request, error) {
+	var request Request
+	if c.Request.Method == http.MethodGet {
+		request.Query = c.Query("query")
+		request.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+				return nil, fmt.Errorf("%w: variables must be a JSON object", domain.ErrInvalidInput)
+			}
+		}
+	} else if err := c.ShouldBindJSON(&request); err != nil {
+		return nil, fmt.Errorf("%w: request body must be a JSON object with a query", domain.ErrInvalidInput)
+	}
+
+	if request.Query == "" {
+		return nil, fmt.Errorf("%w: query is required", domain.ErrInvalidInput)
+	}
+	return &request, nil
+}
+
+func (h *Handler) respondWithErrors(c *gin.Context, statusCode int, errs []gqlerrors.FormattedError) {
+	c.JSON(statusCode, &gql.Result{Errors: errs})
+}
@@ -0,0 +1,284 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/company/stock-api/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// MockStockRepository is a mock of the domain.StockRepository methods the resolvers use;
+// the embedded interface panics on anything else
+type MockStockRepository struct {
+	domain.StockRepository
+	mock.Mock
+}
+
+func (m *MockStockRepository) FindAll(filter domain.StockFilter) ([]*domain.StockWithDetails, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.StockWithDetails), args.Error(1)
+}
+
+func (m *MockStockRepository) Count(filter domain.StockFilter) (int64, error) {
+	args := m.Called(filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStockRepository) FindByID(id int64) (*domain.StockWithDetails, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.StockWithDetails), args.Error(1)
+}
+
+// MockBrokerageRepository is a mock of domain.BrokerageRepository batch lookups
+type MockBrokerageRepository struct {
+	domain.BrokerageRepository
+	mock.Mock
+}
+
+func (m *MockBrokerageRepository) FindByIDs(ctx context.Context, ids []int64) ([]*domain.Brokerage, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]*domain.Brokerage), args.Error(1)
+}
+
+// MockRatingRepository is a mock of domain.RatingRepository batch lookups
+type MockRatingRepository struct {
+	domain.RatingRepository
+	mock.Mock
+}
+
+func (m *MockRatingRepository) FindByIDs(ctx context.Context, ids []int64) ([]*domain.Rating, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]*domain.Rating), args.Error(1)
+}
+
+type graphqlTestEnv struct {
+	stockRepo     *MockStockRepository
+	brokerageRepo *MockBrokerageRepository
+	ratingRepo    *MockRatingRepository
+	router        *gin.Engine
+}
+
+func newGraphQLTestEnv(t *testing.T, limits Limits) *graphqlTestEnv {
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+	env := &graphqlTestEnv{
+		stockRepo:     new(MockStockRepository),
+		brokerageRepo: new(MockBrokerageRepository),
+		ratingRepo:    new(MockRatingRepository),
+		router:        gin.New(),
+	}
+
+	brokerageUC := usecase.NewBrokerageUseCase(env.brokerageRepo, logger)
+	ratingUC := usecase.NewRatingUseCase(env.ratingRepo, logger)
+	actionUC := usecase.NewActionUseCase(nil, nil, logger)
+	stockUC := usecase.NewStockUseCase(env.stockRepo, nil, brokerageUC, actionUC, ratingUC, nil, nil, logger)
+
+	handler, err := NewHandler(NewResolver(stockUC, brokerageUC, actionUC, ratingUC), limits, logger)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	env.router.POST("/graphql", handler.Serve)
+	env.router.GET("/graphql", handler.Serve)
+	return env
+}
+
+func (env *graphqlTestEnv) post(query string, variables map[string]interface{}) (int, map[string]interface{}) {
+	body, _ := json.Marshal(Request{Query: query, Variables: variables})
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	env.router.ServeHTTP(w, req)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	return w.Code, response
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestHandler_Stocks(t *testing.T) {
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	stocks := []*domain.StockWithDetails{
+		{ID: 1, Ticker: "AAPL", Company: "Apple Inc.", BrokerageID: int64Ptr(10), RatingFromID: int64Ptr(20), RatingToID: int64Ptr(21), Time: now},
+		{ID: 2, Ticker: "MSFT", Company: "Microsoft", BrokerageID: int64Ptr(10), RatingFromID: int64Ptr(21), RatingToID: int64Ptr(22), Time: now},
+		{ID: 3, Ticker: "TSLA", Company: "Tesla", BrokerageID: int64Ptr(11), Time: now},
+	}
+
+	t.Run("Batches nested lookups and paginates", func(t *testing.T) {
+		env := newGraphQLTestEnv(t, Limits{MaxDepth: 10, MaxComplexity: 5000})
+
+		env.stockRepo.On("FindAll", mock.MatchedBy(func(filter domain.StockFilter) bool {
+			return filter.Limit == 3 && filter.Offset == 0 && filter.SortBy == "ticker" && filter.SortOrder == "asc" &&
+				assert.ObjectsAreEqual([]string{"AAPL", "MSFT", "TSLA"}, filter.Ticker.In) &&
+				filter.RatingBucket == domain.RatingBucketBuy
+		})).Return(stocks, nil).Once()
+		env.stockRepo.On("Count", mock.Anything).Return(int64(3), nil).Once()
+		env.brokerageRepo.On("FindByIDs", mock.Anything, []int64{10}).Return([]*domain.Brokerage{
+			{ID: 10, Name: "Goldman Sachs"},
+		}, nil).Once()
+		env.ratingRepo.On("FindByIDs", mock.Anything, []int64{20, 21, 22}).Return([]*domain.Rating{
+			{ID: 20, Term: "Hold", Bucket: domain.RatingBucketHold}, {ID: 21, Term: "Buy", Bucket: domain.RatingBucketBuy}, {ID: 22, Term: "Strong-Buy"},
+		}, nil).Once()
+
+		query := `query($filter: StockFilterInput) {
+			stocks(first: 2, sortBy: TICKER, sortOrder: ASC, filter: $filter) {
+				totalCount
+				pageInfo { hasNextPage hasPreviousPage endCursor }
+				edges { cursor node { ticker brokerage { name } ratingFrom { term } ratingTo { term bucket } } }
+			}
+		}`
+		status, response := env.post(query, map[string]interface{}{
+			"filter": map[string]interface{}{"ticker": []string{"AAPL", "MSFT", "TSLA"}, "ratingBucket": "BUY"},
+		})
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Nil(t, response["errors"])
+		connection := response["data"].(map[string]interface{})["stocks"].(map[string]interface{})
+		assert.Equal(t, float64(3), connection["totalCount"])
+		pageInfo := connection["pageInfo"].(map[string]interface{})
+		assert.Equal(t, true, pageInfo["hasNextPage"])
+		assert.Equal(t, false, pageInfo["hasPreviousPage"])
+		assert.Equal(t, encodeCursor(1), pageInfo["endCursor"])
+
+		edges := connection["edges"].([]interface{})
+		assert.Len(t, edges, 2)
+		second := edges[1].(map[string]interface{})["node"].(map[string]interface{})
+		assert.Equal(t, "MSFT", second["ticker"])
+		assert.Equal(t, map[string]interface{}{"name": "Goldman Sachs"}, second["brokerage"])
+		assert.Equal(t, map[string]interface{}{"term": "Strong-Buy", "bucket": nil}, second["ratingTo"])
+
+		// One query per entity type, however many stocks reference it
+		env.stockRepo.AssertExpectations(t)
+		env.brokerageRepo.AssertExpectations(t)
+		env.ratingRepo.AssertExpectations(t)
+	})
+
+	t.Run("After cursor continues from the next offset", func(t *testing.T) {
+		env := newGraphQLTestEnv(t, Limits{MaxDepth: 10, MaxComplexity: 5000})
+
+		env.stockRepo.On("FindAll", mock.MatchedBy(func(filter domain.StockFilter) bool {
+			return filter.Offset == 2 && filter.Limit == 3
+		})).Return(stocks[2:], nil).Once()
+
+		status, response := env.post(`query($after: String) { stocks(first: 2, after: $after) { pageInfo { hasNextPage hasPreviousPage } edges { cursor } } }`,
+			map[string]interface{}{"after": encodeCursor(1)})
+
+		assert.Equal(t, http.StatusOK, status)
+		connection := response["data"].(map[string]interface{})["stocks"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"hasNextPage": false, "hasPreviousPage": true}, connection["pageInfo"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"cursor": encodeCursor(2)}}, connection["edges"])
+		env.stockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid filter is a field error", func(t *testing.T) {
+		env := newGraphQLTestEnv(t, Limits{MaxDepth: 10, MaxComplexity: 5000})
+
+		status, response := env.post(`{ stocks(filter: {ticker: ["AAPL"], tickerNot: ["aapl"]}) { totalCount } }`, nil)
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Contains(t, response["errors"].([]interface{})[0].(map[string]interface{})["message"], "both included and excluded")
+		env.stockRepo.AssertNotCalled(t, "FindAll", mock.Anything)
+	})
+}
+
+func TestHandler_Stock(t *testing.T) {
+	env := newGraphQLTestEnv(t, Limits{MaxDepth: 10, MaxComplexity: 5000})
+	env.stockRepo.On("FindByID", int64(404)).Return(nil, domain.ErrNotFound).Once()
+
+	status, response := env.post(`{ stock(id: "404") { ticker } }`, nil)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Nil(t, response["errors"])
+	assert.Equal(t, map[string]interface{}{"stock": nil}, response["data"])
+}
+
+func TestHandler_Recommendations(t *testing.T) {
+	env := newGraphQLTestEnv(t, Limits{MaxDepth: 10, MaxComplexity: 5000})
+	env.stockRepo.On("FindAll", mock.Anything).Return([]*domain.StockWithDetails{
+		{Ticker: "AAPL", ActionCategory: domain.ActionCategoryUpgrade, Time: time.Now()},
+	}, nil).Once()
+
+	status, response := env.post(`{ recommendations(limit: 5, strategy: "momentum") { score factors { name value score weight contribution } } }`, nil)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Nil(t, response["errors"])
+	data := response["data"].(map[string]interface{})["recommendations"].([]interface{})
+	if !assert.Len(t, data, 1) {
+		t.FailNow()
+	}
+	factors := data[0].(map[string]interface{})["factors"].([]interface{})
+	if assert.Len(t, factors, 3) {
+		assert.Equal(t, map[string]interface{}{
+			"name": "action", "value": "upgrade", "score": 10.0, "weight": 0.35, "contribution": 3.5,
+		}, factors[0])
+	}
+
+	status, response = env.post(`{ recommendations(strategy: "yolo") { score } }`, nil)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.NotNil(t, response["errors"])
+}
+
+func TestHandler_RejectsInvalidQueries(t *testing.T) {
+	env := newGraphQLTestEnv(t, Limits{MaxDepth: 3, MaxComplexity: 50})
+
+	tests := []struct {
+		name    string
+		query   string
+		message string
+	}{
+		{"syntax error", `{ stocks {`, "Syntax Error"},
+		{"unknown field", `{ stocks { unknown } }`, "Cannot query field"},
+		{"too deep", `{ stocks { edges { node { ticker } } } }`, "query depth 4 exceeds the maximum of 3"},
+		{"too complex", `{ stocks(first: 100) { totalCount } }`, "query complexity 101 exceeds the maximum of 50"},
+		{"missing query", ``, "query is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, response := env.post(tt.query, nil)
+
+			assert.Equal(t, http.StatusBadRequest, status)
+			errs, _ := response["errors"].([]interface{})
+			if assert.NotEmpty(t, errs) {
+				assert.Contains(t, errs[0].(map[string]interface{})["message"], tt.message)
+			}
+		})
+	}
+}
+
+func TestHandler_Get(t *testing.T) {
+	env := newGraphQLTestEnv(t, Limits{MaxDepth: 10, MaxComplexity: 5000})
+	env.stockRepo.On("Count", mock.Anything).Return(int64(7), nil).Once()
+
+	params := url.Values{}
+	params.Set("query", `query($first: Int) { stocks(first: $first) { totalCount } }`)
+	params.Set("variables", `{"first": 1}`)
+	req := httptest.NewRequest(http.MethodGet, "/graphql?"+params.Encode(), nil)
+	w := httptest.NewRecorder()
+	env.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"data":{"stocks":{"totalCount":7}}}`, w.Body.String())
+	// Only the count is selected, so no page is loaded
+	env.stockRepo.AssertNotCalled(t, "FindAll", mock.Anything)
+}
@@ -0,0 +1,196 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/company/stock-api/internal/domain"
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// assumedListSize is the complexity multiplier for lists whose size no argument bounds
+const assumedListSize = 10
+
+// Limits bounds the depth and estimated cost of a query
+type Limits struct {
+	// MaxDepth is the deepest allowed field nesting; top-level fields have depth 1
+	MaxDepth int
+	// MaxComplexity caps the estimated number of resolved fields, with list fields
+	// multiplying the cost of their selections by their size
+	MaxComplexity int
+}
+
+// queryCost is the measured shape of an operation
+type queryCost struct {
+	Depth      int
+	Complexity int
+}
+
+// checkLimits measures every operation in doc and rejects those exceeding limits. Introspection
+// fields (starting with "__") are not counted, so schema explorers keep working.
+func checkLimits(schema gql.Schema, doc *ast.Document, variables map[string]interface{}, limits Limits) error {
+	costs := measureQuery(schema, doc, variables)
+	for _, cost := range costs {
+		if limits.MaxDepth > 0 && cost.Depth > limits.MaxDepth {
+			return fmt.Errorf("%w: query depth %d exceeds the maximum of %d", domain.ErrInvalidInput, cost.Depth, limits.MaxDepth)
+		}
+		if limits.MaxComplexity > 0 && cost.Complexity > limits.MaxComplexity {
+			return fmt.Errorf("%w: query complexity %d exceeds the maximum of %d", domain.ErrInvalidInput, cost.Complexity, limits.MaxComplexity)
+		}
+	}
+	return nil
+}
+
+// measureQuery returns the cost of each query operation in doc. doc must have passed validation.
+func measureQuery(schema gql.Schema, doc *ast.Document, variables map[string]interface{}) []queryCost {
+	analyzer := &queryAnalyzer{
+		fragments: map[string]*ast.FragmentDefinition{},
+		schema:    schema,
+		variables: variables,
+	}
+	for _, definition := range doc.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			analyzer.fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	var costs []queryCost
+	for _, definition := range doc.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok || operation.Operation != ast.OperationTypeQuery {
+			continue
+		}
+		depth, complexity := analyzer.selectionSet(operation.SelectionSet, schema.QueryType(), 0, map[string]bool{})
+		costs = append(costs, queryCost{Depth: depth, Complexity: complexity})
+	}
+	return costs
+}
+
+type queryAnalyzer struct {
+	fragments map[string]*ast.FragmentDefinition
+	schema    gql.Schema
+	variables map[string]interface{}
+}
+
+// selectionSet returns the deepest field depth and the total cost of set, selected on parent at depth
+func (a *queryAnalyzer) selectionSet(set *ast.SelectionSet, parent *gql.Object, depth int, visiting map[string]bool) (int, int) {
+	if set == nil || parent == nil {
+		return depth, 0
+	}
+
+	maxDepth, complexity := depth, 0
+	for _, selection := range set.Selections {
+		var selectionDepth, selectionCost int
+
+		switch selection := selection.(type) {
+		case *ast.Field:
+			name := selection.Name.Value
+			if strings.HasPrefix(name, "__") {
+				continue
+			}
+			definition, ok := parent.Fields()[name]
+			if !ok {
+				continue
+			}
+			childDepth, childCost := a.selectionSet(selection.SelectionSet, objectType(definition.Type), depth+1, visiting)
+			selectionDepth = childDepth
+			selectionCost = 1 + a.listSize(parent, selection, definition)*childCost
+
+		case *ast.InlineFragment:
+			target := parent
+			if selection.TypeCondition != nil {
+				target = a.namedObject(selection.TypeCondition.Name.Value)
+			}
+			selectionDepth, selectionCost = a.selectionSet(selection.SelectionSet, target, depth, visiting)
+
+		case *ast.FragmentSpread:
+			name := selection.Name.Value
+			fragment, ok := a.fragments[name]
+			if !ok || visiting[name] {
+				continue
+			}
+			visiting[name] = true
+			selectionDepth, selectionCost = a.selectionSet(fragment.SelectionSet, a.namedObject(fragment.TypeCondition.Name.Value), depth, visiting)
+			delete(visiting, name)
+		}
+
+		if selectionDepth > maxDepth {
+			maxDepth = selectionDepth
+		}
+		complexity += selectionCost
+	}
+
+	return maxDepth, complexity
+}
+
+// listSize returns how many times the selections of field are resolved per parent
+func (a *queryAnalyzer) listSize(parent *gql.Object, field *ast.Field, definition *gql.FieldDefinition) int {
+	switch parent.Name() + "." + field.Name.Value {
+	case "Query.stocks":
+		return a.intArgument(field, "first", defaultPageSize)
+	case "Query.recommendations":
+		return a.intArgument(field, "limit", defaultRecommendationLimit)
+	case "StockConnection.edges":
+		// Already bounded by the first argument of the connection
+		return 1
+	}
+
+	if isList(definition.Type) {
+		return assumedListSize
+	}
+	return 1
+}
+
+// intArgument returns the value of an integer argument given inline or as a variable
+func (a *queryAnalyzer) intArgument(field *ast.Field, name string, defaultValue int) int {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != name {
+			continue
+		}
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(value.Value); err == nil && n >= 0 {
+				return n
+			}
+		case *ast.Variable:
+			switch n := a.variables[value.Name.Value].(type) {
+			case int:
+				return n
+			case float64:
+				return int(n)
+			}
+		}
+	}
+	return defaultValue
+}
+
+func (a *queryAnalyzer) namedObject(name string) *gql.Object {
+	object, _ := a.schema.Type(name).(*gql.Object)
+	return object
+}
+
+// objectType unwraps non-null and list wrappers, returning nil for scalars and enums
+func objectType(t gql.Type) *gql.Object {
+	for {
+		switch wrapped := t.(type) {
+		case *gql.NonNull:
+			t = wrapped.OfType
+		case *gql.List:
+			t = wrapped.OfType
+		case *gql.Object:
+			return wrapped
+		default:
+			return nil
+		}
+	}
+}
+
+func isList(t gql.Type) bool {
+	if nonNull, ok := t.(*gql.NonNull); ok {
+		t = nonNull.OfType
+	}
+	_, ok := t.(*gql.List)
+	return ok
+}
@@ -0,0 +1,83 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/company/stock-api/internal/domain"
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func mustMeasure(t *testing.T, schema gql.Schema, query string, variables map[string]interface{}) queryCost {
+	t.Helper()
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if !assert.NoError(t, err) || !assert.True(t, gql.ValidateDocument(&schema, doc, nil).IsValid) {
+		t.FailNow()
+	}
+
+	costs := measureQuery(schema, doc, variables)
+	if !assert.Len(t, costs, 1) {
+		t.FailNow()
+	}
+	return costs[0]
+}
+
+func TestMeasureQuery(t *testing.T) {
+	schema, err := NewSchema(NewResolver(nil, nil, nil, nil))
+	assert.NoError(t, err)
+
+	t.Run("Scalar fields", func(t *testing.T) {
+		cost := mustMeasure(t, schema, `{ brokerage(id: "1") { id name } }`, nil)
+
+		assert.Equal(t, queryCost{Depth: 2, Complexity: 3}, cost)
+	})
+
+	t.Run("Connection multiplies by first", func(t *testing.T) {
+		query := `{ stocks(first: 20) { totalCount edges { node { ticker brokerage { name } } } } }`
+
+		cost := mustMeasure(t, schema, query, nil)
+
+		// stocks + 20 * (totalCount + edges + node + ticker + brokerage + name)
+		assert.Equal(t, queryCost{Depth: 5, Complexity: 1 + 20*6}, cost)
+	})
+
+	t.Run("Variables and fragments", func(t *testing.T) {
+		query := `
+			query Page($first: Int) { stocks(first: $first) { edges { node { ...StockFields } } } }
+			fragment StockFields on Stock { ticker ratingTo { term } }`
+
+		cost := mustMeasure(t, schema, query, map[string]interface{}{"first": float64(5)})
+
+		assert.Equal(t, queryCost{Depth: 5, Complexity: 1 + 5*5}, cost)
+	})
+
+	t.Run("Unbounded lists use the assumed size", func(t *testing.T) {
+		cost := mustMeasure(t, schema, `{ tickerHistory(ticker: "AAPL") { ticker } }`, nil)
+
+		assert.Equal(t, queryCost{Depth: 2, Complexity: 1 + assumedListSize}, cost)
+	})
+
+	t.Run("Introspection is not counted", func(t *testing.T) {
+		cost := mustMeasure(t, schema, `{ __schema { types { name fields { name type { name ofType { name } } } } } }`, nil)
+
+		assert.Equal(t, queryCost{}, cost)
+	})
+}
+
+func TestCheckLimits(t *testing.T) {
+	schema, err := NewSchema(NewResolver(nil, nil, nil, nil))
+	assert.NoError(t, err)
+	doc, err := parser.Parse(parser.ParseParams{Source: `{ stocks(first: 100) { edges { node { ticker action { name } } } } }`})
+	assert.NoError(t, err)
+
+	assert.NoError(t, checkLimits(schema, doc, nil, Limits{MaxDepth: 5, MaxComplexity: 1000}))
+
+	err = checkLimits(schema, doc, nil, Limits{MaxDepth: 4, MaxComplexity: 1000})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "depth 5")
+
+	err = checkLimits(schema, doc, nil, Limits{MaxDepth: 5, MaxComplexity: 100})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "complexity 501")
+}
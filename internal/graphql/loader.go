@@ -0,0 +1,130 @@
+package graphql
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/company/stock-api/internal/usecase"
+)
+
+// BatchFunc loads the values for a batch of keys; keys missing from the result resolve to nil
+type BatchFunc[V any] func(ctx context.Context, ids []int64) (map[int64]V, error)
+
+// Loader batches the IDs requested while a query executes into a single BatchFunc call and
+// caches the results for the rest of the request. Load returns a thunk: graphql-go resolves
+// thunks only after the sibling fields of a level have been resolved, so every ID requested by
+// a list of stocks is known when the first thunk runs.
+type Loader[V any] struct {
+	ctx   context.Context
+	batch BatchFunc[V]
+
+	mu      sync.Mutex
+	pending []int64
+	results map[int64]V
+	errs    map[int64]error
+}
+
+// NewLoader creates a Loader that calls batch with ctx
+func NewLoader[V any](ctx context.Context, batch BatchFunc[V]) *Loader[V] {
+	return &Loader[V]{
+		ctx:     ctx,
+		batch:   batch,
+		results: map[int64]V{},
+		errs:    map[int64]error{},
+	}
+}
+
+// Load queues id for the next batch and returns a thunk that resolves to its value
+func (l *Loader[V]) Load(id int64) func() (interface{}, error) {
+	l.mu.Lock()
+	if _, loaded := l.results[id]; !loaded && l.errs[id] == nil {
+		l.pending = append(l.pending, id)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		value, err := l.get(id)
+		if err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+}
+
+// get returns the value for id, running the pending batch if id has not been loaded yet
+func (l *Loader[V]) get(id int64) (V, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.errs[id]; err != nil {
+		var zero V
+		return zero, err
+	}
+	if value, loaded := l.results[id]; loaded {
+		return value, nil
+	}
+
+	ids := uniqueIDs(append(l.pending, id))
+	l.pending = nil
+
+	values, err := l.batch(l.ctx, ids)
+	for _, key := range ids {
+		if err != nil {
+			l.errs[key] = err
+			continue
+		}
+		l.results[key] = values[key]
+	}
+
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	return l.results[id], nil
+}
+
+// uniqueIDs removes duplicate IDs and sorts them. Resolvers may queue IDs in any order, so sorting
+// keeps the batch queries of identical requests identical.
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	sort.Slice(unique, func(i, j int) bool { return unique[i] < unique[j] })
+	return unique
+}
+
+// Loaders holds the per-request loaders for the entities stocks reference
+type Loaders struct {
+	Brokerages *Loader[*domain.Brokerage]
+	Actions    *Loader[*domain.Action]
+	Ratings    *Loader[*domain.Rating]
+}
+
+// NewLoaders creates fresh loaders for one request; they must not be shared between requests
+func NewLoaders(ctx context.Context, brokerageUC *usecase.BrokerageUseCase, actionUC *usecase.ActionUseCase, ratingUC *usecase.RatingUseCase) *Loaders {
+	return &Loaders{
+		Brokerages: NewLoader(ctx, brokerageUC.GetByIDs),
+		Actions:    NewLoader(ctx, actionUC.GetByIDs),
+		Ratings:    NewLoader(ctx, ratingUC.GetByIDs),
+	}
+}
+
+type loadersKey struct{}
+
+// withLoaders attaches loaders to ctx
+func withLoaders(ctx context.Context, loaders *Loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, loaders)
+}
+
+// loadersFrom returns the loaders attached to ctx
+func loadersFrom(ctx context.Context) *Loaders {
+	loaders, _ := ctx.Value(loadersKey{}).(*Loaders)
+	return loaders
+}
@@ -0,0 +1,86 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoader(t *testing.T) {
+	t.Run("Batches pending IDs into one call", func(t *testing.T) {
+		var batches [][]int64
+		loader := NewLoader(context.Background(), func(ctx context.Context, ids []int64) (map[int64]string, error) {
+			batches = append(batches, ids)
+			values := map[int64]string{}
+			for _, id := range ids {
+				if id != 3 {
+					values[id] = "value"
+				}
+			}
+			return values, nil
+		})
+
+		thunks := []func() (interface{}, error){loader.Load(1), loader.Load(2), loader.Load(1), loader.Load(3)}
+		for i, thunk := range thunks {
+			value, err := thunk()
+			assert.NoError(t, err)
+			if i == 3 {
+				assert.Equal(t, "", value, "missing IDs resolve to the zero value")
+			} else {
+				assert.Equal(t, "value", value)
+			}
+		}
+
+		assert.Equal(t, [][]int64{{1, 2, 3}}, batches)
+	})
+
+	t.Run("Sorts IDs before the batch call", func(t *testing.T) {
+		var batches [][]int64
+		loader := NewLoader(context.Background(), func(ctx context.Context, ids []int64) (map[int64]int64, error) {
+			batches = append(batches, ids)
+			return map[int64]int64{}, nil
+		})
+
+		thunks := []func() (interface{}, error){loader.Load(9), loader.Load(4), loader.Load(7), loader.Load(4)}
+		for _, thunk := range thunks {
+			_, err := thunk()
+			assert.NoError(t, err)
+		}
+
+		assert.Equal(t, [][]int64{{4, 7, 9}}, batches)
+	})
+
+	t.Run("Caches loaded IDs", func(t *testing.T) {
+		calls := 0
+		loader := NewLoader(context.Background(), func(ctx context.Context, ids []int64) (map[int64]int64, error) {
+			calls++
+			values := map[int64]int64{}
+			for _, id := range ids {
+				values[id] = id * 10
+			}
+			return values, nil
+		})
+
+		_, _ = loader.Load(1)()
+		value, err := loader.Load(1)()
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), value)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Batch error fails every ID in the batch", func(t *testing.T) {
+		loader := NewLoader(context.Background(), func(ctx context.Context, ids []int64) (map[int64]string, error) {
+			return nil, errors.New("database error")
+		})
+
+		first, second := loader.Load(1), loader.Load(2)
+
+		_, err := first()
+		assert.Error(t, err)
+		_, err = second()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,376 @@
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/company/stock-api/internal/usecase"
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Pagination limits
+const (
+	defaultPageSize            = 50
+	maxPageSize                = 100
+	defaultRecommendationLimit = 10
+	maxRecommendationLimit     = 100
+)
+
+// cursorPrefix marks opaque stock cursors, which encode the offset of an edge
+const cursorPrefix = "offset:"
+
+// Resolver resolves GraphQL fields with the existing use cases
+type Resolver struct {
+	stockUC     *usecase.StockUseCase
+	brokerageUC *usecase.BrokerageUseCase
+	actionUC    *usecase.ActionUseCase
+	ratingUC    *usecase.RatingUseCase
+}
+
+// NewResolver creates a new Resolver
+func NewResolver(stockUC *usecase.StockUseCase, brokerageUC *usecase.BrokerageUseCase, actionUC *usecase.ActionUseCase, ratingUC *usecase.RatingUseCase) *Resolver {
+	return &Resolver{
+		stockUC:     stockUC,
+		brokerageUC: brokerageUC,
+		actionUC:    actionUC,
+		ratingUC:    ratingUC,
+	}
+}
+
+// stockConnection is a page of stocks; totalCount is resolved from filter only when requested
+type stockConnection struct {
+	Edges    []*stockEdge `json:"edges"`
+	PageInfo pageInfo     `json:"pageInfo"`
+	filter   domain.StockFilter
+}
+
+type stockEdge struct {
+	Cursor string                   `json:"cursor"`
+	Node   *domain.StockWithDetails `json:"node"`
+}
+
+type pageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
+}
+
+func (r *Resolver) stocks(p gql.ResolveParams) (interface{}, error) {
+	filter, err := parseStockFilterInput(p.Args["filter"], time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	first, err := pageSize(p.Args["first"])
+	if err != nil {
+		return nil, err
+	}
+	offset := 0
+	if after, ok := p.Args["after"].(string); ok && after != "" {
+		if offset, err = decodeCursor(after); err != nil {
+			return nil, err
+		}
+		offset++
+	}
+
+	filter.SortBy, _ = p.Args["sortBy"].(string)
+	filter.SortOrder, _ = p.Args["sortOrder"].(string)
+	filter.Offset = offset
+	// Fetch one extra stock to find out whether there is a next page
+	filter.Limit = first + 1
+
+	// Skip loading the page when only totalCount is selected
+	var stocks []*domain.StockWithDetails
+	if selectsAny(p.Info, "edges", "pageInfo") {
+		if stocks, err = r.stockUC.GetStocks(p.Context, filter); err != nil {
+			return nil, err
+		}
+	}
+
+	connection := &stockConnection{
+		Edges:  make([]*stockEdge, 0, first),
+		filter: filter,
+		PageInfo: pageInfo{
+			HasNextPage:     len(stocks) > first,
+			HasPreviousPage: offset > 0,
+		},
+	}
+	for i, stock := range stocks {
+		if i == first {
+			break
+		}
+		connection.Edges = append(connection.Edges, &stockEdge{Cursor: encodeCursor(offset + i), Node: stock})
+	}
+	if len(connection.Edges) > 0 {
+		connection.PageInfo.StartCursor = &connection.Edges[0].Cursor
+		connection.PageInfo.EndCursor = &connection.Edges[len(connection.Edges)-1].Cursor
+	}
+
+	return connection, nil
+}
+
+func (r *Resolver) stockConnectionTotalCount(p gql.ResolveParams) (interface{}, error) {
+	connection := p.Source.(*stockConnection)
+	return r.stockUC.GetStockCount(p.Context, connection.filter)
+}
+
+func (r *Resolver) stock(p gql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+	return nullIfNotFound(r.stockUC.GetStockByID(p.Context, id))
+}
+
+func (r *Resolver) tickerHistory(p gql.ResolveParams) (interface{}, error) {
+	ticker := strings.TrimSpace(p.Args["ticker"].(string))
+	if ticker == "" {
+		return nil, fmt.Errorf("%w: ticker is required", domain.ErrInvalidInput)
+	}
+
+	timeFrom, _ := p.Args["timeFrom"].(string)
+	timeTo, _ := p.Args["timeTo"].(string)
+	timeRange, err := usecase.ParseTimeRange(timeFrom, timeTo, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return r.stockUC.GetStocksByTicker(p.Context, ticker, timeRange, nil)
+}
+
+func (r *Resolver) recommendations(p gql.ResolveParams) (interface{}, error) {
+	limit, _ := p.Args["limit"].(int)
+	if limit <= 0 || limit > maxRecommendationLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", domain.ErrInvalidInput, maxRecommendationLimit)
+	}
+	strategy, _ := p.Args["strategy"].(string)
+	return r.stockUC.GetRecommendations(p.Context, domain.RecommendationQuery{Limit: limit, Strategy: strategy})
+}
+
+func (r *Resolver) brokerages(p gql.ResolveParams) (interface{}, error) {
+	return r.brokerageUC.GetAll(p.Context)
+}
+
+func (r *Resolver) brokerage(p gql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+	return nullIfNotFound(r.brokerageUC.GetByID(p.Context, id))
+}
+
+func (r *Resolver) actions(p gql.ResolveParams) (interface{}, error) {
+	return r.actionUC.GetAll(p.Context)
+}
+
+func (r *Resolver) action(p gql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+	return nullIfNotFound(r.actionUC.GetByID(p.Context, id))
+}
+
+func (r *Resolver) ratings(p gql.ResolveParams) (interface{}, error) {
+	return r.ratingUC.GetAll(p.Context)
+}
+
+func (r *Resolver) rating(p gql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+	return nullIfNotFound(r.ratingUC.GetByID(p.Context, id))
+}
+
+func (r *Resolver) stockBrokerage(p gql.ResolveParams) (interface{}, error) {
+	stock := p.Source.(*domain.StockWithDetails)
+	if stock.BrokerageID == nil {
+		return nil, nil
+	}
+	return r.loaders(p.Context).Brokerages.Load(*stock.BrokerageID), nil
+}
+
+func (r *Resolver) stockAction(p gql.ResolveParams) (interface{}, error) {
+	stock := p.Source.(*domain.StockWithDetails)
+	if stock.ActionID == nil {
+		return nil, nil
+	}
+	return r.loaders(p.Context).Actions.Load(*stock.ActionID), nil
+}
+
+// stockRating resolves the rating whose ID id returns; ratingFrom and ratingTo share one loader
+func (r *Resolver) stockRating(id func(s *domain.StockWithDetails) *int64) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		ratingID := id(p.Source.(*domain.StockWithDetails))
+		if ratingID == nil {
+			return nil, nil
+		}
+		return r.loaders(p.Context).Ratings.Load(*ratingID), nil
+	}
+}
+
+// loaders returns the request's loaders, falling back to fresh ones when the
+// schema is executed without going through Handler
+func (r *Resolver) loaders(ctx context.Context) *Loaders {
+	if loaders := loadersFrom(ctx); loaders != nil {
+		return loaders
+	}
+	return NewLoaders(ctx, r.brokerageUC, r.actionUC, r.ratingUC)
+}
+
+// selectsAny reports whether the field being resolved selects any of names, looking through fragments
+func selectsAny(info gql.ResolveInfo, names ...string) bool {
+	var visit func(set *ast.SelectionSet) bool
+	visit = func(set *ast.SelectionSet) bool {
+		if set == nil {
+			return false
+		}
+		for _, selection := range set.Selections {
+			switch selection := selection.(type) {
+			case *ast.Field:
+				for _, name := range names {
+					if selection.Name.Value == name {
+						return true
+					}
+				}
+			case *ast.InlineFragment:
+				if visit(selection.SelectionSet) {
+					return true
+				}
+			case *ast.FragmentSpread:
+				if fragment, ok := info.Fragments[selection.Name.Value].(*ast.FragmentDefinition); ok && visit(fragment.SelectionSet) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	for _, field := range info.FieldASTs {
+		if visit(field.SelectionSet) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseStockFilterInput maps a StockFilterInput argument onto a domain.StockFilter
+func parseStockFilterInput(value interface{}, now time.Time) (domain.StockFilter, error) {
+	var filter domain.StockFilter
+	input, _ := value.(map[string]interface{})
+	if input == nil {
+		return filter, nil
+	}
+
+	filter.Ticker = valueFilterInput(input, "ticker")
+	filter.Brokerage = valueFilterInput(input, "brokerage")
+	filter.Action = valueFilterInput(input, "action")
+	filter.RatingFrom = valueFilterInput(input, "ratingFrom")
+	filter.RatingTo = valueFilterInput(input, "ratingTo")
+	filter.Company, _ = input["company"].(string)
+	filter.ActionCategory, _ = input["actionCategory"].(domain.ActionCategory)
+	filter.RatingBucket, _ = input["ratingBucket"].(domain.RatingBucket)
+	filter.TargetToMin = floatInput(input, "targetToMin")
+	filter.TargetToMax = floatInput(input, "targetToMax")
+	filter.TargetChangePctMin = floatInput(input, "targetChangePctMin")
+
+	timeFrom, _ := input["timeFrom"].(string)
+	timeTo, _ := input["timeTo"].(string)
+	var err error
+	if filter.Time, err = usecase.ParseTimeRange(timeFrom, timeTo, now); err != nil {
+		return filter, err
+	}
+
+	if q, _ := input["q"].(string); q != "" {
+		if filter.Expr, err = usecase.ParseFilterExpr(q, now); err != nil {
+			return filter, err
+		}
+	}
+
+	return filter, filter.Validate()
+}
+
+// valueFilterInput reads the include list key and the exclude list keyNot
+func valueFilterInput(input map[string]interface{}, key string) domain.ValueFilter {
+	return domain.ValueFilter{
+		In:    stringsInput(input[key]),
+		NotIn: stringsInput(input[key+"Not"]),
+	}
+}
+
+func stringsInput(value interface{}) []string {
+	items, _ := value.([]interface{})
+	var values []string
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			if s = strings.TrimSpace(s); s != "" {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
+
+func floatInput(input map[string]interface{}, key string) *float64 {
+	value, ok := input[key].(float64)
+	if !ok {
+		return nil
+	}
+	return &value
+}
+
+// pageSize validates the first argument
+func pageSize(value interface{}) (int, error) {
+	first, ok := value.(int)
+	if !ok {
+		return defaultPageSize, nil
+	}
+	if first < 0 || first > maxPageSize {
+		return 0, fmt.Errorf("%w: first must be between 0 and %d", domain.ErrInvalidInput, maxPageSize)
+	}
+	return first, nil
+}
+
+func encodeCursor(offset int) string {
+	return base64.StdEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	decoded, err := base64.StdEncoding.DecodeString(cursor)
+	if err == nil && strings.HasPrefix(string(decoded), cursorPrefix) {
+		offset, err := strconv.Atoi(strings.TrimPrefix(string(decoded), cursorPrefix))
+		if err == nil && offset >= 0 {
+			return offset, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: invalid cursor %q", domain.ErrInvalidInput, cursor)
+}
+
+func parseID(value interface{}) (int64, error) {
+	s, _ := value.(string)
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid id %q", domain.ErrInvalidInput, s)
+	}
+	return id, nil
+}
+
+// nullIfNotFound resolves single-entity lookups of missing IDs to null instead of an error
+func nullIfNotFound[T any](value *T, err error) (interface{}, error) {
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
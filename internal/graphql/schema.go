@@ -0,0 +1,339 @@
+// Package graphql exposes stocks, brokerages, actions, ratings and recommendations over GraphQL.
+// Resolvers delegate to the same use cases as the REST handlers.
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/company/stock-api/internal/domain"
+	gql "github.com/graphql-go/graphql"
+)
+
+// stockSortFields maps the StockSortField enum onto the sortBy values the stock repository accepts
+var stockSortFields = map[string]string{
+	"TICKER":    "ticker",
+	"COMPANY":   "company",
+	"TIME":      "time",
+	"RATING_TO": "rating_to_term",
+	"ACTION":    "action_name",
+	"BROKERAGE": "brokerage_name",
+	"TARGET_TO": "target_to",
+}
+
+// NewSchema builds the GraphQL schema with fields resolved by r
+func NewSchema(r *Resolver) (gql.Schema, error) {
+	ratingBucketEnum := newEnum("RatingBucket", "Canonical rating bucket", domain.RatingBuckets)
+	actionCategoryEnum := newEnum("ActionCategory", "Canonical analyst action category", domain.ActionCategories)
+	actionDirectionEnum := newEnum("ActionDirection", "Whether an action is a positive or negative signal",
+		[]domain.ActionDirection{domain.ActionDirectionUp, domain.ActionDirectionDown, domain.ActionDirectionNeutral})
+
+	sortFieldValues := gql.EnumValueConfigMap{}
+	for name, value := range stockSortFields {
+		sortFieldValues[name] = &gql.EnumValueConfig{Value: value}
+	}
+	stockSortFieldEnum := gql.NewEnum(gql.EnumConfig{
+		Name:   "StockSortField",
+		Values: sortFieldValues,
+	})
+	sortOrderEnum := gql.NewEnum(gql.EnumConfig{
+		Name: "SortOrder",
+		Values: gql.EnumValueConfigMap{
+			"ASC":  &gql.EnumValueConfig{Value: "asc"},
+			"DESC": &gql.EnumValueConfig{Value: "desc"},
+		},
+	})
+
+	brokerageType := gql.NewObject(gql.ObjectConfig{
+		Name:        "Brokerage",
+		Description: "A brokerage firm",
+		Fields: gql.Fields{
+			"id":        &gql.Field{Type: gql.NewNonNull(gql.ID), Resolve: brokerageField(func(b *domain.Brokerage) interface{} { return formatID(b.ID) })},
+			"name":      &gql.Field{Type: gql.NewNonNull(gql.String), Resolve: brokerageField(func(b *domain.Brokerage) interface{} { return b.Name })},
+			"createdAt": &gql.Field{Type: gql.NewNonNull(gql.DateTime), Resolve: brokerageField(func(b *domain.Brokerage) interface{} { return b.CreatedAt })},
+			"updatedAt": &gql.Field{Type: gql.NewNonNull(gql.DateTime), Resolve: brokerageField(func(b *domain.Brokerage) interface{} { return b.UpdatedAt })},
+		},
+	})
+
+	actionType := gql.NewObject(gql.ObjectConfig{
+		Name:        "Action",
+		Description: "An analyst action, such as an upgrade or a target raise",
+		Fields: gql.Fields{
+			"id":        &gql.Field{Type: gql.NewNonNull(gql.ID), Resolve: actionField(func(a *domain.Action) interface{} { return formatID(a.ID) })},
+			"name":      &gql.Field{Type: gql.NewNonNull(gql.String), Resolve: actionField(func(a *domain.Action) interface{} { return a.Name })},
+			"category":  &gql.Field{Type: actionCategoryEnum, Resolve: actionField(func(a *domain.Action) interface{} { return a.Category })},
+			"direction": &gql.Field{Type: actionDirectionEnum, Resolve: actionField(func(a *domain.Action) interface{} { return a.Direction })},
+			"createdAt": &gql.Field{Type: gql.NewNonNull(gql.DateTime), Resolve: actionField(func(a *domain.Action) interface{} { return a.CreatedAt })},
+			"updatedAt": &gql.Field{Type: gql.NewNonNull(gql.DateTime), Resolve: actionField(func(a *domain.Action) interface{} { return a.UpdatedAt })},
+		},
+	})
+
+	ratingType := gql.NewObject(gql.ObjectConfig{
+		Name:        "Rating",
+		Description: "A rating term and its place on the canonical scale",
+		Fields: gql.Fields{
+			"id":        &gql.Field{Type: gql.NewNonNull(gql.ID), Resolve: ratingField(func(r *domain.Rating) interface{} { return formatID(r.ID) })},
+			"term":      &gql.Field{Type: gql.NewNonNull(gql.String), Resolve: ratingField(func(r *domain.Rating) interface{} { return r.Term })},
+			"bucket":    &gql.Field{Type: ratingBucketEnum, Resolve: ratingField(func(r *domain.Rating) interface{} { return r.Bucket })},
+			"score":     &gql.Field{Type: gql.Float, Resolve: ratingField(func(r *domain.Rating) interface{} { return r.Score })},
+			"createdAt": &gql.Field{Type: gql.NewNonNull(gql.DateTime), Resolve: ratingField(func(r *domain.Rating) interface{} { return r.CreatedAt })},
+			"updatedAt": &gql.Field{Type: gql.NewNonNull(gql.DateTime), Resolve: ratingField(func(r *domain.Rating) interface{} { return r.UpdatedAt })},
+		},
+	})
+
+	stockType := gql.NewObject(gql.ObjectConfig{
+		Name:        "Stock",
+		Description: "A brokerage rating event for a ticker",
+		Fields: gql.Fields{
+			"id":               &gql.Field{Type: gql.NewNonNull(gql.ID), Resolve: stockField(func(s *domain.StockWithDetails) interface{} { return formatID(s.ID) })},
+			"ticker":           &gql.Field{Type: gql.NewNonNull(gql.String), Resolve: stockField(func(s *domain.StockWithDetails) interface{} { return s.Ticker })},
+			"company":          &gql.Field{Type: gql.NewNonNull(gql.String), Resolve: stockField(func(s *domain.StockWithDetails) interface{} { return s.Company })},
+			"targetFrom":       &gql.Field{Type: gql.String, Resolve: stockField(func(s *domain.StockWithDetails) interface{} { return s.TargetFrom })},
+			"targetTo":         &gql.Field{Type: gql.String, Resolve: stockField(func(s *domain.StockWithDetails) interface{} { return s.TargetTo })},
+			"targetFromAmount": &gql.Field{Type: gql.Float, Resolve: stockField(func(s *domain.StockWithDetails) interface{} { return s.TargetFromAmount })},
+			"targetToAmount":   &gql.Field{Type: gql.Float, Resolve: stockField(func(s *domain.StockWithDetails) interface{} { return s.TargetToAmount })},
+			"targetCurrency":   &gql.Field{Type: gql.String, Resolve: stockField(func(s *domain.StockWithDetails) interface{} { return nullIfEmpty(s.TargetCurrency) })},
+			"time":             &gql.Field{Type: gql.NewNonNull(gql.DateTime), Resolve: stockField(func(s *domain.StockWithDetails) interface{} { return s.Time })},
+			"createdAt":        &gql.Field{Type: gql.NewNonNull(gql.DateTime), Resolve: stockField(func(s *domain.StockWithDetails) interface{} { return s.CreatedAt })},
+			"updatedAt":        &gql.Field{Type: gql.NewNonNull(gql.DateTime), Resolve: stockField(func(s *domain.StockWithDetails) interface{} { return s.UpdatedAt })},
+			"brokerage": &gql.Field{
+				Type:        brokerageType,
+				Description: "The brokerage that issued the rating (batched per request)",
+				Resolve:     r.stockBrokerage,
+			},
+			"action": &gql.Field{
+				Type:        actionType,
+				Description: "The analyst action (batched per request)",
+				Resolve:     r.stockAction,
+			},
+			"ratingFrom": &gql.Field{
+				Type:        ratingType,
+				Description: "The previous rating (batched per request)",
+				Resolve:     r.stockRating(func(s *domain.StockWithDetails) *int64 { return s.RatingFromID }),
+			},
+			"ratingTo": &gql.Field{
+				Type:        ratingType,
+				Description: "The new rating (batched per request)",
+				Resolve:     r.stockRating(func(s *domain.StockWithDetails) *int64 { return s.RatingToID }),
+			},
+		},
+	})
+
+	pageInfoType := gql.NewObject(gql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: gql.Fields{
+			"hasNextPage":     &gql.Field{Type: gql.NewNonNull(gql.Boolean)},
+			"hasPreviousPage": &gql.Field{Type: gql.NewNonNull(gql.Boolean)},
+			"startCursor":     &gql.Field{Type: gql.String},
+			"endCursor":       &gql.Field{Type: gql.String},
+		},
+	})
+
+	stockEdgeType := gql.NewObject(gql.ObjectConfig{
+		Name: "StockEdge",
+		Fields: gql.Fields{
+			"cursor": &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"node":   &gql.Field{Type: gql.NewNonNull(stockType)},
+		},
+	})
+
+	stockConnectionType := gql.NewObject(gql.ObjectConfig{
+		Name: "StockConnection",
+		Fields: gql.Fields{
+			"edges":    &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(stockEdgeType)))},
+			"pageInfo": &gql.Field{Type: gql.NewNonNull(pageInfoType)},
+			"totalCount": &gql.Field{
+				Type:        gql.NewNonNull(gql.Int),
+				Description: "Number of stocks matching the filter, counted only when requested",
+				Resolve:     r.stockConnectionTotalCount,
+			},
+		},
+	})
+
+	scoreFactorType := gql.NewObject(gql.ObjectConfig{
+		Name:        "ScoreFactor",
+		Description: "One factor's part in a recommendation score",
+		Fields: gql.Fields{
+			"name":         &gql.Field{Type: gql.NewNonNull(gql.String), Resolve: scoreFactorField(func(f domain.ScoreFactor) interface{} { return f.Name })},
+			"value":        &gql.Field{Type: gql.String, Resolve: scoreFactorField(func(f domain.ScoreFactor) interface{} { return fmt.Sprint(f.Value) })},
+			"score":        &gql.Field{Type: gql.NewNonNull(gql.Float), Resolve: scoreFactorField(func(f domain.ScoreFactor) interface{} { return f.Score })},
+			"weight":       &gql.Field{Type: gql.NewNonNull(gql.Float), Resolve: scoreFactorField(func(f domain.ScoreFactor) interface{} { return f.Weight })},
+			"contribution": &gql.Field{Type: gql.NewNonNull(gql.Float), Resolve: scoreFactorField(func(f domain.ScoreFactor) interface{} { return f.Contribution })},
+		},
+	})
+
+	recommendationType := gql.NewObject(gql.ObjectConfig{
+		Name:        "StockRecommendation",
+		Description: "A stock with its recommendation score",
+		Fields: gql.Fields{
+			"stock":                 &gql.Field{Type: gql.NewNonNull(stockType), Resolve: recommendationField(func(rec *domain.StockRecommendation) interface{} { return rec.Stock })},
+			"score":                 &gql.Field{Type: gql.NewNonNull(gql.Float), Resolve: recommendationField(func(rec *domain.StockRecommendation) interface{} { return rec.Score })},
+			"reason":                &gql.Field{Type: gql.NewNonNull(gql.String), Resolve: recommendationField(func(rec *domain.StockRecommendation) interface{} { return rec.Reason })},
+			"targetIncreasePercent": &gql.Field{Type: gql.Float, Resolve: recommendationField(func(rec *domain.StockRecommendation) interface{} { return rec.TargetIncrease })},
+			"impliedUpsidePercent":  &gql.Field{Type: gql.Float, Resolve: recommendationField(func(rec *domain.StockRecommendation) interface{} { return rec.ImpliedUpside })},
+			"lastClose":             &gql.Field{Type: gql.Float, Resolve: recommendationField(func(rec *domain.StockRecommendation) interface{} { return rec.LastClose })},
+			"factors":               &gql.Field{Type: gql.NewList(gql.NewNonNull(scoreFactorType)), Resolve: recommendationField(func(rec *domain.StockRecommendation) interface{} { return rec.Factors })},
+		},
+	})
+
+	stringList := gql.NewList(gql.NewNonNull(gql.String))
+	stockFilterInput := gql.NewInputObject(gql.InputObjectConfig{
+		Name:        "StockFilterInput",
+		Description: "Filters for stocks, matching the query parameters of GET /api/v1/stocks",
+		Fields: gql.InputObjectConfigFieldMap{
+			"ticker":             &gql.InputObjectFieldConfig{Type: stringList, Description: "Tickers to include (exact match)"},
+			"tickerNot":          &gql.InputObjectFieldConfig{Type: stringList, Description: "Tickers to exclude"},
+			"company":            &gql.InputObjectFieldConfig{Type: gql.String, Description: "Company name substring"},
+			"brokerage":          &gql.InputObjectFieldConfig{Type: stringList, Description: "Brokerages to include (fuzzy match)"},
+			"brokerageNot":       &gql.InputObjectFieldConfig{Type: stringList, Description: "Brokerages to exclude (substring match)"},
+			"action":             &gql.InputObjectFieldConfig{Type: stringList, Description: "Actions to include (exact match)"},
+			"actionNot":          &gql.InputObjectFieldConfig{Type: stringList, Description: "Actions to exclude"},
+			"actionCategory":     &gql.InputObjectFieldConfig{Type: actionCategoryEnum},
+			"ratingFrom":         &gql.InputObjectFieldConfig{Type: stringList, Description: "Previous ratings to include (exact match)"},
+			"ratingFromNot":      &gql.InputObjectFieldConfig{Type: stringList, Description: "Previous ratings to exclude"},
+			"ratingTo":           &gql.InputObjectFieldConfig{Type: stringList, Description: "New ratings to include (exact match)"},
+			"ratingToNot":        &gql.InputObjectFieldConfig{Type: stringList, Description: "New ratings to exclude"},
+			"ratingBucket":       &gql.InputObjectFieldConfig{Type: ratingBucketEnum},
+			"targetToMin":        &gql.InputObjectFieldConfig{Type: gql.Float},
+			"targetToMax":        &gql.InputObjectFieldConfig{Type: gql.Float},
+			"targetChangePctMin": &gql.InputObjectFieldConfig{Type: gql.Float},
+			"timeFrom":           &gql.InputObjectFieldConfig{Type: gql.String, Description: "RFC3339 timestamp, date or relative time such as 30d"},
+			"timeTo":             &gql.InputObjectFieldConfig{Type: gql.String, Description: "RFC3339 timestamp, date or relative time such as 30d"},
+			"q":                  &gql.InputObjectFieldConfig{Type: gql.String, Description: "Filter expression, as in the q query parameter"},
+		},
+	})
+
+	queryType := gql.NewObject(gql.ObjectConfig{
+		Name: "Query",
+		Fields: gql.Fields{
+			"stocks": &gql.Field{
+				Type:        gql.NewNonNull(stockConnectionType),
+				Description: "Latest rating per ticker, paginated with first/after",
+				Args: gql.FieldConfigArgument{
+					"filter":    &gql.ArgumentConfig{Type: stockFilterInput},
+					"first":     &gql.ArgumentConfig{Type: gql.Int, DefaultValue: defaultPageSize},
+					"after":     &gql.ArgumentConfig{Type: gql.String},
+					"sortBy":    &gql.ArgumentConfig{Type: stockSortFieldEnum, DefaultValue: "time"},
+					"sortOrder": &gql.ArgumentConfig{Type: sortOrderEnum, DefaultValue: "desc"},
+				},
+				Resolve: r.stocks,
+			},
+			"stock": &gql.Field{
+				Type:    stockType,
+				Args:    gql.FieldConfigArgument{"id": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)}},
+				Resolve: r.stock,
+			},
+			"tickerHistory": &gql.Field{
+				Type:        gql.NewNonNull(gql.NewList(gql.NewNonNull(stockType))),
+				Description: "All rating events for a ticker, newest first",
+				Args: gql.FieldConfigArgument{
+					"ticker":   &gql.ArgumentConfig{Type: gql.NewNonNull(gql.String)},
+					"timeFrom": &gql.ArgumentConfig{Type: gql.String},
+					"timeTo":   &gql.ArgumentConfig{Type: gql.String},
+				},
+				Resolve: r.tickerHistory,
+			},
+			"recommendations": &gql.Field{
+				Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(recommendationType))),
+				Args: gql.FieldConfigArgument{
+					"limit":    &gql.ArgumentConfig{Type: gql.Int, DefaultValue: defaultRecommendationLimit},
+					"strategy": &gql.ArgumentConfig{Type: gql.String, Description: "Scoring strategy (balanced, momentum, consensus, contrarian); defaults to balanced"},
+				},
+				Resolve: r.recommendations,
+			},
+			"brokerages": &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(brokerageType))), Resolve: r.brokerages},
+			"brokerage": &gql.Field{
+				Type:    brokerageType,
+				Args:    gql.FieldConfigArgument{"id": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)}},
+				Resolve: r.brokerage,
+			},
+			"actions": &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(actionType))), Resolve: r.actions},
+			"action": &gql.Field{
+				Type:    actionType,
+				Args:    gql.FieldConfigArgument{"id": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)}},
+				Resolve: r.action,
+			},
+			"ratings": &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(ratingType))), Resolve: r.ratings},
+			"rating": &gql.Field{
+				Type:    ratingType,
+				Args:    gql.FieldConfigArgument{"id": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)}},
+				Resolve: r.rating,
+			},
+		},
+	})
+
+	return gql.NewSchema(gql.SchemaConfig{Query: queryType})
+}
+
+// newEnum builds an enum whose GraphQL names are the upper-cased domain values
+func newEnum[T ~string](name, description string, values []T) *gql.Enum {
+	config := gql.EnumValueConfigMap{}
+	for _, value := range values {
+		config[strings.ToUpper(string(value))] = &gql.EnumValueConfig{Value: value}
+	}
+	return gql.NewEnum(gql.EnumConfig{Name: name, Description: description, Values: config})
+}
+
+func stockField(get func(s *domain.StockWithDetails) interface{}) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		return get(p.Source.(*domain.StockWithDetails)), nil
+	}
+}
+
+func brokerageField(get func(b *domain.Brokerage) interface{}) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		return get(p.Source.(*domain.Brokerage)), nil
+	}
+}
+
+func actionField(get func(a *domain.Action) interface{}) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		return nullIfEmpty(get(p.Source.(*domain.Action))), nil
+	}
+}
+
+func ratingField(get func(r *domain.Rating) interface{}) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		return nullIfEmpty(get(p.Source.(*domain.Rating))), nil
+	}
+}
+
+func recommendationField(get func(rec *domain.StockRecommendation) interface{}) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		return get(p.Source.(*domain.StockRecommendation)), nil
+	}
+}
+
+func scoreFactorField(get func(f domain.ScoreFactor) interface{}) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		return get(p.Source.(domain.ScoreFactor)), nil
+	}
+}
+
+// nullIfEmpty turns empty strings (including unset enum values) into null
+func nullIfEmpty(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+	case domain.ActionCategory:
+		if v == "" {
+			return nil
+		}
+	case domain.ActionDirection:
+		if v == "" {
+			return nil
+		}
+	case domain.RatingBucket:
+		if v == "" {
+			return nil
+		}
+	}
+	return value
+}
+
+func formatID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,87 @@
+package grpcapi
+
+import (
+	"time"
+
+	stockv1 "github.com/company/stock-api/api/proto/stock/v1"
+	"github.com/company/stock-api/internal/domain"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toStockMessage(stock *domain.StockWithDetails) *stockv1.Stock {
+	return &stockv1.Stock{
+		Id:               stock.ID,
+		Ticker:           stock.Ticker,
+		Company:          stock.Company,
+		TargetFrom:       stock.TargetFrom,
+		TargetTo:         stock.TargetTo,
+		TargetFromAmount: stock.TargetFromAmount,
+		TargetToAmount:   stock.TargetToAmount,
+		TargetCurrency:   stock.TargetCurrency,
+		ActionId:         stock.ActionID,
+		Action:           stock.ActionName,
+		ActionCategory:   string(stock.ActionCategory),
+		ActionDirection:  string(stock.ActionDirection),
+		BrokerageId:      stock.BrokerageID,
+		Brokerage:        stock.BrokerageName,
+		RatingFromId:     stock.RatingFromID,
+		RatingFrom:       stock.RatingFromTerm,
+		RatingFromBucket: string(stock.RatingFromBucket),
+		RatingFromScore:  stock.RatingFromScore,
+		RatingToId:       stock.RatingToID,
+		RatingTo:         stock.RatingToTerm,
+		RatingToBucket:   string(stock.RatingToBucket),
+		RatingToScore:    stock.RatingToScore,
+		Time:             toTimestamp(stock.Time),
+		CreatedAt:        toTimestamp(stock.CreatedAt),
+		UpdatedAt:        toTimestamp(stock.UpdatedAt),
+	}
+}
+
+func toRecommendationMessage(recommendation *domain.StockRecommendation) *stockv1.StockRecommendation {
+	return &stockv1.StockRecommendation{
+		Stock:                 toStockMessage(recommendation.Stock),
+		Score:                 recommendation.Score,
+		Reason:                recommendation.Reason,
+		TargetIncreasePercent: recommendation.TargetIncrease,
+	}
+}
+
+func toBrokerageMessage(brokerage *domain.Brokerage) *stockv1.Brokerage {
+	return &stockv1.Brokerage{
+		Id:        brokerage.ID,
+		Name:      brokerage.Name,
+		CreatedAt: toTimestamp(brokerage.CreatedAt),
+		UpdatedAt: toTimestamp(brokerage.UpdatedAt),
+	}
+}
+
+func toActionMessage(action *domain.Action) *stockv1.Action {
+	return &stockv1.Action{
+		Id:        action.ID,
+		Name:      action.Name,
+		Category:  string(action.Category),
+		Direction: string(action.Direction),
+		CreatedAt: toTimestamp(action.CreatedAt),
+		UpdatedAt: toTimestamp(action.UpdatedAt),
+	}
+}
+
+func toRatingMessage(rating *domain.Rating) *stockv1.Rating {
+	return &stockv1.Rating{
+		Id:        rating.ID,
+		Term:      rating.Term,
+		Bucket:    string(rating.Bucket),
+		Score:     rating.Score,
+		CreatedAt: toTimestamp(rating.CreatedAt),
+		UpdatedAt: toTimestamp(rating.UpdatedAt),
+	}
+}
+
+// toTimestamp converts t, leaving zero times (columns not loaded) unset
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,275 @@
+// Package grpcapi serves the StockService gRPC API defined in api/proto/stock/v1.
+// Handlers delegate to the same use cases as the REST handlers.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	stockv1 "github.com/company/stock-api/api/proto/stock/v1"
+	"github.com/company/stock-api/internal/domain"
+	"github.com/company/stock-api/internal/usecase"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// Streaming limits
+const (
+	// stockPageSize is how many stocks ListStocks loads per query while streaming
+	stockPageSize              = 500
+	defaultRecommendationLimit = 10
+	maxRecommendationLimit     = 100
+)
+
+// StockServer implements stockv1.StockServiceServer
+type StockServer struct {
+	stockv1.UnimplementedStockServiceServer
+
+	stockUC     *usecase.StockUseCase
+	brokerageUC *usecase.BrokerageUseCase
+	actionUC    *usecase.ActionUseCase
+	ratingUC    *usecase.RatingUseCase
+	logger      *zap.Logger
+}
+
+// NewStockServer creates a new StockServer
+func NewStockServer(stockUC *usecase.StockUseCase, brokerageUC *usecase.BrokerageUseCase, actionUC *usecase.ActionUseCase, ratingUC *usecase.RatingUseCase, logger *zap.Logger) *StockServer {
+	return &StockServer{
+		stockUC:     stockUC,
+		brokerageUC: brokerageUC,
+		actionUC:    actionUC,
+		ratingUC:    ratingUC,
+		logger:      logger,
+	}
+}
+
+// NewServer creates a gRPC server with the StockService, server reflection, and logging and recovery interceptors
+func NewServer(stockServer *StockServer, logger *zap.Logger) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryRecovery(logger), unaryLogger(logger)),
+		grpc.ChainStreamInterceptor(streamRecovery(logger), streamLogger(logger)),
+	)
+	stockv1.RegisterStockServiceServer(server, stockServer)
+	reflection.Register(server)
+	return server
+}
+
+// ListStocks streams the latest stock per ticker, loading stockPageSize stocks at a time
+func (s *StockServer) ListStocks(req *stockv1.ListStocksRequest, stream stockv1.StockService_ListStocksServer) error {
+	filter, err := parseStockFilter(req.GetFilter(), time.Now())
+	if err != nil {
+		return toStatus(err)
+	}
+	if req.GetLimit() < 0 || req.GetOffset() < 0 {
+		return status.Error(codes.InvalidArgument, "limit and offset must not be negative")
+	}
+	filter.SortBy = req.GetSortBy()
+	filter.SortOrder = req.GetSortOrder()
+
+	remaining := int(req.GetLimit())
+	offset := int(req.GetOffset())
+	for {
+		filter.Offset = offset
+		filter.Limit = stockPageSize
+		if remaining > 0 && remaining < stockPageSize {
+			filter.Limit = remaining
+		}
+
+		stocks, err := s.stockUC.GetStocks(stream.Context(), filter)
+		if err != nil {
+			return toStatus(err)
+		}
+		for _, stock := range stocks {
+			if err := stream.Send(toStockMessage(stock)); err != nil {
+				return err
+			}
+		}
+
+		offset += len(stocks)
+		if remaining > 0 {
+			remaining -= len(stocks)
+			if remaining == 0 {
+				return nil
+			}
+		}
+		if len(stocks) < filter.Limit {
+			return nil
+		}
+	}
+}
+
+// GetStock returns a single stock by ID
+func (s *StockServer) GetStock(ctx context.Context, req *stockv1.GetStockRequest) (*stockv1.Stock, error) {
+	stock, err := s.stockUC.GetStockByID(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toStockMessage(stock), nil
+}
+
+// GetTickerHistory streams every stock event for a ticker, newest first
+func (s *StockServer) GetTickerHistory(req *stockv1.GetTickerHistoryRequest, stream stockv1.StockService_GetTickerHistoryServer) error {
+	ticker := strings.TrimSpace(req.GetTicker())
+	if ticker == "" {
+		return status.Error(codes.InvalidArgument, "ticker is required")
+	}
+
+	timeRange, err := usecase.ParseTimeRange(req.GetTimeFrom(), req.GetTimeTo(), time.Now())
+	if err != nil {
+		return toStatus(err)
+	}
+
+	stocks, err := s.stockUC.GetStocksByTicker(stream.Context(), ticker, timeRange, nil)
+	if err != nil {
+		return toStatus(err)
+	}
+	for _, stock := range stocks {
+		if err := stream.Send(toStockMessage(stock)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetRecommendations streams the best-scored stocks, best first
+func (s *StockServer) GetRecommendations(req *stockv1.GetRecommendationsRequest, stream stockv1.StockService_GetRecommendationsServer) error {
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = defaultRecommendationLimit
+	}
+	if limit < 0 || limit > maxRecommendationLimit {
+		return status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxRecommendationLimit)
+	}
+
+	recommendations, err := s.stockUC.GetRecommendations(stream.Context(), domain.RecommendationQuery{Limit: limit})
+	if err != nil {
+		return toStatus(err)
+	}
+	for _, recommendation := range recommendations {
+		if err := stream.Send(toRecommendationMessage(recommendation)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListBrokerages streams all brokerages
+func (s *StockServer) ListBrokerages(req *stockv1.ListBrokeragesRequest, stream stockv1.StockService_ListBrokeragesServer) error {
+	brokerages, err := s.brokerageUC.GetAll(stream.Context())
+	if err != nil {
+		return toStatus(err)
+	}
+	for _, brokerage := range brokerages {
+		if err := stream.Send(toBrokerageMessage(brokerage)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListActions streams all analyst actions
+func (s *StockServer) ListActions(req *stockv1.ListActionsRequest, stream stockv1.StockService_ListActionsServer) error {
+	actions, err := s.actionUC.GetAll(stream.Context())
+	if err != nil {
+		return toStatus(err)
+	}
+	for _, action := range actions {
+		if err := stream.Send(toActionMessage(action)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListRatings streams all rating terms
+func (s *StockServer) ListRatings(req *stockv1.ListRatingsRequest, stream stockv1.StockService_ListRatingsServer) error {
+	ratings, err := s.ratingUC.GetAll(stream.Context())
+	if err != nil {
+		return toStatus(err)
+	}
+	for _, rating := range ratings {
+		if err := stream.Send(toRatingMessage(rating)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TriggerSync fetches all stocks from the external API and stores them
+func (s *StockServer) TriggerSync(ctx context.Context, req *stockv1.TriggerSyncRequest) (*stockv1.TriggerSyncResponse, error) {
+	count, err := s.stockUC.SyncStocksFromAPI(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &stockv1.TriggerSyncResponse{SyncedCount: int32(count)}, nil
+}
+
+// parseStockFilter maps a StockFilter message onto a domain.StockFilter
+func parseStockFilter(msg *stockv1.StockFilter, now time.Time) (domain.StockFilter, error) {
+	if msg == nil {
+		msg = &stockv1.StockFilter{}
+	}
+
+	filter := domain.StockFilter{
+		Ticker:             domain.ValueFilter{In: msg.GetTicker(), NotIn: msg.GetTickerNot()},
+		Company:            msg.GetCompany(),
+		Brokerage:          domain.ValueFilter{In: msg.GetBrokerage(), NotIn: msg.GetBrokerageNot()},
+		Action:             domain.ValueFilter{In: msg.GetAction(), NotIn: msg.GetActionNot()},
+		RatingFrom:         domain.ValueFilter{In: msg.GetRatingFrom(), NotIn: msg.GetRatingFromNot()},
+		RatingTo:           domain.ValueFilter{In: msg.GetRatingTo(), NotIn: msg.GetRatingToNot()},
+		TargetToMin:        msg.TargetToMin,
+		TargetToMax:        msg.TargetToMax,
+		TargetChangePctMin: msg.TargetChangePctMin,
+	}
+
+	if value := msg.GetRatingBucket(); value != "" {
+		bucket, ok := domain.ParseRatingBucket(value)
+		if !ok {
+			return filter, fmt.Errorf("%w: unknown rating bucket %q", domain.ErrInvalidInput, value)
+		}
+		filter.RatingBucket = bucket
+	}
+
+	if value := msg.GetActionCategory(); value != "" {
+		category, ok := domain.ParseActionCategory(value)
+		if !ok {
+			return filter, fmt.Errorf("%w: unknown action category %q", domain.ErrInvalidInput, value)
+		}
+		filter.ActionCategory = category
+	}
+
+	var err error
+	if filter.Time, err = usecase.ParseTimeRange(msg.GetTimeFrom(), msg.GetTimeTo(), now); err != nil {
+		return filter, err
+	}
+
+	if q := msg.GetQ(); q != "" {
+		if filter.Expr, err = usecase.ParseFilterExpr(q, now); err != nil {
+			return filter, err
+		}
+	}
+
+	return filter, filter.Validate()
+}
+
+// toStatus maps domain errors onto gRPC status codes
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,216 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	stockv1 "github.com/company/stock-api/api/proto/stock/v1"
+	"github.com/company/stock-api/internal/domain"
+	"github.com/company/stock-api/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// MockStockRepository is a mock of the domain.StockRepository methods the server uses;
+// the embedded interface panics on anything else
+type MockStockRepository struct {
+	domain.StockRepository
+	mock.Mock
+}
+
+func (m *MockStockRepository) FindAll(filter domain.StockFilter) ([]*domain.StockWithDetails, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.StockWithDetails), args.Error(1)
+}
+
+func (m *MockStockRepository) FindByID(id int64) (*domain.StockWithDetails, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.StockWithDetails), args.Error(1)
+}
+
+// MockBrokerageRepository is a mock of domain.BrokerageRepository listing
+type MockBrokerageRepository struct {
+	domain.BrokerageRepository
+	mock.Mock
+}
+
+func (m *MockBrokerageRepository) FindAll(ctx context.Context) ([]*domain.Brokerage, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Brokerage), args.Error(1)
+}
+
+type grpcTestEnv struct {
+	stockRepo     *MockStockRepository
+	brokerageRepo *MockBrokerageRepository
+	server        *grpc.Server
+	client        stockv1.StockServiceClient
+}
+
+func newGRPCTestEnv(t *testing.T) *grpcTestEnv {
+	logger := zap.NewNop()
+	env := &grpcTestEnv{
+		stockRepo:     new(MockStockRepository),
+		brokerageRepo: new(MockBrokerageRepository),
+	}
+
+	brokerageUC := usecase.NewBrokerageUseCase(env.brokerageRepo, logger)
+	actionUC := usecase.NewActionUseCase(nil, nil, logger)
+	ratingUC := usecase.NewRatingUseCase(nil, logger)
+	stockUC := usecase.NewStockUseCase(env.stockRepo, nil, brokerageUC, actionUC, ratingUC, nil, nil, logger)
+	env.server = NewServer(NewStockServer(stockUC, brokerageUC, actionUC, ratingUC, logger), logger)
+
+	listener := bufconn.Listen(1 << 20)
+	go env.server.Serve(listener)
+	t.Cleanup(env.server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(func() { conn.Close() })
+	env.client = stockv1.NewStockServiceClient(conn)
+	return env
+}
+
+func makeStocks(from, count int) []*domain.StockWithDetails {
+	stocks := make([]*domain.StockWithDetails, count)
+	for i := range stocks {
+		stocks[i] = &domain.StockWithDetails{ID: int64(from + i), Ticker: fmt.Sprintf("T%d", from+i), Time: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}
+	}
+	return stocks
+}
+
+// receiveAll reads a server stream until it ends
+func receiveAll[T any](stream interface{ Recv() (*T, error) }) ([]*T, error) {
+	var messages []*T
+	for {
+		message, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return messages, nil
+		}
+		if err != nil {
+			return messages, err
+		}
+		messages = append(messages, message)
+	}
+}
+
+func TestStockServer_ListStocks(t *testing.T) {
+	t.Run("Streams every page when no limit is given", func(t *testing.T) {
+		env := newGRPCTestEnv(t)
+		env.stockRepo.On("FindAll", mock.MatchedBy(func(filter domain.StockFilter) bool {
+			return filter.Offset == 0 && filter.Limit == stockPageSize && filter.SortBy == "ticker" &&
+				assert.ObjectsAreEqual([]string{"AAPL"}, filter.Ticker.NotIn) && filter.RatingBucket == domain.RatingBucketBuy
+		})).Return(makeStocks(0, stockPageSize), nil).Once()
+		env.stockRepo.On("FindAll", mock.MatchedBy(func(filter domain.StockFilter) bool {
+			return filter.Offset == stockPageSize && filter.Limit == stockPageSize
+		})).Return(makeStocks(stockPageSize, 3), nil).Once()
+
+		stream, err := env.client.ListStocks(context.Background(), &stockv1.ListStocksRequest{
+			Filter: &stockv1.StockFilter{TickerNot: []string{"AAPL"}, RatingBucket: "buy"},
+			SortBy: "ticker",
+		})
+		assert.NoError(t, err)
+		stocks, err := receiveAll[stockv1.Stock](stream)
+
+		assert.NoError(t, err)
+		assert.Len(t, stocks, stockPageSize+3)
+		assert.Equal(t, "T502", stocks[len(stocks)-1].Ticker)
+		assert.Equal(t, int64(2024), int64(stocks[0].Time.AsTime().Year()))
+		env.stockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Stops at the limit", func(t *testing.T) {
+		env := newGRPCTestEnv(t)
+		env.stockRepo.On("FindAll", mock.MatchedBy(func(filter domain.StockFilter) bool {
+			return filter.Offset == 10 && filter.Limit == 2
+		})).Return(makeStocks(10, 2), nil).Once()
+
+		stream, err := env.client.ListStocks(context.Background(), &stockv1.ListStocksRequest{Limit: 2, Offset: 10})
+		assert.NoError(t, err)
+		stocks, err := receiveAll[stockv1.Stock](stream)
+
+		assert.NoError(t, err)
+		assert.Len(t, stocks, 2)
+		env.stockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid filter", func(t *testing.T) {
+		env := newGRPCTestEnv(t)
+
+		stream, err := env.client.ListStocks(context.Background(), &stockv1.ListStocksRequest{
+			Filter: &stockv1.StockFilter{ActionCategory: "sideways"},
+		})
+		assert.NoError(t, err)
+		_, err = receiveAll[stockv1.Stock](stream)
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		env.stockRepo.AssertNotCalled(t, "FindAll", mock.Anything)
+	})
+}
+
+func TestStockServer_GetStock(t *testing.T) {
+	env := newGRPCTestEnv(t)
+	brokerageID := int64(7)
+	env.stockRepo.On("FindByID", int64(1)).Return(&domain.StockWithDetails{ID: 1, Ticker: "AAPL", BrokerageID: &brokerageID, BrokerageName: "Goldman Sachs"}, nil).Once()
+	env.stockRepo.On("FindByID", int64(2)).Return(nil, domain.ErrNotFound).Once()
+	env.stockRepo.On("FindByID", int64(3)).Return(nil, errors.New("database error")).Once()
+
+	stock, err := env.client.GetStock(context.Background(), &stockv1.GetStockRequest{Id: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "AAPL", stock.GetTicker())
+	assert.Equal(t, int64(7), stock.GetBrokerageId())
+	assert.Nil(t, stock.RatingToId)
+	assert.Nil(t, stock.CreatedAt, "zero times are left unset")
+
+	_, err = env.client.GetStock(context.Background(), &stockv1.GetStockRequest{Id: 2})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = env.client.GetStock(context.Background(), &stockv1.GetStockRequest{Id: 3})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestStockServer_ListBrokerages(t *testing.T) {
+	env := newGRPCTestEnv(t)
+	env.brokerageRepo.On("FindAll", mock.Anything).Return([]*domain.Brokerage{{ID: 1, Name: "Barclays"}, {ID: 2, Name: "Citigroup"}}, nil).Once()
+
+	stream, err := env.client.ListBrokerages(context.Background(), &stockv1.ListBrokeragesRequest{})
+	assert.NoError(t, err)
+	brokerages, err := receiveAll[stockv1.Brokerage](stream)
+
+	assert.NoError(t, err)
+	assert.Len(t, brokerages, 2)
+	assert.Equal(t, "Citigroup", brokerages[1].GetName())
+}
+
+func TestNewServer_RegistersReflection(t *testing.T) {
+	env := newGRPCTestEnv(t)
+
+	services := env.server.GetServiceInfo()
+
+	assert.Contains(t, services, "stock.v1.StockService")
+	assert.Contains(t, services, "grpc.reflection.v1.ServerReflection")
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GetEventAnalytics godoc
+// @Summary Get time-bucketed event analytics
+// @Description Counts the events matching the filters per day, week or month, optionally split by action, action category, brokerage, rating_to or ticker, with the average target change per bucket. Buckets without events are filled with zero counts. Takes the same filters as GET /api/v1/stocks; every matching event is counted unless view is given.
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param group_by query string false "Split the counts into one series per value (action, action_category, brokerage, rating_to, ticker)"
+// @Param interval query string false "Bucket width in UTC; weeks start on Monday (day, week, month)" default(week)
+// @Param limit query int false "Maximum number of series, largest first (max 100)" default(20)
+// @Param view query string false "Events to count (history, latest, by_brokerage)" default(history)
+// @Param ticker query []string false "Filter by ticker; repeat or comma-separate for several (use not_ticker or ticker!= to exclude)" collectionFormat(multi)
+// @Param company query string false "Filter by company name (partial match)"
+// @Param brokerage query []string false "Filter by brokerage name (partial match); repeat or comma-separate for several (use not_brokerage or brokerage!= to exclude)" collectionFormat(multi)
+// @Param action query []string false "Filter by action; repeat or comma-separate for several (use not_action or action!= to exclude)" collectionFormat(multi)
+// @Param action_category query string false "Filter by action category (upgrade, downgrade, initiate, reiterate, target_raised, target_lowered, other)"
+// @Param rating_from query []string false "Filter by rating_from; repeat or comma-separate for several (use not_rating_from or rating_from!= to exclude)" collectionFormat(multi)
+// @Param rating_to query []string false "Filter by rating_to; repeat or comma-separate for several (use not_rating_to or rating_to!= to exclude)" collectionFormat(multi)
+// @Param rating_bucket query string false "Filter by canonical bucket of rating_to (strong_sell, sell, hold, buy, strong_buy)"
+// @Param target_to_min query number false "Minimum numeric target_to"
+// @Param target_to_max query number false "Maximum numeric target_to"
+// @Param target_change_pct_min query number false "Minimum percentage change from target_from to target_to"
+// @Param time_from query string false "Only events at or after this time; also the first bucket (RFC 3339, YYYY-MM-DD or relative like 7d)"
+// @Param time_to query string false "Only events at or before this time; also the last bucket (RFC 3339, YYYY-MM-DD or relative like 7d)"
+// @Param as_of query string false "Only events at or before this time (RFC 3339, YYYY-MM-DD or relative like 30d)"
+// @Param q query string false "Filter expression, e.g. rating_to in ('Buy','Outperform') and target_change_pct > 15 and time > now-30d"
+// @Success 200 {object} Response{data=domain.EventAnalytics}
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/v1/analytics/events [get]
+func (h *StockHandler) GetEventAnalytics(c *gin.Context) {
+	filter, err := h.parseStockFilter(c)
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+	// Analytics count every event unless a view is asked for
+	if c.Query("view") == "" {
+		filter.View = domain.StockViewHistory
+	}
+
+	groupBy, ok := domain.ParseAnalyticsGroupBy(c.Query("group_by"))
+	if !ok {
+		h.respondWithError(c, http.StatusBadRequest, fmt.Errorf("%w: unknown group_by %q (allowed: action, action_category, brokerage, rating_to, ticker)", domain.ErrInvalidInput, c.Query("group_by")))
+		return
+	}
+	interval, ok := domain.ParseAnalyticsInterval(c.Query("interval"))
+	if !ok {
+		h.respondWithError(c, http.StatusBadRequest, fmt.Errorf("%w: unknown interval %q (allowed: day, week, month)", domain.ErrInvalidInput, c.Query("interval")))
+		return
+	}
+
+	query := domain.EventAnalyticsQuery{Filter: filter, GroupBy: groupBy, Interval: interval}
+	analytics, err := h.useCase.GetEventAnalytics(c.Request.Context(), query, h.parseIntQuery(c, "limit", 20))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			h.respondWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		h.logger.Error("Failed to get event analytics", zap.Error(err))
+		h.respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    analytics,
+	})
+}
+
+// GetRatingTransitions godoc
+// @Summary Get the rating transition matrix
+// @Description Counts the events matching the filters per rating_from (rows) and rating_to (columns), as canonical buckets or raw terms, with the non-empty cells listed most frequent first and classified as upgrade, downgrade or unchanged. Takes the same filters as GET /api/v1/stocks; every matching event is counted unless view is given.
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param level query string false "Compare canonical buckets or raw rating terms (bucket, term)" default(bucket)
+// @Param view query string false "Events to count (history, latest, by_brokerage)" default(history)
+// @Param ticker query []string false "Filter by ticker; repeat or comma-separate for several (use not_ticker or ticker!= to exclude)" collectionFormat(multi)
+// @Param brokerage query []string false "Filter by brokerage name (partial match); repeat or comma-separate for several (use not_brokerage or brokerage!= to exclude)" collectionFormat(multi)
+// @Param action query []string false "Filter by action; repeat or comma-separate for several (use not_action or action!= to exclude)" collectionFormat(multi)
+// @Param action_category query string false "Filter by action category (upgrade, downgrade, initiate, reiterate, target_raised, target_lowered, other)"
+// @Param time_from query string false "Only events at or after this time (RFC 3339, YYYY-MM-DD or relative like 7d)"
+// @Param time_to query string false "Only events at or before this time (RFC 3339, YYYY-MM-DD or relative like 7d)"
+// @Param as_of query string false "Only events at or before this time (RFC 3339, YYYY-MM-DD or relative like 30d)"
+// @Param q query string false "Filter expression, e.g. rating_to in ('Buy','Outperform') and target_change_pct > 15 and time > now-30d"
+// @Success 200 {object} Response{data=domain.RatingTransitionMatrix}
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/v1/analytics/rating-transitions [get]
+func (h *StockHandler) GetRatingTransitions(c *gin.Context) {
+	filter, err := h.parseStockFilter(c)
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+	// Transitions count every event unless a view is asked for
+	if c.Query("view") == "" {
+		filter.View = domain.StockViewHistory
+	}
+
+	level, ok := domain.ParseTransitionLevel(c.Query("level"))
+	if !ok {
+		h.respondWithError(c, http.StatusBadRequest, fmt.Errorf("%w: unknown level %q (allowed: bucket, term)", domain.ErrInvalidInput, c.Query("level")))
+		return
+	}
+
+	matrix, err := h.useCase.GetRatingTransitions(c.Request.Context(), domain.RatingTransitionQuery{Filter: filter, Level: level})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			h.respondWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		h.logger.Error("Failed to get rating transitions", zap.Error(err))
+		h.respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    matrix,
+	})
+}
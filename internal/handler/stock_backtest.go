@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/company/stock-api/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Backtest defaults
+const (
+	defaultBacktestHorizon = "30d"
+	// defaultBacktestSpan is the backtested period when from is not given
+	defaultBacktestSpan = 26 * 7 * 24 * time.Hour
+)
+
+// GetBacktest godoc
+// @Summary Backtest the recommendation algorithm
+// @Description Replays the recommendations on every date from from to to, scoring only the events available at each date, and follows every scored stock for the horizon. A stock is a hit when later upgrades and target raises by other brokerages outnumber their downgrades and target cuts, and a miss when they are outnumbered. Returns the hit rates of the top picks and of every scored stock, statistics per score decile, and the picks themselves; format=csv returns only the statistics. Requires the admin API key, since every date is a full scoring pass; the backtest CLI subcommand runs it offline.
+// @Tags stocks
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Param from query string false "First date to score at (RFC 3339, YYYY-MM-DD or relative like 26w); defaults to 26 weeks before to"
+// @Param to query string false "Last date to score at (RFC 3339, YYYY-MM-DD or relative like 30d); defaults to one horizon before now"
+// @Param interval query string false "Time between scored dates (day, week, month)" default(week)
+// @Param horizon query string false "How long to follow each stock, like 30d or 12w (max 365d)" default(30d)
+// @Param limit query int false "Number of top-scored stocks per date counted as picks (max 100)" default(10)
+// @Param strategy query string false "Scoring strategy to backtest (balanced, momentum, consensus, contrarian)" default(balanced)
+// @Param format query string false "Response format (json, csv)" default(json)
+// @Success 200 {object} Response{data=domain.BacktestResult}
+// @Failure 400 {object} Response
+// @Failure 401 {object} Response
+// @Failure 500 {object} Response
+// @Security AdminAPIKey
+// @Router /api/v1/recommendations/backtest [get]
+func (h *StockHandler) GetBacktest(c *gin.Context) {
+	now := time.Now()
+	query := domain.BacktestQuery{Limit: h.parseIntQuery(c, "limit", 0), Strategy: c.Query("strategy")}
+
+	var err error
+	if query.Horizon, err = usecase.ParseRelativeDuration(c.DefaultQuery("horizon", defaultBacktestHorizon)); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, fmt.Errorf("horizon: %w", err))
+		return
+	}
+
+	query.To = now.Add(-query.Horizon)
+	if value := c.Query("to"); value != "" {
+		if query.To, err = usecase.ParseTimeBound(value, now); err != nil {
+			h.respondWithError(c, http.StatusBadRequest, fmt.Errorf("to: %w", err))
+			return
+		}
+	}
+	query.From = query.To.Add(-defaultBacktestSpan)
+	if value := c.Query("from"); value != "" {
+		if query.From, err = usecase.ParseTimeBound(value, now); err != nil {
+			h.respondWithError(c, http.StatusBadRequest, fmt.Errorf("from: %w", err))
+			return
+		}
+	}
+
+	interval, ok := domain.ParseAnalyticsInterval(c.Query("interval"))
+	if !ok {
+		h.respondWithError(c, http.StatusBadRequest, fmt.Errorf("%w: unknown interval %q (allowed: day, week, month)", domain.ErrInvalidInput, c.Query("interval")))
+		return
+	}
+	query.Interval = interval
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		h.respondWithError(c, http.StatusBadRequest, fmt.Errorf("%w: unknown format %q (allowed: json, csv)", domain.ErrInvalidInput, format))
+		return
+	}
+
+	// Replaying many dates can outlast the server's write timeout
+	extendWriteDeadline(c)
+
+	result, err := h.useCase.Backtest(c.Request.Context(), query)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			h.respondWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		h.logger.Error("Failed to run backtest", zap.Error(err))
+		h.respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if format == "csv" {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "backtest-"+now.UTC().Format("20060102T150405Z")+".csv"))
+		c.Status(http.StatusOK)
+		if err := usecase.WriteBacktestCSV(c.Writer, result); err != nil {
+			h.logger.Warn("Backtest CSV aborted", zap.Error(err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    result,
+	})
+}
@@ -0,0 +1,326 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/company/stock-api/internal/export"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Export streaming
+const (
+	// exportFlushRows is how many rows are written between flushes, so clients receive the export in chunks
+	exportFlushRows = 500
+	// exportWriteTimeout replaces the server's write timeout for each chunk, so long exports are not cut off
+	// while stalled clients still are
+	exportWriteTimeout = 30 * time.Second
+)
+
+// stockExportWriter writes exported stocks in one format
+type stockExportWriter interface {
+	// writeHeader is called once before the first stock
+	writeHeader() error
+	writeStock(stock *domain.StockWithDetails) error
+	flush() error
+}
+
+// ExportStocks godoc
+// @Summary Export stocks
+// @Description Streams every stock matching the filters as CSV or NDJSON, without pagination. view=latest exports the latest event per ticker, view=by_brokerage the latest event per ticker and brokerage, view=history every matching event. Takes the same filters as GET /api/v1/stocks.
+// @Tags stocks
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param format query string false "Export format (csv, ndjson)" default(csv)
+// @Param view query string false "Events to export (latest, by_brokerage, history)" default(latest)
+// @Param ticker query []string false "Filter by ticker; repeat or comma-separate for several (use not_ticker or ticker!= to exclude)" collectionFormat(multi)
+// @Param company query string false "Filter by company name (partial match)"
+// @Param brokerage query []string false "Filter by brokerage name (partial match); repeat or comma-separate for several (use not_brokerage or brokerage!= to exclude)" collectionFormat(multi)
+// @Param action query []string false "Filter by action; repeat or comma-separate for several (use not_action or action!= to exclude)" collectionFormat(multi)
+// @Param action_category query string false "Filter by action category (upgrade, downgrade, initiate, reiterate, target_raised, target_lowered, other)"
+// @Param rating_from query []string false "Filter by rating_from; repeat or comma-separate for several (use not_rating_from or rating_from!= to exclude)" collectionFormat(multi)
+// @Param rating_to query []string false "Filter by rating_to; repeat or comma-separate for several (use not_rating_to or rating_to!= to exclude)" collectionFormat(multi)
+// @Param rating_bucket query string false "Filter by canonical bucket of rating_to (strong_sell, sell, hold, buy, strong_buy)"
+// @Param target_to_min query number false "Minimum numeric target_to"
+// @Param target_to_max query number false "Maximum numeric target_to"
+// @Param target_change_pct_min query number false "Minimum percentage change from target_from to target_to"
+// @Param time_from query string false "Only events at or after this time (RFC 3339, YYYY-MM-DD or relative like 7d)"
+// @Param time_to query string false "Only events at or before this time (RFC 3339, YYYY-MM-DD or relative like 7d)"
+// @Param as_of query string false "Show the data as it was at this time: only events at or before it are considered (RFC 3339, YYYY-MM-DD or relative like 30d)"
+// @Param q query string false "Filter expression, e.g. rating_to in ('Buy','Outperform') and target_change_pct > 15 and time > now-30d"
+// @Param fields query string false "Comma-separated fields (columns) to export (default: all except converted)"
+// @Param sortBy query string false "Sort by field (ticker, company, time, rating_to, action)" default(time)
+// @Param sortOrder query string false "Sort order (asc, desc)" default(desc)
+// @Param limit query int false "Maximum number of rows (default: all)"
+// @Param offset query int false "Number of rows to skip" default(0)
+// @Success 200 {string} string "CSV or NDJSON rows"
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/v1/stocks/export [get]
+func (h *StockHandler) ExportStocks(c *gin.Context) {
+	filter, err := h.parseStockFilter(c)
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+	// Exports are not paginated unless a limit is given
+	filter.Limit = h.parseIntQuery(c, "limit", 0)
+
+	format := c.DefaultQuery("format", "csv")
+	var writer stockExportWriter
+	var contentType string
+	switch format {
+	case "csv":
+		writer = newCSVStockWriter(c.Writer, exportColumns(filter.Fields))
+		contentType = "text/csv; charset=utf-8"
+	case "ndjson":
+		writer = &ndjsonStockWriter{encoder: json.NewEncoder(c.Writer), fields: filter.Fields}
+		contentType = "application/x-ndjson"
+	default:
+		h.respondWithError(c, http.StatusBadRequest, fmt.Errorf("%w: unknown format %q (allowed: csv, ndjson)", domain.ErrInvalidInput, format))
+		return
+	}
+
+	extendWriteDeadline(c)
+
+	// Headers are sent with the first row so errors before it can still be answered with a JSON error
+	started := false
+	start := func() error {
+		started = true
+		filename := fmt.Sprintf("stocks-%s-%s.%s", filter.View, time.Now().UTC().Format("20060102T150405Z"), format)
+		c.Header("Content-Type", contentType)
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		c.Status(http.StatusOK)
+		return writer.writeHeader()
+	}
+
+	rows := 0
+	err = h.useCase.ExportStocks(c.Request.Context(), filter, func(stock *domain.StockWithDetails) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := writer.writeStock(stock); err != nil {
+			return err
+		}
+		rows++
+		if rows%exportFlushRows == 0 {
+			if err := writer.flush(); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+			extendWriteDeadline(c)
+		}
+		return nil
+	})
+	if err == nil && !started {
+		err = start()
+	}
+
+	if err != nil {
+		if !started {
+			status := http.StatusInternalServerError
+			if errors.Is(err, domain.ErrInvalidInput) {
+				status = http.StatusBadRequest
+			}
+			h.respondWithError(c, status, err)
+			return
+		}
+		// The status line is already sent, so abort the connection to keep a truncated export
+		// from looking complete
+		h.logger.Error("Stock export aborted", zap.Int("rows", rows), zap.Error(err))
+		panic(http.ErrAbortHandler)
+	}
+
+	if err := writer.flush(); err != nil {
+		h.logger.Error("Failed to flush stock export", zap.Error(err))
+	}
+}
+
+// ExportParquet godoc
+// @Summary Export stock events as Parquet
+// @Description Streams a zip archive of Parquet files with every stored stock event and its brokerage, action and rating names, partitioned by month of the event time (month=YYYY-MM/). Pass the watermark of the previous export as since to only export events stored after it. The archive ends with a _watermark file, also returned in the X-Export-Watermark header. Requires the admin API key.
+// @Tags stocks
+// @Produce application/zip
+// @Param since query string false "Only events stored after this watermark (RFC 3339)"
+// @Success 200 {file} file "Zip archive of Parquet files"
+// @Header 200 {string} X-Export-Watermark "Watermark to pass as since on the next export"
+// @Failure 400 {object} Response
+// @Failure 401 {object} Response
+// @Failure 500 {object} Response
+// @Security AdminAPIKey
+// @Router /api/v1/stocks/export/parquet [get]
+func (h *StockHandler) ExportParquet(c *gin.Context) {
+	var since *time.Time
+	if value := c.Query("since"); value != "" {
+		watermark, err := export.ParseWatermark(value)
+		if err != nil {
+			h.respondWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		since = &watermark
+	}
+	until, err := h.useCase.ExportWatermark(c.Request.Context())
+	if err != nil {
+		h.respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	window := export.NewWindow(since, until)
+
+	extendWriteDeadline(c)
+
+	// Headers are sent with the first zip entry so errors before it can still be answered with a JSON error
+	started := false
+	sink := export.NewZipSink(writerFunc(func(p []byte) (int, error) {
+		if !started {
+			started = true
+			filename := fmt.Sprintf("stocks-parquet-%s.zip", window.Until.Format("20060102T150405Z"))
+			c.Header("Content-Type", "application/zip")
+			c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+			c.Header("X-Export-Watermark", export.FormatWatermark(window.Until))
+			c.Status(http.StatusOK)
+		}
+		return c.Writer.Write(p)
+	}))
+
+	rows := 0
+	source := func(ctx context.Context, window domain.EventWindow, fn func(*domain.StockWithDetails) error) error {
+		return h.useCase.StreamEvents(ctx, window, func(stock *domain.StockWithDetails) error {
+			rows++
+			if rows%exportFlushRows == 0 {
+				extendWriteDeadline(c)
+			}
+			return fn(stock)
+		})
+	}
+
+	summary, err := export.WriteParquet(c.Request.Context(), source, window, sink)
+	if err == nil {
+		err = sink.Close()
+	}
+	if err != nil {
+		if !started {
+			status := http.StatusInternalServerError
+			if errors.Is(err, domain.ErrInvalidInput) {
+				status = http.StatusBadRequest
+			}
+			h.respondWithError(c, status, err)
+			return
+		}
+		// The status line is already sent, so abort the connection to keep a truncated archive
+		// from looking complete
+		h.logger.Error("Parquet export aborted", zap.Int("rows", rows), zap.Error(err))
+		panic(http.ErrAbortHandler)
+	}
+
+	h.logger.Info("Parquet export finished", zap.Int64("rows", summary.Rows), zap.Int("files", len(summary.Files)),
+		zap.String("watermark", export.FormatWatermark(summary.Watermark)))
+}
+
+// writerFunc adapts a function to io.Writer
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
+
+// extendWriteDeadline gives an export another exportWriteTimeout to write its next chunk
+func extendWriteDeadline(c *gin.Context) {
+	// Not every ResponseWriter supports deadlines; the server's write timeout then applies
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Now().Add(exportWriteTimeout))
+}
+
+// exportColumns returns the CSV columns for the requested fields; nil fields exports all but converted
+func exportColumns(fields []string) []string {
+	if fields != nil {
+		return fields
+	}
+
+	columns := make([]string, 0, len(domain.StockFields))
+	for _, field := range domain.StockFields {
+		if field != "converted" {
+			columns = append(columns, field)
+		}
+	}
+	return columns
+}
+
+// csvStockWriter writes one CSV record per stock under a header of column names
+type csvStockWriter struct {
+	writer  *csv.Writer
+	columns []string
+	record  []string
+}
+
+func newCSVStockWriter(w http.ResponseWriter, columns []string) *csvStockWriter {
+	return &csvStockWriter{writer: csv.NewWriter(w), columns: columns, record: make([]string, len(columns))}
+}
+
+func (w *csvStockWriter) writeHeader() error {
+	return w.writer.Write(w.columns)
+}
+
+func (w *csvStockWriter) writeStock(stock *domain.StockWithDetails) error {
+	values := stock.Project(w.columns)
+	for i, column := range w.columns {
+		w.record[i] = formatCSVValue(values[column])
+	}
+	return w.writer.Write(w.record)
+}
+
+func (w *csvStockWriter) flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// formatCSVValue renders a projected field value as a CSV cell; missing values are empty
+func formatCSVValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case *float64:
+		if v == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*v, 'f', -1, 64)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.UTC().Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// ndjsonStockWriter writes one JSON object per line, shaped like the stocks of GET /api/v1/stocks
+type ndjsonStockWriter struct {
+	encoder *json.Encoder
+	fields  []string
+}
+
+func (w *ndjsonStockWriter) writeHeader() error {
+	return nil
+}
+
+func (w *ndjsonStockWriter) writeStock(stock *domain.StockWithDetails) error {
+	if w.fields != nil {
+		return w.encoder.Encode(stock.Project(w.fields))
+	}
+	return w.encoder.Encode(stock)
+}
+
+func (w *ndjsonStockWriter) flush() error {
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/company/stock-api/internal/domain"
 	"github.com/company/stock-api/internal/usecase"
@@ -18,16 +20,22 @@ type StockHandler struct {
 	brokerageUC *usecase.BrokerageUseCase
 	actionUC    *usecase.ActionUseCase
 	ratingUC    *usecase.RatingUseCase
+	fxUC        *usecase.FXUseCase
+	priceUC     *usecase.PriceUseCase
+	searchUC    *usecase.SearchUseCase
 	logger      *zap.Logger
 }
 
 // NewStockHandler creates a new StockHandler
-func NewStockHandler(useCase *usecase.StockUseCase, brokerageUC *usecase.BrokerageUseCase, actionUC *usecase.ActionUseCase, ratingUC *usecase.RatingUseCase, logger *zap.Logger) *StockHandler {
+func NewStockHandler(useCase *usecase.StockUseCase, brokerageUC *usecase.BrokerageUseCase, actionUC *usecase.ActionUseCase, ratingUC *usecase.RatingUseCase, fxUC *usecase.FXUseCase, priceUC *usecase.PriceUseCase, searchUC *usecase.SearchUseCase, logger *zap.Logger) *StockHandler {
 	return &StockHandler{
 		useCase:     useCase,
 		brokerageUC: brokerageUC,
 		actionUC:    actionUC,
 		ratingUC:    ratingUC,
+		fxUC:        fxUC,
+		priceUC:     priceUC,
+		searchUC:    searchUC,
 		logger:      logger,
 	}
 }
@@ -82,35 +90,41 @@ func (h *StockHandler) SyncStocks(c *gin.Context) {
 
 // GetStocks godoc
 // @Summary Get stocks
-// @Description Retrieves stocks with optional filtering and pagination
+// @Description Retrieves the latest stock per ticker (or per ticker and brokerage with view=by_brokerage) with optional filtering and pagination. Time filters select which events are considered before picking the latest one.
 // @Tags stocks
 // @Accept json
 // @Produce json
-// @Param ticker query string false "Filter by ticker"
+// @Param view query string false "Rows to return: latest (latest event per ticker), by_brokerage (latest event per ticker and brokerage) or history (every event)" default(latest)
+// @Param ticker query []string false "Filter by ticker; repeat or comma-separate for several (use not_ticker or ticker!= to exclude)" collectionFormat(multi)
 // @Param company query string false "Filter by company name (partial match)"
-// @Param brokerage query string false "Filter by brokerage name (partial match)"
-// @Param action query string false "Filter by action"
-// @Param rating_from query string false "Filter by rating_from"
-// @Param rating_to query string false "Filter by rating_to"
+// @Param brokerage query []string false "Filter by brokerage name (partial match); repeat or comma-separate for several (use not_brokerage or brokerage!= to exclude)" collectionFormat(multi)
+// @Param action query []string false "Filter by action; repeat or comma-separate for several (use not_action or action!= to exclude)" collectionFormat(multi)
+// @Param action_category query string false "Filter by action category (upgrade, downgrade, initiate, reiterate, target_raised, target_lowered, other)"
+// @Param rating_from query []string false "Filter by rating_from; repeat or comma-separate for several (use not_rating_from or rating_from!= to exclude)" collectionFormat(multi)
+// @Param rating_to query []string false "Filter by rating_to; repeat or comma-separate for several (use not_rating_to or rating_to!= to exclude)" collectionFormat(multi)
+// @Param rating_bucket query string false "Filter by canonical bucket of rating_to (strong_sell, sell, hold, buy, strong_buy)"
+// @Param target_to_min query number false "Minimum numeric target_to"
+// @Param target_to_max query number false "Maximum numeric target_to"
+// @Param target_change_pct_min query number false "Minimum percentage change from target_from to target_to"
+// @Param time_from query string false "Only events at or after this time (RFC 3339, YYYY-MM-DD or relative like 7d)"
+// @Param time_to query string false "Only events at or before this time (RFC 3339, YYYY-MM-DD or relative like 7d)"
+// @Param as_of query string false "Show the data as it was at this time: only events at or before it are considered (RFC 3339, YYYY-MM-DD or relative like 30d)"
+// @Param q query string false "Filter expression, e.g. rating_to in ('Buy','Outperform') and target_change_pct > 15 and time > now-30d"
+// @Param fields query string false "Comma-separated fields to return, e.g. ticker,rating_to,target_to (default: all)"
+// @Param currency query string false "Convert price targets to this reporting currency (e.g. USD)"
 // @Param sortBy query string false "Sort by field (ticker, company, time, rating_to, action)" default(time)
 // @Param sortOrder query string false "Sort order (asc, desc)" default(desc)
 // @Param limit query int false "Number of items per page" default(50)
 // @Param offset query int false "Number of items to skip" default(0)
 // @Success 200 {object} PaginatedResponse
+// @Failure 400 {object} Response
 // @Failure 500 {object} Response
 // @Router /api/v1/stocks [get]
 func (h *StockHandler) GetStocks(c *gin.Context) {
-	filter := domain.StockFilter{
-		Ticker:     c.Query("ticker"),
-		Company:    c.Query("company"),
-		Brokerage:  c.Query("brokerage"),
-		Action:     c.Query("action"),
-		RatingFrom: c.Query("rating_from"),
-		RatingTo:   c.Query("rating_to"),
-		SortBy:     c.DefaultQuery("sortBy", "time"),
-		SortOrder:  c.DefaultQuery("sortOrder", "desc"),
-		Limit:      h.parseIntQuery(c, "limit", 50),
-		Offset:     h.parseIntQuery(c, "offset", 0),
+	filter, err := h.parseStockFilter(c)
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
 	}
 
 	stocks, err := h.useCase.GetStocks(c.Request.Context(), filter)
@@ -120,11 +134,15 @@ func (h *StockHandler) GetStocks(c *gin.Context) {
 		return
 	}
 
+	if !h.convertCurrency(c, stocks) {
+		return
+	}
+
 	total, _ := h.useCase.GetStockCount(c.Request.Context(), filter)
 
 	c.JSON(http.StatusOK, PaginatedResponse{
 		Success: true,
-		Data:    stocks,
+		Data:    projectStocks(stocks, filter.Fields),
 		Meta: MetaData{
 			Total:  total,
 			Limit:  filter.Limit,
@@ -176,7 +194,12 @@ func (h *StockHandler) GetStockByID(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param ticker path string true "Stock ticker symbol (e.g., AAPL, GOOGL)"
+// @Param time_from query string false "Only events at or after this time (RFC 3339, YYYY-MM-DD or relative like 7d)"
+// @Param fields query string false "Comma-separated fields to return, e.g. ticker,rating_to,target_to (default: all)"
+// @Param time_to query string false "Only events at or before this time (RFC 3339, YYYY-MM-DD or relative like 7d)"
+// @Param currency query string false "Convert price targets to this reporting currency (e.g. USD)"
 // @Success 200 {object} Response
+// @Failure 400 {object} Response
 // @Failure 404 {object} Response
 // @Failure 500 {object} Response
 // @Router /api/v1/stock/{ticker} [get]
@@ -187,7 +210,19 @@ func (h *StockHandler) GetStocksByTicker(c *gin.Context) {
 		return
 	}
 
-	stocks, err := h.useCase.GetStocksByTicker(c.Request.Context(), ticker)
+	timeRange, err := usecase.ParseTimeRange(c.Query("time_from"), c.Query("time_to"), time.Now())
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	fields, err := usecase.ParseStockFields(c.Query("fields"))
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	stocks, err := h.useCase.GetStocksByTicker(c.Request.Context(), ticker, timeRange, fields)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			h.respondWithError(c, http.StatusNotFound, err)
@@ -198,20 +233,188 @@ func (h *StockHandler) GetStocksByTicker(c *gin.Context) {
 		return
 	}
 
+	if !h.convertCurrency(c, stocks) {
+		return
+	}
+
 	c.JSON(http.StatusOK, Response{
 		Success: true,
-		Data:    stocks,
+		Data:    projectStocks(stocks, fields),
+	})
+}
+
+// GetTimeline godoc
+// @Summary Get a ticker's timeline
+// @Description Retrieves a ticker's events, each annotated with what changed since the previous event by any brokerage (since_previous) and by the same brokerage (since_brokerage): rating movement, target delta and percentage change, and action. Filters select which events are returned; changes are always relative to the full history. An unknown ticker returns 404; filters matching none of a known ticker's events return an empty page.
+// @Tags stocks
+// @Accept json
+// @Produce json
+// @Param ticker path string true "Stock ticker symbol (e.g., AAPL, GOOGL)"
+// @Param brokerage query []string false "Filter by brokerage name (partial match); repeat or comma-separate for several (use not_brokerage or brokerage!= to exclude)" collectionFormat(multi)
+// @Param time_from query string false "Only events at or after this time (RFC 3339, YYYY-MM-DD or relative like 7d)"
+// @Param time_to query string false "Only events at or before this time (RFC 3339, YYYY-MM-DD or relative like 7d)"
+// @Param sortOrder query string false "Sort order by time (asc, desc)" default(desc)
+// @Param limit query int false "Number of items per page" default(50)
+// @Param offset query int false "Number of items to skip" default(0)
+// @Success 200 {object} PaginatedResponse{data=[]domain.TimelineEvent}
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/v1/stock/{ticker}/timeline [get]
+func (h *StockHandler) GetTimeline(c *gin.Context) {
+	ticker := c.Param("ticker")
+	if ticker == "" {
+		h.respondWithError(c, http.StatusBadRequest, domain.ErrInvalidInput)
+		return
+	}
+
+	filter := domain.TimelineFilter{
+		Brokerage: h.parseValueFilter(c, "brokerage"),
+		SortOrder: c.DefaultQuery("sortOrder", "desc"),
+		Limit:     h.parseIntQuery(c, "limit", 50),
+		Offset:    h.parseIntQuery(c, "offset", 0),
+	}
+
+	var err error
+	if filter.Time, err = usecase.ParseTimeRange(c.Query("time_from"), c.Query("time_to"), time.Now()); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	total, err := h.useCase.GetTimelineCount(c.Request.Context(), ticker, filter)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			h.respondWithError(c, http.StatusBadRequest, err)
+		case errors.Is(err, domain.ErrNotFound):
+			h.respondWithError(c, http.StatusNotFound, err)
+		default:
+			h.logger.Error("Failed to count timeline", zap.String("ticker", ticker), zap.Error(err))
+			h.respondWithError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	events, err := h.useCase.GetTimeline(c.Request.Context(), ticker, filter)
+	if err != nil {
+		h.logger.Error("Failed to get timeline", zap.String("ticker", ticker), zap.Error(err))
+		h.respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Success: true,
+		Data:    events,
+		Meta: MetaData{
+			Total:  total,
+			Limit:  filter.Limit,
+			Offset: filter.Offset,
+		},
+	})
+}
+
+// GetConsensus godoc
+// @Summary Get the analyst consensus for a ticker
+// @Description Summarizes the latest rating and the latest price target of each brokerage covering the ticker within the window: rating distribution by canonical bucket, mean/median/high/low price target with standard deviation, number of covering brokerages, and net upgrades minus downgrades over the window.
+// @Tags stocks
+// @Accept json
+// @Produce json
+// @Param ticker path string true "Stock ticker symbol (e.g., AAPL, GOOGL)"
+// @Param window query string false "Start of the window, relative to now like 90d or 12w, or an RFC 3339 time or YYYY-MM-DD date" default(90d)
+// @Success 200 {object} Response{data=domain.Consensus}
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/v1/stock/{ticker}/consensus [get]
+func (h *StockHandler) GetConsensus(c *gin.Context) {
+	ticker := c.Param("ticker")
+	if ticker == "" {
+		h.respondWithError(c, http.StatusBadRequest, domain.ErrInvalidInput)
+		return
+	}
+
+	now := time.Now()
+	from, err := usecase.ParseTimeBound(c.DefaultQuery("window", "90d"), now)
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, fmt.Errorf("window: %w", err))
+		return
+	}
+
+	consensus, err := h.useCase.GetConsensus(c.Request.Context(), ticker, from, now)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			h.respondWithError(c, http.StatusBadRequest, err)
+		case errors.Is(err, domain.ErrNotFound):
+			h.respondWithError(c, http.StatusNotFound, err)
+		default:
+			h.logger.Error("Failed to get consensus", zap.String("ticker", ticker), zap.Error(err))
+			h.respondWithError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    consensus,
+	})
+}
+
+// GetPriceBars godoc
+// @Summary Get daily price bars for a ticker
+// @Description Retrieves the daily open, high, low and close of a ticker, newest first
+// @Tags stocks
+// @Accept json
+// @Produce json
+// @Param ticker path string true "Stock ticker symbol (e.g., AAPL, GOOGL)"
+// @Param time_from query string false "Only bars on or after this date (RFC 3339, YYYY-MM-DD or relative like 90d)"
+// @Param time_to query string false "Only bars on or before this date (RFC 3339, YYYY-MM-DD or relative like 7d)"
+// @Param limit query int false "Maximum number of bars (max 5000)" default(250)
+// @Success 200 {object} Response{data=[]domain.PriceBar}
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/v1/stock/{ticker}/prices [get]
+func (h *StockHandler) GetPriceBars(c *gin.Context) {
+	ticker := c.Param("ticker")
+
+	timeRange, err := usecase.ParseTimeRange(c.Query("time_from"), c.Query("time_to"), time.Now())
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	bars, err := h.priceUC.GetPriceBars(c.Request.Context(), ticker, timeRange, h.parseIntQuery(c, "limit", 0))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			h.respondWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		h.logger.Error("Failed to get price bars", zap.String("ticker", ticker), zap.Error(err))
+		h.respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    bars,
 	})
 }
 
 // GetRecommendations godoc
 // @Summary Get stock recommendations
-// @Description Analyzes stock data and returns the best investment recommendations based on ratings, actions, target prices, and recency
+// @Description Analyzes stock data and returns the best investment recommendations based on ratings, actions, target prices, and recency.
+// @Description Each recommendation breaks its score down into factors with their raw value, score, weight and contribution.
 // @Tags stocks
 // @Accept json
 // @Produce json
 // @Param limit query int false "Number of recommendations to return" default(10)
+// @Param fields query string false "Comma-separated fields to return, e.g. ticker,rating_to,target_to (default: all)"
+// @Param as_of query string false "Score the stocks as they were at this time, with recency relative to it (RFC 3339, YYYY-MM-DD or relative like 30d)"
+// @Param currency query string false "Convert price targets to this reporting currency (e.g. USD)"
+// @Param strategy query string false "Scoring strategy (balanced, momentum, consensus, contrarian); see /api/v1/recommendations/strategies" default(balanced)
+// @Param explain query bool false "Include the profile rule each factor score came from" default(false)
 // @Success 200 {object} Response
+// @Failure 400 {object} Response
 // @Failure 500 {object} Response
 // @Router /api/v1/recommendations [get]
 func (h *StockHandler) GetRecommendations(c *gin.Context) {
@@ -225,20 +428,86 @@ func (h *StockHandler) GetRecommendations(c *gin.Context) {
 		limit = 10
 	}
 
-	recommendations, err := h.useCase.GetRecommendations(c.Request.Context(), limit)
+	fields, err := usecase.ParseStockFields(c.Query("fields"))
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	asOf, err := usecase.ParseAsOf(c.Query("as_of"), time.Now())
 	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	explain, err := h.parseBoolQuery(c, "explain")
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	recommendations, err := h.useCase.GetRecommendations(c.Request.Context(), domain.RecommendationQuery{
+		Limit:    limit,
+		Fields:   fields,
+		AsOf:     asOf,
+		Strategy: c.Query("strategy"),
+		Explain:  explain,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			h.respondWithError(c, http.StatusBadRequest, err)
+			return
+		}
 		h.logger.Error("Failed to get recommendations", zap.Error(err))
 		h.respondWithError(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	stocks := make([]*domain.StockWithDetails, 0, len(recommendations))
+	for _, recommendation := range recommendations {
+		stocks = append(stocks, recommendation.Stock)
+	}
+	if !h.convertCurrency(c, stocks) {
+		return
+	}
+
 	c.JSON(http.StatusOK, Response{
 		Success: true,
-		Data:    recommendations,
+		Data:    projectRecommendations(recommendations, fields),
 		Message: fmt.Sprintf("Top %d stock recommendations based on recent ratings, actions, and target prices", len(recommendations)),
 	})
 }
 
+// GetRecommendationStrategies godoc
+// @Summary List recommendation strategies
+// @Description Lists the scoring strategies /api/v1/recommendations and the backtest accept in strategy, and which one is the default
+// @Tags stocks
+// @Accept json
+// @Produce json
+// @Success 200 {object} Response{data=[]domain.ScoringStrategyInfo}
+// @Router /api/v1/recommendations/strategies [get]
+func (h *StockHandler) GetRecommendationStrategies(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    h.useCase.Strategies(),
+	})
+}
+
+// GetScoringProfile godoc
+// @Summary Get the active scoring profile
+// @Description Returns the factor weights, action scores, recency buckets and brokerage tiers recommendations are currently scored with, the file they were loaded from ('default' for the built-in profile) and when
+// @Tags stocks
+// @Accept json
+// @Produce json
+// @Success 200 {object} Response{data=domain.ActiveScoringProfile}
+// @Router /api/v1/recommendations/profile [get]
+func (h *StockHandler) GetScoringProfile(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    h.useCase.ScoringProfile(),
+	})
+}
+
 // HealthCheck godoc
 // @Summary Health check
 // @Description Check if the API is healthy
@@ -270,6 +539,189 @@ func (h *StockHandler) parseIntQuery(c *gin.Context, key string, defaultValue in
 	return intValue
 }
 
+// parseStockFilter builds a StockFilter from query parameters, validating enumerated and numeric values
+func (h *StockHandler) parseStockFilter(c *gin.Context) (domain.StockFilter, error) {
+	filter := domain.StockFilter{
+		Ticker:     h.parseValueFilter(c, "ticker"),
+		Company:    c.Query("company"),
+		Brokerage:  h.parseValueFilter(c, "brokerage"),
+		Action:     h.parseValueFilter(c, "action"),
+		RatingFrom: h.parseValueFilter(c, "rating_from"),
+		RatingTo:   h.parseValueFilter(c, "rating_to"),
+		SortBy:     c.DefaultQuery("sortBy", "time"),
+		SortOrder:  c.DefaultQuery("sortOrder", "desc"),
+		Limit:      h.parseIntQuery(c, "limit", 50),
+		Offset:     h.parseIntQuery(c, "offset", 0),
+	}
+
+	view, ok := domain.ParseStockView(c.Query("view"))
+	if !ok {
+		return filter, fmt.Errorf("%w: unknown view %q (allowed: latest, by_brokerage, history)", domain.ErrInvalidInput, c.Query("view"))
+	}
+	filter.View = view
+
+	if value := c.Query("rating_bucket"); value != "" {
+		bucket, ok := domain.ParseRatingBucket(value)
+		if !ok {
+			return filter, fmt.Errorf("%w: unknown rating bucket %q", domain.ErrInvalidInput, value)
+		}
+		filter.RatingBucket = bucket
+	}
+
+	if value := c.Query("action_category"); value != "" {
+		category, ok := domain.ParseActionCategory(value)
+		if !ok {
+			return filter, fmt.Errorf("%w: unknown action category %q", domain.ErrInvalidInput, value)
+		}
+		filter.ActionCategory = category
+	}
+
+	var err error
+	if filter.TargetToMin, err = h.parseFloatQuery(c, "target_to_min"); err != nil {
+		return filter, err
+	}
+	if filter.TargetToMax, err = h.parseFloatQuery(c, "target_to_max"); err != nil {
+		return filter, err
+	}
+	if filter.TargetChangePctMin, err = h.parseFloatQuery(c, "target_change_pct_min"); err != nil {
+		return filter, err
+	}
+
+	// Relative times and filter expressions are relative to as_of when it is given
+	now := time.Now()
+	if filter.AsOf, err = usecase.ParseAsOf(c.Query("as_of"), now); err != nil {
+		return filter, err
+	}
+	if filter.AsOf != nil {
+		now = *filter.AsOf
+	}
+	if filter.Time, err = usecase.ParseTimeRange(c.Query("time_from"), c.Query("time_to"), now); err != nil {
+		return filter, err
+	}
+
+	if filter.Fields, err = usecase.ParseStockFields(c.Query("fields")); err != nil {
+		return filter, err
+	}
+
+	if q := c.Query("q"); q != "" {
+		if filter.Expr, err = usecase.ParseFilterExpr(q, now); err != nil {
+			return filter, err
+		}
+	}
+
+	return filter, filter.Validate()
+}
+
+// parseValueFilter collects included values from key (repeated or comma-separated) and
+// excluded values from "key!=" and "not_key"
+func (h *StockHandler) parseValueFilter(c *gin.Context, key string) domain.ValueFilter {
+	return domain.ValueFilter{
+		In:    splitQueryValues(c.QueryArray(key)),
+		NotIn: splitQueryValues(append(c.QueryArray(key+"!"), c.QueryArray("not_"+key)...)),
+	}
+}
+
+// splitQueryValues splits comma-separated query values, dropping blanks
+func splitQueryValues(raw []string) []string {
+	var values []string
+	for _, item := range raw {
+		for _, value := range strings.Split(item, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// projectStocks keeps only the requested fields of each stock; nil fields returns the stocks unchanged
+func projectStocks(stocks []*domain.StockWithDetails, fields []string) interface{} {
+	if fields == nil {
+		return stocks
+	}
+
+	projected := make([]map[string]interface{}, len(stocks))
+	for i, stock := range stocks {
+		projected[i] = stock.Project(fields)
+	}
+	return projected
+}
+
+// projectedRecommendation is a recommendation whose stock only has the requested fields
+type projectedRecommendation struct {
+	*domain.StockRecommendation
+	Stock map[string]interface{} `json:"stock"`
+}
+
+// projectRecommendations keeps only the requested fields of each recommended stock
+func projectRecommendations(recommendations []*domain.StockRecommendation, fields []string) interface{} {
+	if fields == nil {
+		return recommendations
+	}
+
+	projected := make([]projectedRecommendation, len(recommendations))
+	for i, recommendation := range recommendations {
+		projected[i] = projectedRecommendation{
+			StockRecommendation: recommendation,
+			Stock:               recommendation.Stock.Project(fields),
+		}
+	}
+	return projected
+}
+
+// convertCurrency converts price targets to the currency given in the "currency" query parameter, if any.
+// It responds with an error and returns false if the conversion fails.
+func (h *StockHandler) convertCurrency(c *gin.Context, stocks []*domain.StockWithDetails) bool {
+	value := c.Query("currency")
+	if value == "" {
+		return true
+	}
+
+	currency, err := usecase.ParseCurrency(value)
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return false
+	}
+
+	if err := h.fxUC.ConvertStocks(c.Request.Context(), stocks, currency); err != nil {
+		h.logger.Error("Failed to convert currency", zap.String("currency", currency), zap.Error(err))
+		h.respondWithError(c, http.StatusInternalServerError, err)
+		return false
+	}
+
+	return true
+}
+
+// parseFloatQuery parses an optional numeric query parameter, returning nil if it is absent
+func (h *StockHandler) parseFloatQuery(c *gin.Context, key string) (*float64, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	floatValue, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s must be a number", domain.ErrInvalidInput, key)
+	}
+
+	return &floatValue, nil
+}
+
+// parseBoolQuery parses an optional boolean query parameter, returning false if it is absent
+func (h *StockHandler) parseBoolQuery(c *gin.Context, key string) (bool, error) {
+	value := c.Query(key)
+	if value == "" {
+		return false, nil
+	}
+
+	boolValue, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s must be true or false", domain.ErrInvalidInput, key)
+	}
+
+	return boolValue, nil
+}
+
 func (h *StockHandler) respondWithError(c *gin.Context, statusCode int, err error) {
 	c.JSON(statusCode, Response{
 		Success: false,
@@ -336,9 +788,83 @@ func (h *StockHandler) GetBrokerageByID(c *gin.Context) {
 	})
 }
 
+// GetBrokerageProfile godoc
+// @Summary Get a brokerage's profile
+// @Description Retrieves a brokerage with its activity over its full history (tickers covered, events by action category, upgrade/downgrade ratio, average target change, first and last event) and its most recent events
+// @Tags brokerages
+// @Accept json
+// @Produce json
+// @Param id path int true "Brokerage ID"
+// @Param recent query int false "Number of recent events to return (max 100)" default(10)
+// @Success 200 {object} Response{data=domain.BrokerageProfile}
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/v1/brokerages/{id}/profile [get]
+func (h *StockHandler) GetBrokerageProfile(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, errors.New("invalid brokerage ID"))
+		return
+	}
+
+	profile, err := h.brokerageUC.GetProfile(c.Request.Context(), id, h.parseIntQuery(c, "recent", 10))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			h.respondWithError(c, http.StatusNotFound, err)
+			return
+		}
+		h.logger.Error("Failed to get brokerage profile", zap.Int64("id", id), zap.Error(err))
+		h.respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    profile,
+	})
+}
+
+// GetBrokerageLeaderboard godoc
+// @Summary Get the brokerage activity leaderboard
+// @Description Ranks brokerages by their number of events within the window, with the tickers they covered, their upgrades and downgrades, average target change and latest event
+// @Tags brokerages
+// @Accept json
+// @Produce json
+// @Param window query string false "Start of the window, relative to now like 30d or 12w, or an RFC 3339 time or YYYY-MM-DD date" default(30d)
+// @Param limit query int false "Number of brokerages to return (max 100)" default(20)
+// @Success 200 {object} Response{data=[]domain.BrokerageLeaderboardEntry}
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/v1/brokerages/leaderboard [get]
+func (h *StockHandler) GetBrokerageLeaderboard(c *gin.Context) {
+	now := time.Now()
+	from, err := usecase.ParseTimeBound(c.DefaultQuery("window", "30d"), now)
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, fmt.Errorf("window: %w", err))
+		return
+	}
+
+	entries, err := h.brokerageUC.GetLeaderboard(c.Request.Context(), from, now, h.parseIntQuery(c, "limit", 20))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			h.respondWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		h.logger.Error("Failed to get brokerage leaderboard", zap.Error(err))
+		h.respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    entries,
+	})
+}
+
 // GetActions godoc
 // @Summary Get all actions
-// @Description Retrieves all analyst actions
+// @Description Retrieves all analyst actions with their canonical category and direction
 // @Tags actions
 // @Accept json
 // @Produce json
@@ -395,9 +921,63 @@ func (h *StockHandler) GetActionByID(c *gin.Context) {
 	})
 }
 
+// UpdateActionCategoryRequest is the body for assigning an action to a canonical category
+type UpdateActionCategoryRequest struct {
+	Category  string `json:"category" binding:"required" example:"target_raised"`
+	Direction string `json:"direction,omitempty" example:"up"`
+}
+
+// UpdateActionCategory godoc
+// @Summary Set the category of an action
+// @Description Assigns a canonical category (upgrade, downgrade, initiate, reiterate, target_raised, target_lowered, other) and a direction (up, down, neutral) to an action. The direction defaults to the category's direction.
+// @Tags actions
+// @Accept json
+// @Produce json
+// @Param id path int true "Action ID"
+// @Param request body UpdateActionCategoryRequest true "Category and optional direction"
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 401 {object} Response
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Security AdminAPIKey
+// @Router /api/v1/actions/{id} [put]
+func (h *StockHandler) UpdateActionCategory(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, errors.New("invalid action ID"))
+		return
+	}
+
+	var req UpdateActionCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	action, err := h.actionUC.UpdateCategory(c.Request.Context(), id, req.Category, req.Direction)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			h.respondWithError(c, http.StatusBadRequest, err)
+		case errors.Is(err, domain.ErrNotFound):
+			h.respondWithError(c, http.StatusNotFound, err)
+		default:
+			h.logger.Error("Failed to update action category", zap.Int64("id", id), zap.Error(err))
+			h.respondWithError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    action,
+	})
+}
+
 // GetRatings godoc
 // @Summary Get all ratings
-// @Description Retrieves all rating terms with brokerage information
+// @Description Retrieves all rating terms with their canonical bucket and score
 // @Tags ratings
 // @Accept json
 // @Produce json
@@ -453,3 +1033,263 @@ func (h *StockHandler) GetRatingByID(c *gin.Context) {
 		Data:    rating,
 	})
 }
+
+// UpdateRatingScaleRequest is the body for assigning a rating term to the canonical scale
+type UpdateRatingScaleRequest struct {
+	Bucket string   `json:"bucket" binding:"required" example:"buy"`
+	Score  *float64 `json:"score,omitempty" example:"4"`
+}
+
+// UpdateRatingScale godoc
+// @Summary Map a rating onto the canonical scale
+// @Description Assigns a canonical bucket (strong_sell, sell, hold, buy, strong_buy) and a 1-5 score to a rating term. The score defaults to the bucket's position on the scale.
+// @Tags ratings
+// @Accept json
+// @Produce json
+// @Param id path int true "Rating ID"
+// @Param request body UpdateRatingScaleRequest true "Canonical bucket and optional score"
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 401 {object} Response
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Security AdminAPIKey
+// @Router /api/v1/ratings/{id} [put]
+func (h *StockHandler) UpdateRatingScale(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, errors.New("invalid rating ID"))
+		return
+	}
+
+	var req UpdateRatingScaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	rating, err := h.ratingUC.UpdateScale(c.Request.Context(), id, req.Bucket, req.Score)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			h.respondWithError(c, http.StatusBadRequest, err)
+		case errors.Is(err, domain.ErrNotFound):
+			h.respondWithError(c, http.StatusNotFound, err)
+		default:
+			h.logger.Error("Failed to update rating scale", zap.Int64("id", id), zap.Error(err))
+			h.respondWithError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    rating,
+	})
+}
+
+// GetRatingQualityReport godoc
+// @Summary Rating data quality report
+// @Description Lists rating terms that are not mapped to a canonical bucket, with how many events use them
+// @Tags ratings
+// @Accept json
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/v1/ratings/quality [get]
+func (h *StockHandler) GetRatingQualityReport(c *gin.Context) {
+	report, err := h.ratingUC.GetQualityReport(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to get rating quality report", zap.Error(err))
+		h.respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    report,
+	})
+}
+
+// GetFXRates godoc
+// @Summary Get FX rates
+// @Description Retrieves stored FX rates (value of one unit of currency in USD per date), newest first
+// @Tags fx
+// @Accept json
+// @Produce json
+// @Param currency query string false "Only rates for this currency"
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/v1/fx-rates [get]
+func (h *StockHandler) GetFXRates(c *gin.Context) {
+	rates, err := h.fxUC.GetRates(c.Request.Context(), c.Query("currency"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			h.respondWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		h.logger.Error("Failed to get fx rates", zap.Error(err))
+		h.respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    rates,
+	})
+}
+
+// ImportFXRates godoc
+// @Summary Import FX rates
+// @Description Loads FX rates from a CSV body (Content-Type text/csv, header "date,currency,usd_rate") or a JSON array. Rates for an existing currency and date are replaced.
+// @Tags fx
+// @Accept json
+// @Accept text/csv
+// @Produce json
+// @Param request body []domain.FXRate true "FX rates"
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 401 {object} Response
+// @Failure 500 {object} Response
+// @Security AdminAPIKey
+// @Router /api/v1/fx-rates [post]
+func (h *StockHandler) ImportFXRates(c *gin.Context) {
+	var count int
+	var err error
+
+	if c.ContentType() == "text/csv" {
+		count, err = h.fxUC.ImportCSV(c.Request.Context(), c.Request.Body)
+	} else {
+		var rates []*domain.FXRate
+		if bindErr := c.ShouldBindJSON(&rates); bindErr != nil {
+			h.respondWithError(c, http.StatusBadRequest, fmt.Errorf("%w: %v", domain.ErrInvalidInput, bindErr))
+			return
+		}
+		count, err = h.fxUC.Import(c.Request.Context(), rates)
+	}
+
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			h.respondWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		h.logger.Error("Failed to import fx rates", zap.Error(err))
+		h.respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Message: "FX rates imported successfully",
+		Data: map[string]interface{}{
+			"imported_count": count,
+		},
+	})
+}
+
+// ImportPriceBars godoc
+// @Summary Import daily price bars
+// @Description Loads daily price bars from a CSV body (Content-Type text/csv, header with date, ticker and close, plus optional open, high, low, volume and currency) or a JSON array. Bars for an existing ticker and date are replaced.
+// @Tags stocks
+// @Accept json
+// @Accept text/csv
+// @Produce json
+// @Param request body []domain.PriceBar true "Price bars"
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 401 {object} Response
+// @Failure 500 {object} Response
+// @Security AdminAPIKey
+// @Router /api/v1/prices [post]
+func (h *StockHandler) ImportPriceBars(c *gin.Context) {
+	var count int
+	var err error
+
+	if c.ContentType() == "text/csv" {
+		count, err = h.priceUC.ImportCSV(c.Request.Context(), c.Request.Body)
+	} else {
+		var bars []*domain.PriceBar
+		if bindErr := c.ShouldBindJSON(&bars); bindErr != nil {
+			h.respondWithError(c, http.StatusBadRequest, fmt.Errorf("%w: %v", domain.ErrInvalidInput, bindErr))
+			return
+		}
+		count, err = h.priceUC.Import(c.Request.Context(), bars)
+	}
+
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			h.respondWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		h.logger.Error("Failed to import price bars", zap.Error(err))
+		h.respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Message: "Price bars imported successfully",
+		Data: map[string]interface{}{
+			"imported_count": count,
+		},
+	})
+}
+
+// Search godoc
+// @Summary Search tickers, companies and brokerages
+// @Description Free-text search ranked by trigram similarity. Each result names the best matching field and highlights the match with <mark> tags.
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param q query string true "Search text (at most 100 characters)"
+// @Param limit query int false "Maximum number of results (max 100)" default(20)
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/v1/search [get]
+func (h *StockHandler) Search(c *gin.Context) {
+	results, err := h.searchUC.Search(c.Request.Context(), c.Query("q"), h.parseIntQuery(c, "limit", 0))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			h.respondWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		h.respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    results,
+	})
+}
+
+// SearchSuggest godoc
+// @Summary Typeahead suggestions
+// @Description Prefix completions for search boxes: tickers first, then company and brokerage names matching at the start of a word
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param q query string true "Prefix typed so far"
+// @Param limit query int false "Maximum number of suggestions (max 25)" default(10)
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/v1/search/suggest [get]
+func (h *StockHandler) SearchSuggest(c *gin.Context) {
+	suggestions, err := h.searchUC.Suggest(c.Request.Context(), c.Query("q"), h.parseIntQuery(c, "limit", 0))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			h.respondWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		h.respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    suggestions,
+	})
+}
@@ -1,6 +1,9 @@
 package middleware
 
 import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -43,6 +46,29 @@ func Logger(logger *zap.Logger) gin.HandlerFunc {
 	}
 }
 
+// Recovery returns a middleware that turns panics into 500 responses, like gin.Recovery.
+// http.ErrAbortHandler is passed on to net/http, which aborts the connection: handlers use it
+// when a streamed response fails after it started, so the client sees a broken transfer.
+func Recovery(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				if r == http.ErrAbortHandler {
+					panic(r)
+				}
+				logger.Error("Recovered from panic",
+					zap.String("method", c.Request.Method),
+					zap.String("path", c.Request.URL.Path),
+					zap.Any("panic", r),
+					zap.Stack("stack"))
+				c.AbortWithStatus(http.StatusInternalServerError)
+			}
+		}()
+
+		c.Next()
+	}
+}
+
 // CORS returns a middleware that adds CORS headers
 func CORS() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -59,3 +85,20 @@ func CORS() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// AdminAuth returns a middleware that restricts a route to callers presenting the admin API key
+// as a bearer token. If no key is configured, admin routes are disabled.
+func AdminAuth(apiKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if apiKey == "" || subtle.ConstantTimeCompare([]byte(token), []byte(apiKey)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"error":   "unauthorized",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
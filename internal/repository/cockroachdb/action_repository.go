@@ -2,10 +2,12 @@ package cockroachdb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/company/stock-api/internal/domain"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -27,12 +29,12 @@ func (r *ActionRepository) Create(action *domain.Action) error {
 	defer cancel()
 
 	query := `
-		INSERT INTO actions (name)
-		VALUES ($1)
+		INSERT INTO actions (name, category, direction)
+		VALUES ($1, $2, $3)
 		RETURNING id, created_at, updated_at
 	`
 
-	err := r.db.QueryRow(ctx, query, action.Name).Scan(
+	err := r.db.QueryRow(ctx, query, action.Name, nullableString(string(action.Category)), nullableString(string(action.Direction))).Scan(
 		&action.ID,
 		&action.CreatedAt,
 		&action.UpdatedAt,
@@ -51,19 +53,12 @@ func (r *ActionRepository) FindByID(id int64) (*domain.Action, error) {
 	defer cancel()
 
 	query := `
-		SELECT id, name, created_at, updated_at
+		SELECT id, name, category, direction, created_at, updated_at
 		FROM actions
 		WHERE id = $1
 	`
 
-	action := &domain.Action{}
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&action.ID,
-		&action.Name,
-		&action.CreatedAt,
-		&action.UpdatedAt,
-	)
-
+	action, err := scanAction(r.db.QueryRow(ctx, query, id))
 	if err != nil {
 		return nil, fmt.Errorf("failed to find action: %w", err)
 	}
@@ -77,19 +72,12 @@ func (r *ActionRepository) FindByName(name string) (*domain.Action, error) {
 	defer cancel()
 
 	query := `
-		SELECT id, name, created_at, updated_at
+		SELECT id, name, category, direction, created_at, updated_at
 		FROM actions
 		WHERE name = $1
 	`
 
-	action := &domain.Action{}
-	err := r.db.QueryRow(ctx, query, name).Scan(
-		&action.ID,
-		&action.Name,
-		&action.CreatedAt,
-		&action.UpdatedAt,
-	)
-
+	action, err := scanAction(r.db.QueryRow(ctx, query, name))
 	if err != nil {
 		return nil, fmt.Errorf("failed to find action by name: %w", err)
 	}
@@ -99,16 +87,37 @@ func (r *ActionRepository) FindByName(name string) (*domain.Action, error) {
 
 // FindAll retrieves all actions
 func (r *ActionRepository) FindAll(ctx context.Context) ([]*domain.Action, error) {
-	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
+	query := `
+		SELECT id, name, category, direction, created_at, updated_at
+		FROM actions
+		ORDER BY name ASC
+	`
+
+	return r.query(ctx, query)
+}
+
+// FindByIDs retrieves the actions with the given IDs in a single query; unknown IDs are skipped
+func (r *ActionRepository) FindByIDs(ctx context.Context, ids []int64) ([]*domain.Action, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
 
 	query := `
-		SELECT id, name, created_at, updated_at
+		SELECT id, name, category, direction, created_at, updated_at
 		FROM actions
+		WHERE id = ANY($1)
 		ORDER BY name ASC
 	`
 
-	rows, err := r.db.Query(queryCtx, query)
+	return r.query(ctx, query, ids)
+}
+
+// query runs a SELECT over actions and scans the rows
+func (r *ActionRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.Action, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(queryCtx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query actions: %w", err)
 	}
@@ -116,13 +125,7 @@ func (r *ActionRepository) FindAll(ctx context.Context) ([]*domain.Action, error
 
 	var actions []*domain.Action
 	for rows.Next() {
-		action := &domain.Action{}
-		err := rows.Scan(
-			&action.ID,
-			&action.Name,
-			&action.CreatedAt,
-			&action.UpdatedAt,
-		)
+		action, err := scanAction(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan action: %w", err)
 		}
@@ -135,3 +138,54 @@ func (r *ActionRepository) FindAll(ctx context.Context) ([]*domain.Action, error
 
 	return actions, nil
 }
+
+// UpdateCategory sets the category and direction of an action
+func (r *ActionRepository) UpdateCategory(ctx context.Context, action *domain.Action) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE actions
+		SET category = $2, direction = $3, updated_at = NOW()
+		WHERE id = $1
+		RETURNING name, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(queryCtx, query, action.ID, string(action.Category), string(action.Direction)).Scan(
+		&action.Name,
+		&action.CreatedAt,
+		&action.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to update action category: %w", err)
+	}
+
+	return nil
+}
+
+// scanAction scans an action row with nullable category and direction
+func scanAction(row pgx.Row) (*domain.Action, error) {
+	action := &domain.Action{}
+	var category, direction *string
+
+	err := row.Scan(
+		&action.ID,
+		&action.Name,
+		&category,
+		&direction,
+		&action.CreatedAt,
+		&action.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	action.Category = domain.ActionCategory(getStringValue(category))
+	action.Direction = domain.ActionDirection(getStringValue(direction))
+
+	return action, nil
+}
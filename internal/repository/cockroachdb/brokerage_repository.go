@@ -2,10 +2,12 @@ package cockroachdb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/company/stock-api/internal/domain"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -65,6 +67,9 @@ func (r *BrokerageRepository) FindByID(id int64) (*domain.Brokerage, error) {
 	)
 
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
 		return nil, fmt.Errorf("failed to find brokerage: %w", err)
 	}
 
@@ -99,16 +104,37 @@ func (r *BrokerageRepository) FindByName(name string) (*domain.Brokerage, error)
 
 // FindAll retrieves all brokerages
 func (r *BrokerageRepository) FindAll(ctx context.Context) ([]*domain.Brokerage, error) {
-	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
+	query := `
+		SELECT id, name, created_at, updated_at
+		FROM brokerages
+		ORDER BY name ASC
+	`
+
+	return r.query(ctx, query)
+}
+
+// FindByIDs retrieves the brokerages with the given IDs in a single query; unknown IDs are skipped
+func (r *BrokerageRepository) FindByIDs(ctx context.Context, ids []int64) ([]*domain.Brokerage, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
 
 	query := `
 		SELECT id, name, created_at, updated_at
 		FROM brokerages
+		WHERE id = ANY($1)
 		ORDER BY name ASC
 	`
 
-	rows, err := r.db.Query(queryCtx, query)
+	return r.query(ctx, query, ids)
+}
+
+// query runs a SELECT over brokerages and scans the rows
+func (r *BrokerageRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.Brokerage, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(queryCtx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query brokerages: %w", err)
 	}
@@ -135,3 +161,143 @@ func (r *BrokerageRepository) FindAll(ctx context.Context) ([]*domain.Brokerage,
 
 	return brokerages, nil
 }
+
+// brokerageTargetChangePct is the percentage change from target_from to target_to of a stocks row s
+const brokerageTargetChangePct = `CASE WHEN s.target_from_amount > 0 THEN (s.target_to_amount - s.target_from_amount) / s.target_from_amount * 100 END`
+
+// FindActivity summarizes all stock events of a brokerage, using idx_stocks_brokerage_id
+func (r *BrokerageRepository) FindActivity(ctx context.Context, id int64) (*domain.BrokerageActivity, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	activity := &domain.BrokerageActivity{EventsByCategory: map[domain.ActionCategory]int64{}}
+	for _, category := range domain.ActionCategories {
+		activity.EventsByCategory[category] = 0
+	}
+
+	query := `
+		SELECT COUNT(*), COUNT(DISTINCT s.ticker), AVG(` + brokerageTargetChangePct + `)::FLOAT8, MIN(s.time), MAX(s.time)
+		FROM stocks s
+		WHERE s.brokerage_id = $1
+	`
+	err := r.db.QueryRow(queryCtx, query, id).Scan(
+		&activity.Events,
+		&activity.TickersCovered,
+		&activity.AvgTargetChangePct,
+		&activity.FirstSeen,
+		&activity.LastSeen,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query brokerage activity: %w", err)
+	}
+
+	rows, err := r.db.Query(queryCtx, `
+		SELECT COALESCE(a.category, $2), COUNT(*)
+		FROM stocks s
+		LEFT JOIN actions a ON s.action_id = a.id
+		WHERE s.brokerage_id = $1
+		GROUP BY 1
+	`, id, string(domain.ActionCategoryOther))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query brokerage events by category: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var category string
+		var count int64
+		if err := rows.Scan(&category, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan brokerage events by category: %w", err)
+		}
+		activity.EventsByCategory[domain.ActionCategory(category)] += count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating brokerage events by category: %w", err)
+	}
+
+	return activity, nil
+}
+
+// FindRecentEvents retrieves the latest stock events of a brokerage with all joined details, newest first
+func (r *BrokerageRepository) FindRecentEvents(ctx context.Context, id int64, limit int) ([]*domain.StockWithDetails, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(queryCtx, stockDetailsSelect+` WHERE s.brokerage_id = $1 ORDER BY s.time DESC, s.id DESC LIMIT $2`, id, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query brokerage events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*domain.StockWithDetails{}
+	for rows.Next() {
+		stock, err := scanStockWithDetails(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stock: %w", err)
+		}
+		events = append(events, stock)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating brokerage events: %w", err)
+	}
+
+	return events, nil
+}
+
+// FindLeaderboard ranks brokerages by their number of events between from and to; ties go to the
+// brokerage covering more tickers, then by name
+func (r *BrokerageRepository) FindLeaderboard(ctx context.Context, from, to time.Time, limit int) ([]*domain.BrokerageLeaderboardEntry, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT b.id, b.name,
+			COUNT(*) AS events,
+			COUNT(DISTINCT s.ticker) AS tickers_covered,
+			COUNT(CASE WHEN a.category = $3 THEN 1 END) AS upgrades,
+			COUNT(CASE WHEN a.category = $4 THEN 1 END) AS downgrades,
+			AVG(` + brokerageTargetChangePct + `)::FLOAT8,
+			MAX(s.time)
+		FROM stocks s
+		JOIN brokerages b ON s.brokerage_id = b.id
+		LEFT JOIN actions a ON s.action_id = a.id
+		WHERE s.time >= $1 AND s.time <= $2
+		GROUP BY b.id, b.name
+		ORDER BY events DESC, tickers_covered DESC, b.name ASC
+		LIMIT $5
+	`
+
+	rows, err := r.db.Query(queryCtx, query, from, to,
+		string(domain.ActionCategoryUpgrade), string(domain.ActionCategoryDowngrade), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query brokerage leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*domain.BrokerageLeaderboardEntry{}
+	for rows.Next() {
+		entry := &domain.BrokerageLeaderboardEntry{Rank: len(entries) + 1}
+		err := rows.Scan(
+			&entry.BrokerageID,
+			&entry.Name,
+			&entry.Events,
+			&entry.TickersCovered,
+			&entry.Upgrades,
+			&entry.Downgrades,
+			&entry.AvgTargetChangePct,
+			&entry.LastSeen,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan brokerage leaderboard entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating brokerage leaderboard: %w", err)
+	}
+
+	return entries, nil
+}
@@ -6,11 +6,13 @@ import (
 	"time"
 
 	"github.com/company/stock-api/internal/config"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-// NewConnection creates a new database connection pool
-func NewConnection(cfg *config.DatabaseConfig) (*pgxpool.Pool, error) {
+// NewConnection creates a new database connection pool.
+// similarityThreshold sets pg_trgm.similarity_threshold on every connection, which the % operator uses.
+func NewConnection(cfg *config.DatabaseConfig, similarityThreshold float64) (*pgxpool.Pool, error) {
 	poolConfig, err := pgxpool.ParseConfig(cfg.GetDSN())
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse database config: %w", err)
@@ -21,6 +23,11 @@ func NewConnection(cfg *config.DatabaseConfig) (*pgxpool.Pool, error) {
 	poolConfig.MinConns = int32(cfg.MinConns)
 	poolConfig.MaxConnLifetime = cfg.MaxConnLifetime
 	poolConfig.MaxConnIdleTime = cfg.MaxConnIdleTime
+	poolConfig.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+		// SET does not accept placeholders; the value is a validated float
+		_, err := conn.Exec(ctx, fmt.Sprintf("SET pg_trgm.similarity_threshold = %g", similarityThreshold))
+		return err
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -99,10 +106,51 @@ func InitSchema(db *pgxpool.Pool) error {
 		CREATE INDEX IF NOT EXISTS idx_stocks_time ON stocks(time DESC);
 		CREATE INDEX IF NOT EXISTS idx_stocks_brokerage_id ON stocks(brokerage_id);
 		CREATE INDEX IF NOT EXISTS idx_stocks_action_id ON stocks(action_id);
+		CREATE INDEX IF NOT EXISTS idx_stocks_created_at ON stocks(created_at);
 
 		-- Trigram indexes for fuzzy search
 		CREATE INDEX IF NOT EXISTS idx_stocks_company_trgm ON stocks USING GIN (company gin_trgm_ops);
 		CREATE INDEX IF NOT EXISTS idx_brokerages_name_trgm ON brokerages USING GIN (name gin_trgm_ops);
+
+		-- Canonical rating scale (NULL bucket means the term is not mapped yet)
+		ALTER TABLE ratings ADD COLUMN IF NOT EXISTS bucket VARCHAR(20);
+		ALTER TABLE ratings ADD COLUMN IF NOT EXISTS score FLOAT8;
+
+		-- Action taxonomy (NULL category means the action has not been classified yet)
+		ALTER TABLE actions ADD COLUMN IF NOT EXISTS category VARCHAR(20);
+		ALTER TABLE actions ADD COLUMN IF NOT EXISTS direction VARCHAR(10);
+
+		-- Numeric price targets parsed from target_from/target_to
+		ALTER TABLE stocks ADD COLUMN IF NOT EXISTS target_from_amount DECIMAL(18,4);
+		ALTER TABLE stocks ADD COLUMN IF NOT EXISTS target_to_amount DECIMAL(18,4);
+		ALTER TABLE stocks ADD COLUMN IF NOT EXISTS target_currency VARCHAR(3);
+		-- Set once the raw targets were parsed, so unparseable targets are not retried on every startup
+		ALTER TABLE stocks ADD COLUMN IF NOT EXISTS target_parsed_at TIMESTAMPTZ;
+
+		-- FX rates: value of one unit of currency in USD on a given date
+		CREATE TABLE IF NOT EXISTS fx_rates (
+			currency VARCHAR(3) NOT NULL,
+			rate_date DATE NOT NULL,
+			usd_rate DECIMAL(18,8) NOT NULL,
+			created_at TIMESTAMP DEFAULT NOW(),
+			updated_at TIMESTAMP DEFAULT NOW(),
+			PRIMARY KEY (currency, rate_date)
+		);
+
+		-- Daily price bars per ticker, used for implied upside versus the last close
+		CREATE TABLE IF NOT EXISTS price_bars (
+			ticker VARCHAR(20) NOT NULL,
+			bar_date DATE NOT NULL,
+			open DECIMAL(18,4) NOT NULL,
+			high DECIMAL(18,4) NOT NULL,
+			low DECIMAL(18,4) NOT NULL,
+			close DECIMAL(18,4) NOT NULL,
+			volume INT8 NOT NULL DEFAULT 0,
+			currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+			created_at TIMESTAMP DEFAULT NOW(),
+			updated_at TIMESTAMP DEFAULT NOW(),
+			PRIMARY KEY (ticker, bar_date)
+		);
 	`
 
 	_, err := db.Exec(ctx, schema)
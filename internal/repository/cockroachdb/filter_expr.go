@@ -0,0 +1,152 @@
+package cockroachdb
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+)
+
+// filterExprColumns maps filter expression fields to view_stocks columns or expressions
+var filterExprColumns = map[string]string{
+	"ticker":             "ticker",
+	"company":            "company",
+	"brokerage":          "brokerage_name",
+	"action":             "action_name",
+	"action_category":    "action_category",
+	"action_direction":   "action_direction",
+	"rating_from":        "rating_from_term",
+	"rating_to":          "rating_to_term",
+	"rating_from_bucket": "rating_from_bucket",
+	"rating_to_bucket":   "rating_to_bucket",
+	"rating_from_score":  "rating_from_score",
+	"rating_to_score":    "rating_to_score",
+	"target_from":        "target_from_amount",
+	"target_to":          "target_to_amount",
+	"target_change_pct":  "(CASE WHEN target_from_amount > 0 THEN (target_to_amount - target_from_amount) / target_from_amount * 100 END)",
+	"currency":           "target_currency",
+	"time":               "time",
+}
+
+// likeEscaper escapes LIKE wildcards so "~" always matches a literal substring
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// compileFilterExpr translates a parsed filter expression into a parameterized SQL condition.
+// Every comparison evaluates to true or false, never NULL, so "not" and negated operators keep
+// rows where the field is NULL, consistent with the not_<field> query parameters.
+func compileFilterExpr(expr domain.FilterExpr, args []interface{}, argPos int) (string, []interface{}, int, error) {
+	switch e := expr.(type) {
+	case domain.FilterAnd:
+		return compileFilterBinary("AND", e.Left, e.Right, args, argPos)
+
+	case domain.FilterOr:
+		return compileFilterBinary("OR", e.Left, e.Right, args, argPos)
+
+	case domain.FilterNot:
+		sql, args, argPos, err := compileFilterExpr(e.Expr, args, argPos)
+		if err != nil {
+			return "", args, argPos, err
+		}
+		return "(NOT " + sql + ")", args, argPos, nil
+
+	case domain.FilterComparison:
+		return compileFilterComparison(e, args, argPos)
+
+	default:
+		return "", args, argPos, fmt.Errorf("unsupported filter expression %T", expr)
+	}
+}
+
+func compileFilterBinary(op string, left, right domain.FilterExpr, args []interface{}, argPos int) (string, []interface{}, int, error) {
+	leftSQL, args, argPos, err := compileFilterExpr(left, args, argPos)
+	if err != nil {
+		return "", args, argPos, err
+	}
+
+	rightSQL, args, argPos, err := compileFilterExpr(right, args, argPos)
+	if err != nil {
+		return "", args, argPos, err
+	}
+
+	return "(" + leftSQL + " " + op + " " + rightSQL + ")", args, argPos, nil
+}
+
+func compileFilterComparison(c domain.FilterComparison, args []interface{}, argPos int) (string, []interface{}, int, error) {
+	column, ok := filterExprColumns[c.Field]
+	if !ok {
+		return "", args, argPos, fmt.Errorf("%w: unknown filter field %q", domain.ErrInvalidInput, c.Field)
+	}
+	if len(c.Values) == 0 {
+		return "", args, argPos, fmt.Errorf("%w: no value for filter field %q", domain.ErrInvalidInput, c.Field)
+	}
+
+	var condition string
+	switch c.Op {
+	case domain.FilterOpEq, domain.FilterOpNotEq:
+		condition = fmt.Sprintf("%s = $%d", column, argPos)
+		args = append(args, c.Values[0])
+
+	case domain.FilterOpGt, domain.FilterOpGte, domain.FilterOpLt, domain.FilterOpLte:
+		condition = fmt.Sprintf("%s %s $%d", column, c.Op, argPos)
+		args = append(args, c.Values[0])
+
+	case domain.FilterOpContains, domain.FilterOpNotContains:
+		value, ok := c.Values[0].(string)
+		if !ok {
+			return "", args, argPos, fmt.Errorf("%w: %q requires a string value", domain.ErrInvalidInput, c.Op)
+		}
+		condition = fmt.Sprintf("%s ILIKE $%d", column, argPos)
+		args = append(args, "%"+likeEscaper.Replace(value)+"%")
+
+	case domain.FilterOpIn, domain.FilterOpNotIn:
+		list, err := filterValueList(c.Values)
+		if err != nil {
+			return "", args, argPos, err
+		}
+		condition = fmt.Sprintf("%s = ANY($%d)", column, argPos)
+		args = append(args, list)
+
+	default:
+		return "", args, argPos, fmt.Errorf("%w: unsupported filter operator %q", domain.ErrInvalidInput, c.Op)
+	}
+	argPos++
+
+	if c.Op.Negated() {
+		return "(NOT COALESCE(" + condition + ", false))", args, argPos, nil
+	}
+	return "COALESCE(" + condition + ", false)", args, argPos, nil
+}
+
+// filterValueList converts "in" values to a typed slice so pgx can encode it as an array
+func filterValueList(values []interface{}) (interface{}, error) {
+	switch values[0].(type) {
+	case string:
+		list := make([]string, 0, len(values))
+		for _, v := range values {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: mixed value types in list", domain.ErrInvalidInput)
+			}
+			list = append(list, s)
+		}
+		return list, nil
+
+	case float64:
+		list := make([]float64, 0, len(values))
+		for _, v := range values {
+			f, ok := v.(float64)
+			if !ok {
+				return nil, fmt.Errorf("%w: mixed value types in list", domain.ErrInvalidInput)
+			}
+			list = append(list, f)
+		}
+		return list, nil
+
+	case time.Time:
+		return nil, fmt.Errorf("%w: time values cannot be used in lists", domain.ErrInvalidInput)
+
+	default:
+		return nil, fmt.Errorf("%w: unsupported value type %T", domain.ErrInvalidInput, values[0])
+	}
+}
@@ -0,0 +1,130 @@
+package cockroachdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// FXRateRepository implements domain.FXRateRepository for CockroachDB
+type FXRateRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewFXRateRepository creates a new instance of FXRateRepository
+func NewFXRateRepository(db *pgxpool.Pool) *FXRateRepository {
+	return &FXRateRepository{
+		db: db,
+	}
+}
+
+// Upsert inserts FX rates in a single transaction, replacing existing rates for the same currency and date
+func (r *FXRateRepository) Upsert(ctx context.Context, rates []*domain.FXRate) error {
+	if len(rates) == 0 {
+		return nil
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	tx, err := r.db.Begin(queryCtx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(queryCtx)
+
+	query := `
+		INSERT INTO fx_rates (currency, rate_date, usd_rate)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (currency, rate_date) DO UPDATE
+		SET usd_rate = excluded.usd_rate, updated_at = NOW()
+		RETURNING created_at, updated_at
+	`
+
+	for _, rate := range rates {
+		err := tx.QueryRow(queryCtx, query, rate.Currency, rate.Date, rate.USDRate).Scan(
+			&rate.CreatedAt,
+			&rate.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to upsert fx rate: %w", err)
+		}
+	}
+
+	if err := tx.Commit(queryCtx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FindAll retrieves all FX rates, optionally for a single currency, newest first
+func (r *FXRateRepository) FindAll(ctx context.Context, currency string) ([]*domain.FXRate, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT currency, rate_date, usd_rate, created_at, updated_at
+		FROM fx_rates
+		WHERE $1 = '' OR currency = $1
+		ORDER BY currency ASC, rate_date DESC
+	`
+
+	rows, err := r.db.Query(queryCtx, query, currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fx rates: %w", err)
+	}
+
+	return collectFXRates(rows)
+}
+
+// FindUpTo retrieves the rates of the given currencies dated on or before until, oldest first
+func (r *FXRateRepository) FindUpTo(ctx context.Context, currencies []string, until time.Time) ([]*domain.FXRate, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT currency, rate_date, usd_rate, created_at, updated_at
+		FROM fx_rates
+		WHERE currency = ANY($1) AND rate_date <= $2
+		ORDER BY currency ASC, rate_date ASC
+	`
+
+	rows, err := r.db.Query(queryCtx, query, currencies, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fx rates: %w", err)
+	}
+
+	return collectFXRates(rows)
+}
+
+// collectFXRates scans and closes FX rate rows
+func collectFXRates(rows pgx.Rows) ([]*domain.FXRate, error) {
+	defer rows.Close()
+
+	rates := []*domain.FXRate{}
+	for rows.Next() {
+		rate := &domain.FXRate{}
+		err := rows.Scan(
+			&rate.Currency,
+			&rate.Date,
+			&rate.USDRate,
+			&rate.CreatedAt,
+			&rate.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan fx rate: %w", err)
+		}
+		rates = append(rates, rate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating fx rates: %w", err)
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,143 @@
+package cockroachdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PriceBarRepository implements domain.PriceBarRepository for CockroachDB
+type PriceBarRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPriceBarRepository creates a new instance of PriceBarRepository
+func NewPriceBarRepository(db *pgxpool.Pool) *PriceBarRepository {
+	return &PriceBarRepository{
+		db: db,
+	}
+}
+
+// Upsert inserts price bars in a single transaction, replacing existing bars for the same ticker and date
+func (r *PriceBarRepository) Upsert(ctx context.Context, bars []*domain.PriceBar) error {
+	if len(bars) == 0 {
+		return nil
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	tx, err := r.db.Begin(queryCtx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(queryCtx)
+
+	query := `
+		INSERT INTO price_bars (ticker, bar_date, open, high, low, close, volume, currency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (ticker, bar_date) DO UPDATE
+		SET open = excluded.open, high = excluded.high, low = excluded.low, close = excluded.close,
+			volume = excluded.volume, currency = excluded.currency, updated_at = NOW()
+		RETURNING created_at, updated_at
+	`
+
+	for _, bar := range bars {
+		err := tx.QueryRow(queryCtx, query,
+			bar.Ticker, bar.Date, bar.Open, bar.High, bar.Low, bar.Close, bar.Volume, bar.Currency,
+		).Scan(
+			&bar.CreatedAt,
+			&bar.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to upsert price bar: %w", err)
+		}
+	}
+
+	if err := tx.Commit(queryCtx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FindByTicker retrieves the bars of a ticker within the time range, newest first
+func (r *PriceBarRepository) FindByTicker(ctx context.Context, ticker string, timeRange domain.TimeRange, limit int) ([]*domain.PriceBar, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT ticker, bar_date, open, high, low, close, volume, currency, created_at, updated_at
+		FROM price_bars
+		WHERE ticker = $1
+			AND ($2::TIMESTAMPTZ IS NULL OR bar_date >= $2::TIMESTAMPTZ::DATE)
+			AND ($3::TIMESTAMPTZ IS NULL OR bar_date <= $3::TIMESTAMPTZ::DATE)
+		ORDER BY bar_date DESC
+		LIMIT $4
+	`
+
+	rows, err := r.db.Query(queryCtx, query, ticker, timeRange.From, timeRange.To, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query price bars: %w", err)
+	}
+
+	return collectPriceBars(rows)
+}
+
+// FindLatest retrieves the latest bar of each ticker dated from from's date up to the day before until.
+// A bar dated on until's day is left out: its close is not known yet at until.
+func (r *PriceBarRepository) FindLatest(ctx context.Context, tickers []string, from, until time.Time) ([]*domain.PriceBar, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT DISTINCT ON (ticker)
+			ticker, bar_date, open, high, low, close, volume, currency, created_at, updated_at
+		FROM price_bars
+		WHERE ticker = ANY($1) AND bar_date >= $2::DATE AND bar_date < $3::DATE
+		ORDER BY ticker ASC, bar_date DESC
+	`
+
+	rows, err := r.db.Query(queryCtx, query, tickers, from, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest price bars: %w", err)
+	}
+
+	return collectPriceBars(rows)
+}
+
+// collectPriceBars scans and closes price bar rows
+func collectPriceBars(rows pgx.Rows) ([]*domain.PriceBar, error) {
+	defer rows.Close()
+
+	bars := []*domain.PriceBar{}
+	for rows.Next() {
+		bar := &domain.PriceBar{}
+		err := rows.Scan(
+			&bar.Ticker,
+			&bar.Date,
+			&bar.Open,
+			&bar.High,
+			&bar.Low,
+			&bar.Close,
+			&bar.Volume,
+			&bar.Currency,
+			&bar.CreatedAt,
+			&bar.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan price bar: %w", err)
+		}
+		bars = append(bars, bar)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating price bars: %w", err)
+	}
+
+	return bars, nil
+}
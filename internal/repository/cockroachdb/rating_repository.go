@@ -2,10 +2,12 @@ package cockroachdb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/company/stock-api/internal/domain"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -21,18 +23,18 @@ func NewRatingRepository(db *pgxpool.Pool) *RatingRepository {
 	}
 }
 
-// Create inserts a new rating record (term-only)
+// Create inserts a new rating record along with its canonical scale, if known
 func (r *RatingRepository) Create(rating *domain.Rating) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	query := `
-		INSERT INTO ratings (term)
-		VALUES ($1)
+		INSERT INTO ratings (term, bucket, score)
+		VALUES ($1, $2, $3)
 		RETURNING id, created_at, updated_at
 	`
 
-	err := r.db.QueryRow(ctx, query, rating.Term).Scan(
+	err := r.db.QueryRow(ctx, query, rating.Term, nullableString(string(rating.Bucket)), rating.Score).Scan(
 		&rating.ID,
 		&rating.CreatedAt,
 		&rating.UpdatedAt,
@@ -51,15 +53,18 @@ func (r *RatingRepository) FindByID(id int64) (*domain.Rating, error) {
 	defer cancel()
 
 	query := `
-		SELECT id, term, created_at, updated_at
+		SELECT id, term, bucket, score, created_at, updated_at
 		FROM ratings
 		WHERE id = $1
 	`
 
 	rating := &domain.Rating{}
+	var bucket *string
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&rating.ID,
 		&rating.Term,
+		&bucket,
+		&rating.Score,
 		&rating.CreatedAt,
 		&rating.UpdatedAt,
 	)
@@ -67,6 +72,7 @@ func (r *RatingRepository) FindByID(id int64) (*domain.Rating, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to find rating: %w", err)
 	}
+	rating.Bucket = domain.RatingBucket(getStringValue(bucket))
 
 	return rating, nil
 }
@@ -77,15 +83,18 @@ func (r *RatingRepository) FindByTerm(term string) (*domain.Rating, error) {
 	defer cancel()
 
 	query := `
-		SELECT id, term, created_at, updated_at
+		SELECT id, term, bucket, score, created_at, updated_at
 		FROM ratings
 		WHERE term = $1
 	`
 
 	rating := &domain.Rating{}
+	var bucket *string
 	err := r.db.QueryRow(ctx, query, term).Scan(
 		&rating.ID,
 		&rating.Term,
+		&bucket,
+		&rating.Score,
 		&rating.CreatedAt,
 		&rating.UpdatedAt,
 	)
@@ -93,22 +102,44 @@ func (r *RatingRepository) FindByTerm(term string) (*domain.Rating, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to find rating by term: %w", err)
 	}
+	rating.Bucket = domain.RatingBucket(getStringValue(bucket))
 
 	return rating, nil
 }
 
 // FindAll retrieves all ratings
 func (r *RatingRepository) FindAll(ctx context.Context) ([]*domain.Rating, error) {
-	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
+	query := `
+		SELECT id, term, bucket, score, created_at, updated_at
+		FROM ratings
+		ORDER BY term ASC
+	`
+
+	return r.query(ctx, query)
+}
+
+// FindByIDs retrieves the ratings with the given IDs in a single query; unknown IDs are skipped
+func (r *RatingRepository) FindByIDs(ctx context.Context, ids []int64) ([]*domain.Rating, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
 
 	query := `
-		SELECT id, term, created_at, updated_at
+		SELECT id, term, bucket, score, created_at, updated_at
 		FROM ratings
+		WHERE id = ANY($1)
 		ORDER BY term ASC
 	`
 
-	rows, err := r.db.Query(queryCtx, query)
+	return r.query(ctx, query, ids)
+}
+
+// query runs a SELECT over ratings and scans the rows
+func (r *RatingRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.Rating, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(queryCtx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query ratings: %w", err)
 	}
@@ -117,15 +148,19 @@ func (r *RatingRepository) FindAll(ctx context.Context) ([]*domain.Rating, error
 	var ratings []*domain.Rating
 	for rows.Next() {
 		rating := &domain.Rating{}
+		var bucket *string
 		err := rows.Scan(
 			&rating.ID,
 			&rating.Term,
+			&bucket,
+			&rating.Score,
 			&rating.CreatedAt,
 			&rating.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan rating: %w", err)
 		}
+		rating.Bucket = domain.RatingBucket(getStringValue(bucket))
 		ratings = append(ratings, rating)
 	}
 
@@ -136,4 +171,66 @@ func (r *RatingRepository) FindAll(ctx context.Context) ([]*domain.Rating, error
 	return ratings, nil
 }
 
-// (previous brokerage-scoped FindAll removed; ratings are global now)
+// FindUnmapped retrieves ratings without a canonical bucket, most used first
+func (r *RatingRepository) FindUnmapped(ctx context.Context) ([]*domain.UnmappedRating, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT r.id, r.term, COUNT(s.id) AS usage_count
+		FROM ratings r
+		LEFT JOIN stocks s ON s.rating_from_id = r.id OR s.rating_to_id = r.id
+		WHERE r.bucket IS NULL
+		GROUP BY r.id, r.term
+		ORDER BY usage_count DESC, r.term ASC
+	`
+
+	rows, err := r.db.Query(queryCtx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unmapped ratings: %w", err)
+	}
+	defer rows.Close()
+
+	unmapped := []*domain.UnmappedRating{}
+	for rows.Next() {
+		rating := &domain.UnmappedRating{}
+		if err := rows.Scan(&rating.ID, &rating.Term, &rating.UsageCount); err != nil {
+			return nil, fmt.Errorf("failed to scan unmapped rating: %w", err)
+		}
+		unmapped = append(unmapped, rating)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating unmapped ratings: %w", err)
+	}
+
+	return unmapped, nil
+}
+
+// UpdateScale sets the canonical bucket and score of a rating
+func (r *RatingRepository) UpdateScale(ctx context.Context, rating *domain.Rating) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE ratings
+		SET bucket = $2, score = $3, updated_at = NOW()
+		WHERE id = $1
+		RETURNING term, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(queryCtx, query, rating.ID, nullableString(string(rating.Bucket)), rating.Score).Scan(
+		&rating.Term,
+		&rating.CreatedAt,
+		&rating.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to update rating scale: %w", err)
+	}
+
+	return nil
+}
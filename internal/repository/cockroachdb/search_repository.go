@@ -0,0 +1,146 @@
+package cockroachdb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SearchRepository implements domain.SearchRepository for CockroachDB
+type SearchRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewSearchRepository creates a new instance of SearchRepository
+func NewSearchRepository(db *pgxpool.Pool) *SearchRepository {
+	return &SearchRepository{
+		db: db,
+	}
+}
+
+// Search ranks tickers, company names and brokerages by trigram similarity to query.
+// Substring matches are always included; other matches need a similarity of at least threshold.
+func (r *SearchRepository) Search(ctx context.Context, query string, threshold float64, limit int) ([]*domain.SearchResult, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	sql := `
+		WITH tickers AS (
+			SELECT DISTINCT ON (ticker) ticker, company
+			FROM stocks
+			ORDER BY ticker, time DESC
+		), stock_matches AS (
+			SELECT ticker, company,
+				CASE WHEN ticker = UPPER($1) THEN 1.0::FLOAT8 ELSE similarity(ticker, $1)::FLOAT8 END AS ticker_score,
+				CASE WHEN LOWER(company) = LOWER($1) THEN 1.0::FLOAT8 ELSE similarity(company, $1)::FLOAT8 END AS company_score
+			FROM tickers
+			WHERE ticker ILIKE $2 OR company ILIKE $2
+				OR similarity(ticker, $1) >= $3 OR similarity(company, $1) >= $3
+		)
+		SELECT type, ticker, company, brokerage_id, brokerage, matched_field, score
+		FROM (
+			SELECT 'stock' AS type, ticker, company, 0::INT8 AS brokerage_id, ''::STRING AS brokerage,
+				CASE WHEN ticker_score >= company_score THEN 'ticker' ELSE 'company' END AS matched_field,
+				GREATEST(ticker_score, company_score) AS score
+			FROM stock_matches
+			UNION ALL
+			SELECT 'brokerage', ''::STRING, ''::STRING, id::INT8, name, 'brokerage',
+				CASE WHEN LOWER(name) = LOWER($1) THEN 1.0::FLOAT8 ELSE similarity(name, $1)::FLOAT8 END
+			FROM brokerages
+			WHERE name ILIKE $2 OR similarity(name, $1) >= $3
+		) results
+		ORDER BY score DESC, ticker, brokerage
+		LIMIT $4
+	`
+
+	rows, err := r.db.Query(queryCtx, sql, query, "%"+likeEscaper.Replace(query)+"%", threshold, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+	defer rows.Close()
+
+	results := []*domain.SearchResult{}
+	for rows.Next() {
+		result := &domain.SearchResult{}
+		var resultType string
+		if err := rows.Scan(
+			&resultType,
+			&result.Ticker,
+			&result.Company,
+			&result.BrokerageID,
+			&result.Brokerage,
+			&result.MatchedField,
+			&result.Score,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		result.Type = domain.SearchResultType(resultType)
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating search results: %w", err)
+	}
+
+	return results, nil
+}
+
+// Suggest returns prefix completions: tickers first, then company names and brokerages.
+// Company and brokerage names also match at the start of any word.
+func (r *SearchRepository) Suggest(ctx context.Context, prefix string, limit int) ([]*domain.SearchSuggestion, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	escaped := likeEscaper.Replace(prefix)
+
+	// Tickers are stored upper-case, so LIKE on the upper-cased prefix can use idx_stocks_ticker
+	sql := `
+		SELECT field, value, ticker
+		FROM (
+			(SELECT DISTINCT 'ticker' AS field, ticker AS value, ticker, 1 AS priority
+			FROM stocks
+			WHERE ticker LIKE $1
+			ORDER BY value
+			LIMIT $4)
+			UNION ALL
+			(SELECT DISTINCT ON (company) 'company', company, ticker, 2
+			FROM stocks
+			WHERE company ILIKE $2 OR company ILIKE $3
+			ORDER BY company, time DESC
+			LIMIT $4)
+			UNION ALL
+			(SELECT 'brokerage', name, ''::STRING, 3
+			FROM brokerages
+			WHERE name ILIKE $2 OR name ILIKE $3
+			ORDER BY name
+			LIMIT $4)
+		) suggestions
+		ORDER BY priority, LENGTH(value), value
+		LIMIT $4
+	`
+
+	rows, err := r.db.Query(queryCtx, sql, strings.ToUpper(escaped)+"%", escaped+"%", "% "+escaped+"%", limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	suggestions := []*domain.SearchSuggestion{}
+	for rows.Next() {
+		suggestion := &domain.SearchSuggestion{}
+		if err := rows.Scan(&suggestion.Field, &suggestion.Value, &suggestion.Ticker); err != nil {
+			return nil, fmt.Errorf("failed to scan suggestion: %w", err)
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating suggestions: %w", err)
+	}
+
+	return suggestions, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/company/stock-api/internal/domain"
@@ -27,6 +28,327 @@ func getStringValue(s *string) string {
 	return *s
 }
 
+// nullableString converts an empty string to NULL
+func nullableString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// stockDetailsSelect selects a stock joined with its action, brokerage and ratings
+const stockDetailsSelect = `
+	SELECT 
+		s.id, s.ticker, s.target_from, s.target_to,
+		s.target_from_amount, s.target_to_amount, s.target_currency, s.company,
+		s.action_id, a.name as action_name, a.category as action_category, a.direction as action_direction,
+		s.brokerage_id, b.name as brokerage_name,
+		s.rating_from_id, rf.term as rating_from_term, rf.bucket as rating_from_bucket, rf.score as rating_from_score,
+		s.rating_to_id, rt.term as rating_to_term, rt.bucket as rating_to_bucket, rt.score as rating_to_score,
+		s.time, s.created_at, s.updated_at
+	FROM stocks s
+	LEFT JOIN actions a ON s.action_id = a.id
+	LEFT JOIN brokerages b ON s.brokerage_id = b.id
+	LEFT JOIN ratings rf ON s.rating_from_id = rf.id
+	LEFT JOIN ratings rt ON s.rating_to_id = rt.id
+`
+
+// stockViewCTE builds the view_stocks CTE from the events matching eventConditions.
+// The latest view keeps only the latest stock per ticker, which prevents duplicates when stocks are updated over time;
+// the by_brokerage view keeps the latest stock per ticker and brokerage and the history view keeps every event.
+func stockViewCTE(view domain.StockView, eventConditions string) string {
+	events := stockDetailsSelect + ` WHERE 1=1` + eventConditions
+
+	var distinctOn string
+	switch view {
+	case domain.StockViewHistory:
+		return `
+	WITH view_stocks AS (` + events + `)
+`
+	case domain.StockViewByBrokerage:
+		// Events without a brokerage form one group per ticker
+		distinctOn = "ticker, brokerage_id"
+	default:
+		distinctOn = "ticker"
+	}
+	return `
+	WITH view_stocks AS (
+		SELECT DISTINCT ON (` + distinctOn + `) *
+		FROM (` + events + `) s
+		ORDER BY ` + distinctOn + `, time DESC
+	)
+`
+}
+
+// timeRangeConditions restricts events to a time range on s.time, so idx_stocks_time can be used
+func timeRangeConditions(timeRange domain.TimeRange, args []interface{}, argPos int) (string, []interface{}, int) {
+	conditions := ""
+
+	if timeRange.From != nil {
+		conditions += fmt.Sprintf(" AND s.time >= $%d", argPos)
+		args = append(args, *timeRange.From)
+		argPos++
+	}
+
+	if timeRange.To != nil {
+		conditions += fmt.Sprintf(" AND s.time <= $%d", argPos)
+		args = append(args, *timeRange.To)
+		argPos++
+	}
+
+	return conditions, args, argPos
+}
+
+// buildStocksQuery builds "<CTE> <selectClause> FROM view_stocks WHERE ..." for a filter.
+// FindAll, Count and Stream share it so their results always agree. It returns the next free placeholder position.
+func buildStocksQuery(filter domain.StockFilter, selectClause string) (string, []interface{}, int, error) {
+	// Time range and as_of apply to events before picking the latest one per ticker
+	eventConditions, args, argPos := timeRangeConditions(filter.Time, []interface{}{}, 1)
+	if filter.AsOf != nil {
+		eventConditions += fmt.Sprintf(" AND s.time <= $%d", argPos)
+		args = append(args, *filter.AsOf)
+		argPos++
+	}
+
+	query := stockViewCTE(filter.View, eventConditions) + `
+		` + selectClause + `
+		FROM view_stocks
+		WHERE 1=1
+	`
+
+	var conditions string
+	conditions, args, argPos = stockFilterConditions(filter, args, argPos)
+
+	if filter.Expr != nil {
+		exprSQL, exprArgs, nextPos, err := compileFilterExpr(filter.Expr, args, argPos)
+		if err != nil {
+			return "", nil, 0, err
+		}
+		conditions += " AND " + exprSQL
+		args, argPos = exprArgs, nextPos
+	}
+
+	return query + conditions, args, argPos, nil
+}
+
+// stockFilterConditions translates a StockFilter into conditions on view_stocks columns
+func stockFilterConditions(filter domain.StockFilter, args []interface{}, argPos int) (string, []interface{}, int) {
+	var conditions, condition string
+
+	condition, args, argPos = valueFilterConditions("ticker", filter.Ticker, args, argPos)
+	conditions += condition
+
+	if filter.Company != "" {
+		// Fuzzy search with similarity matching (handles typos like "Aple" -> "Apple")
+		// Using trigram similarity: matches if similarity >= SEARCH_SIMILARITY_THRESHOLD (pg_trgm.similarity_threshold)
+		conditions += fmt.Sprintf(" AND (company ILIKE $%d OR company %% $%d)", argPos, argPos+1)
+		searchTerm := filter.Company
+		args = append(args, "%"+searchTerm+"%") // ILIKE pattern matching
+		args = append(args, searchTerm)         // Trigram similarity matching
+		argPos += 2
+	}
+
+	condition, args, argPos = brokerageFilterConditions(filter.Brokerage, args, argPos)
+	conditions += condition
+
+	condition, args, argPos = valueFilterConditions("action_name", filter.Action, args, argPos)
+	conditions += condition
+
+	if filter.ActionCategory != "" {
+		conditions += fmt.Sprintf(" AND action_category = $%d", argPos)
+		args = append(args, string(filter.ActionCategory))
+		argPos++
+	}
+
+	condition, args, argPos = valueFilterConditions("rating_from_term", filter.RatingFrom, args, argPos)
+	conditions += condition
+
+	condition, args, argPos = valueFilterConditions("rating_to_term", filter.RatingTo, args, argPos)
+	conditions += condition
+
+	if filter.RatingBucket != "" {
+		conditions += fmt.Sprintf(" AND rating_to_bucket = $%d", argPos)
+		args = append(args, string(filter.RatingBucket))
+		argPos++
+	}
+
+	if filter.TargetToMin != nil {
+		conditions += fmt.Sprintf(" AND target_to_amount >= $%d", argPos)
+		args = append(args, *filter.TargetToMin)
+		argPos++
+	}
+
+	if filter.TargetToMax != nil {
+		conditions += fmt.Sprintf(" AND target_to_amount <= $%d", argPos)
+		args = append(args, *filter.TargetToMax)
+		argPos++
+	}
+
+	if filter.TargetChangePctMin != nil {
+		conditions += fmt.Sprintf(" AND target_from_amount > 0 AND (target_to_amount - target_from_amount) / target_from_amount * 100 >= $%d", argPos)
+		args = append(args, *filter.TargetChangePctMin)
+		argPos++
+	}
+
+	return conditions, args, argPos
+}
+
+// valueFilterConditions matches column exactly against any of filter.In and none of filter.NotIn.
+// Rows where column is NULL are kept by exclusions ("anything except Sell" includes unrated events).
+func valueFilterConditions(column string, filter domain.ValueFilter, args []interface{}, argPos int) (string, []interface{}, int) {
+	conditions := ""
+
+	if len(filter.In) > 0 {
+		conditions += fmt.Sprintf(" AND %s = ANY($%d)", column, argPos)
+		args = append(args, filter.In)
+		argPos++
+	}
+
+	if len(filter.NotIn) > 0 {
+		conditions += fmt.Sprintf(" AND (%s IS NULL OR NOT %s = ANY($%d))", column, column, argPos)
+		args = append(args, filter.NotIn)
+		argPos++
+	}
+
+	return conditions, args, argPos
+}
+
+// brokerageFilterConditions fuzzy-matches any of filter.In and excludes names containing any of filter.NotIn
+func brokerageFilterConditions(filter domain.ValueFilter, args []interface{}, argPos int) (string, []interface{}, int) {
+	conditions := ""
+
+	if len(filter.In) > 0 {
+		// Fuzzy search with similarity matching (handles typos), any term may match
+		matches := make([]string, 0, len(filter.In))
+		for _, searchTerm := range filter.In {
+			matches = append(matches, fmt.Sprintf("brokerage_name ILIKE $%d OR brokerage_name %% $%d", argPos, argPos+1))
+			args = append(args, "%"+searchTerm+"%") // ILIKE pattern matching
+			args = append(args, searchTerm)         // Trigram similarity matching
+			argPos += 2
+		}
+		conditions += " AND (" + strings.Join(matches, " OR ") + ")"
+	}
+
+	// Exclusions use substring matching only - similarity would drop too many unrelated names
+	for _, searchTerm := range filter.NotIn {
+		conditions += fmt.Sprintf(" AND (brokerage_name IS NULL OR brokerage_name NOT ILIKE $%d)", argPos)
+		args = append(args, "%"+searchTerm+"%")
+		argPos++
+	}
+
+	return conditions, args, argPos
+}
+
+// stockColumn maps a StockWithDetails JSON field to its column in stockDetailsSelect and view_stocks
+type stockColumn struct {
+	field  string
+	column string
+	dest   func(s *domain.StockWithDetails) interface{}
+}
+
+// stockColumns lists all columns in scan order
+var stockColumns = []stockColumn{
+	{"id", "id", func(s *domain.StockWithDetails) interface{} { return &s.ID }},
+	{"ticker", "ticker", func(s *domain.StockWithDetails) interface{} { return &s.Ticker }},
+	{"target_from", "target_from", func(s *domain.StockWithDetails) interface{} { return &s.TargetFrom }},
+	{"target_to", "target_to", func(s *domain.StockWithDetails) interface{} { return &s.TargetTo }},
+	{"target_from_amount", "target_from_amount", func(s *domain.StockWithDetails) interface{} { return &s.TargetFromAmount }},
+	{"target_to_amount", "target_to_amount", func(s *domain.StockWithDetails) interface{} { return &s.TargetToAmount }},
+	{"target_currency", "target_currency", func(s *domain.StockWithDetails) interface{} { return &nullString{&s.TargetCurrency} }},
+	{"company", "company", func(s *domain.StockWithDetails) interface{} { return &s.Company }},
+	{"action_id", "action_id", func(s *domain.StockWithDetails) interface{} { return &s.ActionID }},
+	{"action", "action_name", func(s *domain.StockWithDetails) interface{} { return &nullString{&s.ActionName} }},
+	{"action_category", "action_category", func(s *domain.StockWithDetails) interface{} { return &nullString{(*string)(&s.ActionCategory)} }},
+	{"action_direction", "action_direction", func(s *domain.StockWithDetails) interface{} { return &nullString{(*string)(&s.ActionDirection)} }},
+	{"brokerage_id", "brokerage_id", func(s *domain.StockWithDetails) interface{} { return &s.BrokerageID }},
+	{"brokerage", "brokerage_name", func(s *domain.StockWithDetails) interface{} { return &nullString{&s.BrokerageName} }},
+	{"rating_from_id", "rating_from_id", func(s *domain.StockWithDetails) interface{} { return &s.RatingFromID }},
+	{"rating_from", "rating_from_term", func(s *domain.StockWithDetails) interface{} { return &nullString{&s.RatingFromTerm} }},
+	{"rating_from_bucket", "rating_from_bucket", func(s *domain.StockWithDetails) interface{} { return &nullString{(*string)(&s.RatingFromBucket)} }},
+	{"rating_from_score", "rating_from_score", func(s *domain.StockWithDetails) interface{} { return &s.RatingFromScore }},
+	{"rating_to_id", "rating_to_id", func(s *domain.StockWithDetails) interface{} { return &s.RatingToID }},
+	{"rating_to", "rating_to_term", func(s *domain.StockWithDetails) interface{} { return &nullString{&s.RatingToTerm} }},
+	{"rating_to_bucket", "rating_to_bucket", func(s *domain.StockWithDetails) interface{} { return &nullString{(*string)(&s.RatingToBucket)} }},
+	{"rating_to_score", "rating_to_score", func(s *domain.StockWithDetails) interface{} { return &s.RatingToScore }},
+	{"time", "time", func(s *domain.StockWithDetails) interface{} { return &s.Time }},
+	{"created_at", "created_at", func(s *domain.StockWithDetails) interface{} { return &s.CreatedAt }},
+	{"updated_at", "updated_at", func(s *domain.StockWithDetails) interface{} { return &s.UpdatedAt }},
+}
+
+// nullString scans a nullable text column, storing "" for NULL
+type nullString struct {
+	dst *string
+}
+
+// Scan implements sql.Scanner
+func (n *nullString) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*n.dst = ""
+	case string:
+		*n.dst = v
+	case []byte:
+		*n.dst = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into string", src)
+	}
+	return nil
+}
+
+// selectStockColumns returns the columns for the requested JSON fields in scan order; no fields means all columns.
+// Fields without a column (such as converted) are ignored.
+func selectStockColumns(fields []string) []stockColumn {
+	if len(fields) == 0 {
+		return stockColumns
+	}
+
+	requested := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		requested[field] = true
+	}
+
+	columns := []stockColumn{}
+	for _, column := range stockColumns {
+		if requested[column.field] {
+			columns = append(columns, column)
+		}
+	}
+	if len(columns) == 0 {
+		columns = append(columns, stockColumns[0])
+	}
+	return columns
+}
+
+// stockColumnList renders columns as a SELECT list
+func stockColumnList(columns []stockColumn) string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.column
+	}
+	return strings.Join(names, ", ")
+}
+
+// scanStockColumns scans a row selected with stockColumnList(columns)
+func scanStockColumns(row pgx.Row, columns []stockColumn) (*domain.StockWithDetails, error) {
+	stock := &domain.StockWithDetails{}
+
+	dests := make([]interface{}, len(columns))
+	for i, column := range columns {
+		dests[i] = column.dest(stock)
+	}
+
+	if err := row.Scan(dests...); err != nil {
+		return nil, err
+	}
+
+	return stock, nil
+}
+
+// scanStockWithDetails scans a row with all columns of stockDetailsSelect
+func scanStockWithDetails(row pgx.Row) (*domain.StockWithDetails, error) {
+	return scanStockColumns(row, stockColumns)
+}
+
 // NewStockRepository creates a new instance of StockRepository
 func NewStockRepository(db *pgxpool.Pool, brokerageRepo *BrokerageRepository, actionRepo *ActionRepository, ratingRepo *RatingRepository) *StockRepository {
 	return &StockRepository{
@@ -73,8 +395,9 @@ func (r *StockRepository) insertChunk(stocks []*domain.Stock) error {
 	defer tx.Rollback(ctx)
 
 	query := `
-		INSERT INTO stocks (ticker, target_from, target_to, company, action_id, brokerage_id, rating_from_id, rating_to_id, time)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO stocks (ticker, target_from, target_to, target_from_amount, target_to_amount, target_currency,
+			target_parsed_at, company, action_id, brokerage_id, rating_from_id, rating_to_id, time)
+		VALUES ($1, $2, $3, $4, $5, $6, now(), $7, $8, $9, $10, $11, $12)
 		ON CONFLICT (ticker, company, time) DO NOTHING
 		RETURNING id, created_at, updated_at
 	`
@@ -99,6 +422,9 @@ func (r *StockRepository) insertChunk(stocks []*domain.Stock) error {
 			stock.Ticker,
 			stock.TargetFrom,
 			stock.TargetTo,
+			stock.TargetFromAmount,
+			stock.TargetToAmount,
+			nullableString(stock.TargetCurrency),
 			stock.Company,
 			actionID,
 			brokerageID,
@@ -124,47 +450,11 @@ func (r *StockRepository) FindByID(id int64) (*domain.StockWithDetails, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	query := `
-		SELECT 
-			s.id, s.ticker, s.target_from, s.target_to, s.company,
-			s.action_id, a.name as action_name,
-			s.brokerage_id, b.name as brokerage_name,
-			s.rating_from_id, rf.term as rating_from_term,
-			s.rating_to_id, rt.term as rating_to_term,
-			s.time, s.created_at, s.updated_at
-		FROM stocks s
-		LEFT JOIN actions a ON s.action_id = a.id
-		LEFT JOIN brokerages b ON s.brokerage_id = b.id
-		LEFT JOIN ratings rf ON s.rating_from_id = rf.id
-		LEFT JOIN ratings rt ON s.rating_to_id = rt.id
+	query := stockDetailsSelect + `
 		WHERE s.id = $1
 	`
 
-	stock := &domain.StockWithDetails{}
-
-	// Use nullable types for scanning
-	var actionID, brokerageID, ratingFromID, ratingToID *int64
-	var actionName, brokerageName, ratingFromTerm, ratingToTerm *string
-
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&stock.ID,
-		&stock.Ticker,
-		&stock.TargetFrom,
-		&stock.TargetTo,
-		&stock.Company,
-		&actionID,
-		&actionName,
-		&brokerageID,
-		&brokerageName,
-		&ratingFromID,
-		&ratingFromTerm,
-		&ratingToID,
-		&ratingToTerm,
-		&stock.Time,
-		&stock.CreatedAt,
-		&stock.UpdatedAt,
-	)
-
+	stock, err := scanStockWithDetails(r.db.QueryRow(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrNotFound
@@ -172,42 +462,22 @@ func (r *StockRepository) FindByID(id int64) (*domain.StockWithDetails, error) {
 		return nil, fmt.Errorf("failed to find stock: %w", err)
 	}
 
-	// Assign nullable fields
-	stock.ActionID = actionID
-	stock.ActionName = getStringValue(actionName)
-	stock.BrokerageID = brokerageID
-	stock.BrokerageName = getStringValue(brokerageName)
-	stock.RatingFromID = ratingFromID
-	stock.RatingFromTerm = getStringValue(ratingFromTerm)
-	stock.RatingToID = ratingToID
-	stock.RatingToTerm = getStringValue(ratingToTerm)
-
 	return stock, nil
 }
 
-// FindByTicker retrieves all stock records for a given ticker (all historical versions)
-func (r *StockRepository) FindByTicker(ticker string) ([]*domain.StockWithDetails, error) {
+// FindByTicker retrieves all stock records for a given ticker (all historical versions) within a time range
+func (r *StockRepository) FindByTicker(ticker string, timeRange domain.TimeRange, fields []string) ([]*domain.StockWithDetails, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	query := `
-		SELECT 
-			s.id, s.ticker, s.target_from, s.target_to, s.company,
-			s.action_id, a.name as action_name,
-			s.brokerage_id, b.name as brokerage_name,
-			s.rating_from_id, rf.term as rating_from_term,
-			s.rating_to_id, rt.term as rating_to_term,
-			s.time, s.created_at, s.updated_at
-		FROM stocks s
-		LEFT JOIN actions a ON s.action_id = a.id
-		LEFT JOIN brokerages b ON s.brokerage_id = b.id
-		LEFT JOIN ratings rf ON s.rating_from_id = rf.id
-		LEFT JOIN ratings rt ON s.rating_to_id = rt.id
-		WHERE s.ticker = $1
-		ORDER BY s.time DESC
+	columns := selectStockColumns(fields)
+	conditions, args, _ := timeRangeConditions(timeRange, []interface{}{ticker}, 2)
+	query := `SELECT ` + stockColumnList(columns) + `
+		FROM (` + stockDetailsSelect + ` WHERE s.ticker = $1` + conditions + `) s
+		ORDER BY time DESC
 	`
 
-	rows, err := r.db.Query(ctx, query, ticker)
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query stocks by ticker: %w", err)
 	}
@@ -215,44 +485,10 @@ func (r *StockRepository) FindByTicker(ticker string) ([]*domain.StockWithDetail
 
 	var stocks []*domain.StockWithDetails
 	for rows.Next() {
-		stock := &domain.StockWithDetails{}
-
-		// Use nullable types for scanning
-		var actionID, brokerageID, ratingFromID, ratingToID *int64
-		var actionName, brokerageName, ratingFromTerm, ratingToTerm *string
-
-		err := rows.Scan(
-			&stock.ID,
-			&stock.Ticker,
-			&stock.TargetFrom,
-			&stock.TargetTo,
-			&stock.Company,
-			&actionID,
-			&actionName,
-			&brokerageID,
-			&brokerageName,
-			&ratingFromID,
-			&ratingFromTerm,
-			&ratingToID,
-			&ratingToTerm,
-			&stock.Time,
-			&stock.CreatedAt,
-			&stock.UpdatedAt,
-		)
+		stock, err := scanStockColumns(rows, columns)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan stock: %w", err)
 		}
-
-		// Assign nullable fields
-		stock.ActionID = actionID
-		stock.ActionName = getStringValue(actionName)
-		stock.BrokerageID = brokerageID
-		stock.BrokerageName = getStringValue(brokerageName)
-		stock.RatingFromID = ratingFromID
-		stock.RatingFromTerm = getStringValue(ratingFromTerm)
-		stock.RatingToID = ratingToID
-		stock.RatingToTerm = getStringValue(ratingToTerm)
-
 		stocks = append(stocks, stock)
 	}
 
@@ -267,166 +503,398 @@ func (r *StockRepository) FindByTicker(ticker string) ([]*domain.StockWithDetail
 	return stocks, nil
 }
 
+// validSortFields maps sort fields to view_stocks columns; only these are interpolated into ORDER BY
+var validSortFields = map[string]string{
+	"ticker":         "ticker",
+	"company":        "company",
+	"time":           "time",
+	"rating_to_term": "rating_to_term",
+	"action_name":    "action_name",
+	"brokerage_name": "brokerage_name",
+	"target_to":      "target_to_amount", // numeric, not lexicographic
+}
+
+// appendStockOrdering adds ORDER BY, LIMIT and OFFSET for a filter to a buildStocksQuery query.
+// Ties are broken by id so pages and exports come out in a stable order.
+func appendStockOrdering(query string, args []interface{}, argPos int, filter domain.StockFilter) (string, []interface{}) {
+	sortBy := "time"
+	if column, ok := validSortFields[filter.SortBy]; ok {
+		sortBy = column
+	}
+
+	sortOrder := "DESC"
+	if filter.SortOrder == "asc" || filter.SortOrder == "ASC" {
+		sortOrder = "ASC"
+	}
+
+	query += fmt.Sprintf(" ORDER BY %s %s, id %s", sortBy, sortOrder, sortOrder)
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argPos)
+		args = append(args, filter.Limit)
+		argPos++
+	}
+
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argPos)
+		args = append(args, filter.Offset)
+	}
+
+	return query, args
+}
+
 // FindAll retrieves stocks based on filters
 func (r *StockRepository) FindAll(filter domain.StockFilter) ([]*domain.StockWithDetails, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Use a subquery to get only the latest stock per ticker
-	// This prevents duplicates when stocks are updated over time
-	query := `
-		WITH latest_stocks AS (
-			SELECT DISTINCT ON (s.ticker) 
-				s.id, s.ticker, s.target_from, s.target_to, s.company,
-				s.action_id, a.name as action_name,
-				s.brokerage_id, b.name as brokerage_name,
-				s.rating_from_id, rf.term as rating_from_term,
-				s.rating_to_id, rt.term as rating_to_term,
-				s.time, s.created_at, s.updated_at
-			FROM stocks s
-			LEFT JOIN actions a ON s.action_id = a.id
-			LEFT JOIN brokerages b ON s.brokerage_id = b.id
-			LEFT JOIN ratings rf ON s.rating_from_id = rf.id
-			LEFT JOIN ratings rt ON s.rating_to_id = rt.id
-			ORDER BY s.ticker, s.time DESC
-		)
-		SELECT id, ticker, target_from, target_to, company,
-		       action_id, action_name, brokerage_id, brokerage_name,
-		       rating_from_id, rating_from_term, rating_to_id, rating_to_term,
-		       time, created_at, updated_at
-		FROM latest_stocks
-		WHERE 1=1
-	`
+	columns := selectStockColumns(filter.Fields)
+	query, args, argPos, err := buildStocksQuery(filter, "SELECT "+stockColumnList(columns))
+	if err != nil {
+		return nil, err
+	}
 
-	args := []interface{}{}
-	argPos := 1
+	query, args = appendStockOrdering(query, args, argPos, filter)
 
-	if filter.Ticker != "" {
-		query += fmt.Sprintf(" AND ticker = $%d", argPos)
-		args = append(args, filter.Ticker)
-		argPos++
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stocks: %w", err)
 	}
+	defer rows.Close()
 
-	if filter.Company != "" {
-		// Fuzzy search with similarity matching (handles typos like "Aple" -> "Apple")
-		// Using trigram similarity: matches if similarity > 0.3 (configurable threshold)
-		query += fmt.Sprintf(" AND (company ILIKE $%d OR company %% $%d)", argPos, argPos+1)
-		searchTerm := filter.Company
-		args = append(args, "%"+searchTerm+"%") // ILIKE pattern matching
-		args = append(args, searchTerm)         // Trigram similarity matching
-		argPos += 2
+	stocks := []*domain.StockWithDetails{}
+	for rows.Next() {
+		stock, err := scanStockColumns(rows, columns)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stock: %w", err)
+		}
+		stocks = append(stocks, stock)
 	}
 
-	if filter.Brokerage != "" {
-		// Fuzzy search with similarity matching (handles typos)
-		query += fmt.Sprintf(" AND (brokerage_name ILIKE $%d OR brokerage_name %% $%d)", argPos, argPos+1)
-		searchTerm := filter.Brokerage
-		args = append(args, "%"+searchTerm+"%") // ILIKE pattern matching
-		args = append(args, searchTerm)         // Trigram similarity matching
-		argPos += 2
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stocks: %w", err)
 	}
 
-	if filter.Action != "" {
-		query += fmt.Sprintf(" AND action_name = $%d", argPos)
-		args = append(args, filter.Action)
-		argPos++
+	return stocks, nil
+}
+
+// Stream calls fn for every stock matching the filter, reading rows from the cursor as they arrive
+// instead of loading them all. It has no timeout of its own; ctx bounds the whole export.
+func (r *StockRepository) Stream(ctx context.Context, filter domain.StockFilter, fn func(*domain.StockWithDetails) error) error {
+	columns := selectStockColumns(filter.Fields)
+	query, args, argPos, err := buildStocksQuery(filter, "SELECT "+stockColumnList(columns))
+	if err != nil {
+		return err
 	}
+	query, args = appendStockOrdering(query, args, argPos, filter)
 
-	if filter.RatingFrom != "" {
-		query += fmt.Sprintf(" AND rating_from_term = $%d", argPos)
-		args = append(args, filter.RatingFrom)
-		argPos++
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query stocks: %w", err)
 	}
+	defer rows.Close()
 
-	if filter.RatingTo != "" {
-		query += fmt.Sprintf(" AND rating_to_term = $%d", argPos)
-		args = append(args, filter.RatingTo)
-		argPos++
+	for rows.Next() {
+		stock, err := scanStockColumns(rows, columns)
+		if err != nil {
+			return fmt.Errorf("failed to scan stock: %w", err)
+		}
+		if err := fn(stock); err != nil {
+			return err
+		}
 	}
 
-	// Build ORDER BY clause
-	sortBy := "time"
-	if filter.SortBy != "" {
-		// Validate sortBy to prevent SQL injection
-		validSortFields := map[string]bool{
-			"ticker":         true,
-			"company":        true,
-			"time":           true,
-			"rating_to_term": true,
-			"action_name":    true,
-			"brokerage_name": true,
-			"target_to":      true,
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating stocks: %w", err)
+	}
+
+	return nil
+}
+
+// StreamEvents calls fn for every stock event stored within the window, oldest event first.
+// Like Stream, it reads rows from the cursor as they arrive and is bounded only by ctx.
+func (r *StockRepository) StreamEvents(ctx context.Context, window domain.EventWindow, fn func(*domain.StockWithDetails) error) error {
+	query := stockDetailsSelect + ` WHERE s.created_at <= $1`
+	args := []interface{}{window.Until}
+	if window.Since != nil {
+		query += ` AND s.created_at > $2`
+		args = append(args, *window.Since)
+	}
+	query += ` ORDER BY s.time, s.id`
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query stock events: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		stock, err := scanStockWithDetails(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan stock: %w", err)
+		}
+		if err := fn(stock); err != nil {
+			return err
 		}
-		if validSortFields[filter.SortBy] {
-			sortBy = filter.SortBy
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating stock events: %w", err)
+	}
+
+	return nil
+}
+
+// analyticsGroupColumns maps analytics groupings to view_stocks columns; only these are interpolated into GROUP BY
+var analyticsGroupColumns = map[domain.AnalyticsGroupBy]string{
+	domain.AnalyticsGroupByAction:         "action_name",
+	domain.AnalyticsGroupByActionCategory: "action_category",
+	domain.AnalyticsGroupByBrokerage:      "brokerage_name",
+	domain.AnalyticsGroupByRatingTo:       "rating_to_term",
+	domain.AnalyticsGroupByTicker:         "ticker",
+}
+
+// analyticsIntervals maps analytics intervals to date_trunc units
+var analyticsIntervals = map[domain.AnalyticsInterval]string{
+	domain.AnalyticsIntervalDay:   "day",
+	domain.AnalyticsIntervalWeek:  "week",
+	domain.AnalyticsIntervalMonth: "month",
+}
+
+// AggregateEvents counts the events matching the query's filter per UTC time bucket and group and
+// averages their target change. Rows are ordered by bucket and group; empty buckets are not returned.
+func (r *StockRepository) AggregateEvents(ctx context.Context, query domain.EventAnalyticsQuery) ([]*domain.EventAggregate, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	unit, ok := analyticsIntervals[query.Interval]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown interval %q", domain.ErrInvalidInput, query.Interval)
+	}
+	group := "''"
+	if query.GroupBy != "" {
+		column, ok := analyticsGroupColumns[query.GroupBy]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown group_by %q", domain.ErrInvalidInput, query.GroupBy)
+		}
+		group = "COALESCE(" + column + "::STRING, '')"
+	}
+
+	selectClause := fmt.Sprintf(`SELECT date_trunc('%s', time AT TIME ZONE 'UTC') AS bucket, %s AS grp,
+			COUNT(*), AVG(%s)::FLOAT8`, unit, group, filterExprColumns["target_change_pct"])
+	sqlQuery, args, _, err := buildStocksQuery(query.Filter, selectClause)
+	if err != nil {
+		return nil, err
+	}
+	sqlQuery += ` GROUP BY bucket, grp ORDER BY bucket, grp`
+
+	rows, err := r.db.Query(queryCtx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate stock events: %w", err)
+	}
+	defer rows.Close()
+
+	var aggregates []*domain.EventAggregate
+	for rows.Next() {
+		aggregate := &domain.EventAggregate{}
+		if err := rows.Scan(&aggregate.Bucket, &aggregate.Group, &aggregate.Count, &aggregate.AvgTargetChangePct); err != nil {
+			return nil, fmt.Errorf("failed to scan event aggregate: %w", err)
+		}
+		aggregate.Bucket = aggregate.Bucket.UTC()
+		aggregates = append(aggregates, aggregate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating event aggregates: %w", err)
+	}
+
+	return aggregates, nil
+}
+
+// AggregateRatingTransitions counts the events matching the query's filter per rating_from and rating_to,
+// as terms with their scores or as canonical buckets
+func (r *StockRepository) AggregateRatingTransitions(ctx context.Context, query domain.RatingTransitionQuery) ([]*domain.RatingTransitionCount, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var selectClause string
+	switch query.Level {
+	case domain.TransitionLevelTerm:
+		selectClause = `SELECT COALESCE(rating_from_term, '') AS from_rating, rating_from_score AS from_score,
+			COALESCE(rating_to_term, '') AS to_rating, rating_to_score AS to_score, COUNT(*)`
+	case domain.TransitionLevelBucket:
+		selectClause = `SELECT COALESCE(rating_from_bucket::STRING, '') AS from_rating, NULL::FLOAT8 AS from_score,
+			COALESCE(rating_to_bucket::STRING, '') AS to_rating, NULL::FLOAT8 AS to_score, COUNT(*)`
+	default:
+		return nil, fmt.Errorf("%w: unknown level %q", domain.ErrInvalidInput, query.Level)
+	}
+
+	sqlQuery, args, _, err := buildStocksQuery(query.Filter, selectClause)
+	if err != nil {
+		return nil, err
+	}
+	sqlQuery += ` GROUP BY from_rating, from_score, to_rating, to_score`
+
+	rows, err := r.db.Query(queryCtx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate rating transitions: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []*domain.RatingTransitionCount
+	for rows.Next() {
+		count := &domain.RatingTransitionCount{}
+		if err := rows.Scan(&count.From, &count.FromScore, &count.To, &count.ToScore, &count.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan rating transition: %w", err)
 		}
+		counts = append(counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rating transitions: %w", err)
 	}
 
+	return counts, nil
+}
+
+// timelineEventsCTE numbers the events of ticker $1 with the IDs of the previous event by any brokerage
+// and by the same brokerage. It covers the ticker's full history so filters never change what an event is diffed against.
+const timelineEventsCTE = `
+	WITH timeline_events AS (
+		SELECT d.*,
+			LAG(d.id) OVER (ORDER BY d.time, d.id) AS previous_id,
+			CASE WHEN d.brokerage_id IS NOT NULL
+				THEN LAG(d.id) OVER (PARTITION BY d.brokerage_id ORDER BY d.time, d.id)
+			END AS previous_brokerage_event_id
+		FROM (` + stockDetailsSelect + ` WHERE s.ticker = $1) d
+	)
+`
+
+// timelineConditions translates a TimelineFilter into conditions on timeline_events s
+func timelineConditions(filter domain.TimelineFilter, args []interface{}, argPos int) (string, []interface{}, int) {
+	conditions, args, argPos := timeRangeConditions(filter.Time, args, argPos)
+
+	var condition string
+	condition, args, argPos = brokerageFilterConditions(filter.Brokerage, args, argPos)
+	conditions += condition
+
+	return conditions, args, argPos
+}
+
+// FindTimeline retrieves a page of a ticker's events with the earlier events they are diffed against
+func (r *StockRepository) FindTimeline(ctx context.Context, ticker string, filter domain.TimelineFilter) ([]*domain.TimelineEntry, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	conditions, args, argPos := timelineConditions(filter, []interface{}{ticker}, 2)
+
 	sortOrder := "DESC"
 	if filter.SortOrder == "asc" || filter.SortOrder == "ASC" {
 		sortOrder = "ASC"
 	}
 
-	query += fmt.Sprintf(" ORDER BY %s %s", sortBy, sortOrder)
+	query := timelineEventsCTE + `
+		SELECT ` + stockColumnList(stockColumns) + `, previous_id, previous_brokerage_event_id
+		FROM timeline_events s
+		WHERE 1=1` + conditions + fmt.Sprintf(`
+		ORDER BY time %s, id %s
+		LIMIT $%d OFFSET $%d`, sortOrder, sortOrder, argPos, argPos+1)
+	args = append(args, filter.Limit, filter.Offset)
 
-	if filter.Limit > 0 {
-		query += fmt.Sprintf(" LIMIT $%d", argPos)
-		args = append(args, filter.Limit)
-		argPos++
+	rows, err := r.db.Query(queryCtx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query timeline: %w", err)
 	}
+	defer rows.Close()
 
-	if filter.Offset > 0 {
-		query += fmt.Sprintf(" OFFSET $%d", argPos)
-		args = append(args, filter.Offset)
+	entries := []*domain.TimelineEntry{}
+	var previousIDs, previousBrokerageIDs []*int64
+	for rows.Next() {
+		stock := &domain.StockWithDetails{}
+		var previousID, previousBrokerageID *int64
+
+		dests := make([]interface{}, 0, len(stockColumns)+2)
+		for _, column := range stockColumns {
+			dests = append(dests, column.dest(stock))
+		}
+		dests = append(dests, &previousID, &previousBrokerageID)
+
+		if err := rows.Scan(dests...); err != nil {
+			return nil, fmt.Errorf("failed to scan timeline event: %w", err)
+		}
+		entries = append(entries, &domain.TimelineEntry{Stock: stock})
+		previousIDs = append(previousIDs, previousID)
+		previousBrokerageIDs = append(previousBrokerageIDs, previousBrokerageID)
 	}
 
-	rows, err := r.db.Query(ctx, query, args...)
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating timeline: %w", err)
+	}
+
+	// Load the earlier events in one query
+	var ids []int64
+	for i := range entries {
+		for _, id := range []*int64{previousIDs[i], previousBrokerageIDs[i]} {
+			if id != nil {
+				ids = append(ids, *id)
+			}
+		}
+	}
+	previous, err := r.findByIDs(queryCtx, ids)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query stocks: %w", err)
+		return nil, err
+	}
+
+	for i, entry := range entries {
+		if id := previousIDs[i]; id != nil {
+			entry.Previous = previous[*id]
+		}
+		if id := previousBrokerageIDs[i]; id != nil {
+			entry.PreviousByBrokerage = previous[*id]
+		}
+	}
+
+	return entries, nil
+}
+
+// CountTimeline returns the number of a ticker's events matching the filter
+func (r *StockRepository) CountTimeline(ctx context.Context, ticker string, filter domain.TimelineFilter) (int64, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	conditions, args, _ := timelineConditions(filter, []interface{}{ticker}, 2)
+	query := timelineEventsCTE + `
+		SELECT COUNT(*)
+		FROM timeline_events s
+		WHERE 1=1` + conditions
+
+	var count int64
+	if err := r.db.QueryRow(queryCtx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count timeline: %w", err)
+	}
+
+	return count, nil
+}
+
+// findByIDs retrieves stocks with all joined details by ID
+func (r *StockRepository) findByIDs(ctx context.Context, ids []int64) (map[int64]*domain.StockWithDetails, error) {
+	stocks := make(map[int64]*domain.StockWithDetails, len(ids))
+	if len(ids) == 0 {
+		return stocks, nil
+	}
+
+	rows, err := r.db.Query(ctx, stockDetailsSelect+` WHERE s.id = ANY($1)`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stocks by id: %w", err)
 	}
 	defer rows.Close()
 
-	stocks := []*domain.StockWithDetails{}
 	for rows.Next() {
-		stock := &domain.StockWithDetails{}
-
-		// Use nullable types for scanning
-		var actionID, brokerageID, ratingFromID, ratingToID *int64
-		var actionName, brokerageName, ratingFromTerm, ratingToTerm *string
-
-		err := rows.Scan(
-			&stock.ID,
-			&stock.Ticker,
-			&stock.TargetFrom,
-			&stock.TargetTo,
-			&stock.Company,
-			&actionID,
-			&actionName,
-			&brokerageID,
-			&brokerageName,
-			&ratingFromID,
-			&ratingFromTerm,
-			&ratingToID,
-			&ratingToTerm,
-			&stock.Time,
-			&stock.CreatedAt,
-			&stock.UpdatedAt,
-		)
+		stock, err := scanStockWithDetails(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan stock: %w", err)
 		}
-
-		// Assign nullable fields
-		stock.ActionID = actionID
-		stock.ActionName = getStringValue(actionName)
-		stock.BrokerageID = brokerageID
-		stock.BrokerageName = getStringValue(brokerageName)
-		stock.RatingFromID = ratingFromID
-		stock.RatingFromTerm = getStringValue(ratingFromTerm)
-		stock.RatingToID = ratingToID
-		stock.RatingToTerm = getStringValue(ratingToTerm)
-
-		stocks = append(stocks, stock)
+		stocks[stock.ID] = stock
 	}
 
 	if err := rows.Err(); err != nil {
@@ -436,80 +904,109 @@ func (r *StockRepository) FindAll(filter domain.StockFilter) ([]*domain.StockWit
 	return stocks, nil
 }
 
-// Count returns the total number of unique stocks (latest per ticker) matching the filter
+// Count returns the total number of stocks in the filter's view (latest per ticker by default) matching the filter
 func (r *StockRepository) Count(filter domain.StockFilter) (int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// Count only the latest version of each ticker
+	// Count the same view with the same filters as FindAll
+	query, args, _, err := buildStocksQuery(filter, "SELECT COUNT(*)")
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	err = r.db.QueryRow(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count stocks: %w", err)
+	}
+
+	return count, nil
+}
+
+// CurrentTime returns the database clock, which sets created_at
+func (r *StockRepository) CurrentTime(ctx context.Context) (time.Time, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var now time.Time
+	if err := r.db.QueryRow(queryCtx, `SELECT now()`).Scan(&now); err != nil {
+		return time.Time{}, fmt.Errorf("failed to read database time: %w", err)
+	}
+
+	return now.UTC(), nil
+}
+
+// FindPendingTargetBackfill retrieves stocks with raw price targets that were never parsed, ordered by ID
+func (r *StockRepository) FindPendingTargetBackfill(ctx context.Context, afterID int64, limit int) ([]*domain.Stock, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
 	query := `
-		WITH latest_stocks AS (
-			SELECT DISTINCT ON (s.ticker) 
-				s.id, s.ticker, s.target_from, s.target_to, s.company,
-				s.action_id, a.name as action_name,
-				s.brokerage_id, b.name as brokerage_name,
-				s.rating_from_id, rf.term as rating_from_term,
-				s.rating_to_id, rt.term as rating_to_term,
-				s.time, s.created_at, s.updated_at
-			FROM stocks s
-			LEFT JOIN actions a ON s.action_id = a.id
-			LEFT JOIN brokerages b ON s.brokerage_id = b.id
-			LEFT JOIN ratings rf ON s.rating_from_id = rf.id
-			LEFT JOIN ratings rt ON s.rating_to_id = rt.id
-			ORDER BY s.ticker, s.time DESC
-		)
-		SELECT COUNT(*) FROM latest_stocks WHERE 1=1
+		SELECT id, ticker, COALESCE(target_from, ''), COALESCE(target_to, '')
+		FROM stocks
+		WHERE id > $1
+		  AND target_parsed_at IS NULL
+		  AND (target_from <> '' OR target_to <> '')
+		ORDER BY id
+		LIMIT $2
 	`
-	args := []interface{}{}
-	argPos := 1
 
-	if filter.Ticker != "" {
-		query += fmt.Sprintf(" AND ticker = $%d", argPos)
-		args = append(args, filter.Ticker)
-		argPos++
+	rows, err := r.db.Query(queryCtx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stocks for target backfill: %w", err)
 	}
+	defer rows.Close()
 
-	if filter.Company != "" {
-		// Fuzzy search with similarity matching (handles typos like "Aple" -> "Apple")
-		// Using trigram similarity: matches if similarity > 0.3 (configurable threshold)
-		query += fmt.Sprintf(" AND (company ILIKE $%d OR company %% $%d)", argPos, argPos+1)
-		searchTerm := filter.Company
-		args = append(args, "%"+searchTerm+"%") // ILIKE pattern matching
-		args = append(args, searchTerm)         // Trigram similarity matching
-		argPos += 2
+	var stocks []*domain.Stock
+	for rows.Next() {
+		stock := &domain.Stock{}
+		if err := rows.Scan(&stock.ID, &stock.Ticker, &stock.TargetFrom, &stock.TargetTo); err != nil {
+			return nil, fmt.Errorf("failed to scan stock: %w", err)
+		}
+		stocks = append(stocks, stock)
 	}
 
-	if filter.Brokerage != "" {
-		// Fuzzy search with similarity matching (handles typos)
-		query += fmt.Sprintf(" AND (brokerage_name ILIKE $%d OR brokerage_name %% $%d)", argPos, argPos+1)
-		searchTerm := filter.Brokerage
-		args = append(args, "%"+searchTerm+"%") // ILIKE pattern matching
-		args = append(args, searchTerm)         // Trigram similarity matching
-		argPos += 2
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stocks: %w", err)
 	}
 
-	if filter.Action != "" {
-		query += fmt.Sprintf(" AND action_name = $%d", argPos)
-		args = append(args, filter.Action)
-		argPos++
-	}
+	return stocks, nil
+}
 
-	if filter.RatingFrom != "" {
-		query += fmt.Sprintf(" AND rating_from_term = $%d", argPos)
-		args = append(args, filter.RatingFrom)
-		argPos++
+// UpdateTargetAmounts stores the numeric price targets of existing stocks in a single transaction
+// and marks them parsed, including stocks whose targets could not be parsed
+func (r *StockRepository) UpdateTargetAmounts(ctx context.Context, stocks []*domain.Stock) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	tx, err := r.db.Begin(queryCtx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback(queryCtx)
 
-	if filter.RatingTo != "" {
-		query += fmt.Sprintf(" AND rating_to_term = $%d", argPos)
-		args = append(args, filter.RatingTo)
+	query := `
+		UPDATE stocks
+		SET target_from_amount = $2, target_to_amount = $3, target_currency = $4, target_parsed_at = now()
+		WHERE id = $1
+	`
+
+	for _, stock := range stocks {
+		_, err := tx.Exec(queryCtx, query,
+			stock.ID,
+			stock.TargetFromAmount,
+			stock.TargetToAmount,
+			nullableString(stock.TargetCurrency),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update target amounts: %w", err)
+		}
 	}
 
-	var count int64
-	err := r.db.QueryRow(ctx, query, args...).Scan(&count)
-	if err != nil {
-		return 0, fmt.Errorf("failed to count stocks: %w", err)
+	if err := tx.Commit(queryCtx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	return count, nil
+	return nil
 }
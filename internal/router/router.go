@@ -1,6 +1,7 @@
 package router
 
 import (
+	"github.com/company/stock-api/internal/graphql"
 	"github.com/company/stock-api/internal/handler"
 	"github.com/company/stock-api/internal/middleware"
 	"github.com/gin-gonic/gin"
@@ -10,14 +11,14 @@ import (
 )
 
 // SetupRouter configures and returns the HTTP router
-func SetupRouter(stockHandler *handler.StockHandler, logger *zap.Logger) *gin.Engine {
+func SetupRouter(stockHandler *handler.StockHandler, graphqlHandler *graphql.Handler, adminAPIKey string, logger *zap.Logger) *gin.Engine {
 	// Set Gin mode based on environment
 	gin.SetMode(gin.ReleaseMode)
 
 	router := gin.New()
 
 	// Global middleware
-	router.Use(gin.Recovery())
+	router.Use(middleware.Recovery(logger))
 	router.Use(middleware.Logger(logger))
 	router.Use(middleware.CORS())
 
@@ -27,41 +28,85 @@ func SetupRouter(stockHandler *handler.StockHandler, logger *zap.Logger) *gin.En
 	// Swagger documentation
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// GraphQL endpoint over stocks, brokerages, actions, ratings and recommendations
+	router.POST("/graphql", graphqlHandler.Serve)
+	router.GET("/graphql", graphqlHandler.Serve)
+
+	// Admin-only routes require the admin API key
+	admin := middleware.AdminAuth(adminAPIKey)
+
 	// API v1 routes
 	v1 := router.Group("/api/v1")
 	{
 		stocks := v1.Group("/stocks")
 		{
 			stocks.GET("", stockHandler.GetStocks)
+			stocks.GET("/export", stockHandler.ExportStocks)
+			stocks.GET("/export/parquet", admin, stockHandler.ExportParquet)
 			stocks.GET("/:id", stockHandler.GetStockByID)
 			stocks.POST("/sync", stockHandler.SyncStocks)
 		}
 
 		// Get all historical versions of a stock by ticker
 		v1.GET("/stock/:ticker", stockHandler.GetStocksByTicker)
+		v1.GET("/stock/:ticker/timeline", stockHandler.GetTimeline)
+		v1.GET("/stock/:ticker/consensus", stockHandler.GetConsensus)
+		v1.GET("/stock/:ticker/prices", stockHandler.GetPriceBars)
 
 		// Get stock recommendations
 		v1.GET("/recommendations", stockHandler.GetRecommendations)
+		v1.GET("/recommendations/backtest", admin, stockHandler.GetBacktest)
+		v1.GET("/recommendations/profile", stockHandler.GetScoringProfile)
+		v1.GET("/recommendations/strategies", stockHandler.GetRecommendationStrategies)
+
+		// Analytics routes
+		analytics := v1.Group("/analytics")
+		{
+			analytics.GET("/events", stockHandler.GetEventAnalytics)
+			analytics.GET("/rating-transitions", stockHandler.GetRatingTransitions)
+		}
+
+		// Search routes
+		search := v1.Group("/search")
+		{
+			search.GET("", stockHandler.Search)
+			search.GET("/suggest", stockHandler.SearchSuggest)
+		}
 
 		// Brokerage routes (read-only)
 		brokerages := v1.Group("/brokerages")
 		{
 			brokerages.GET("", stockHandler.GetBrokerages)
+			brokerages.GET("/leaderboard", stockHandler.GetBrokerageLeaderboard)
 			brokerages.GET("/:id", stockHandler.GetBrokerageByID)
+			brokerages.GET("/:id/profile", stockHandler.GetBrokerageProfile)
 		}
 
-		// Action routes (read-only)
+		// Action routes (categories are managed by admins)
 		actions := v1.Group("/actions")
 		{
 			actions.GET("", stockHandler.GetActions)
 			actions.GET("/:id", stockHandler.GetActionByID)
+			actions.PUT("/:id", admin, stockHandler.UpdateActionCategory)
+		}
+
+		// FX rates used for currency conversion
+		fxRates := v1.Group("/fx-rates")
+		{
+			fxRates.GET("", stockHandler.GetFXRates)
+			fxRates.POST("", admin, stockHandler.ImportFXRates)
 		}
 
-		// Rating routes (read-only)
+		// Daily price bars used for implied upside
+		v1.POST("/prices", admin, stockHandler.ImportPriceBars)
+
+		// Rating routes (canonical scale is managed by admins)
 		ratings := v1.Group("/ratings")
 		{
 			ratings.GET("", stockHandler.GetRatings)
+			ratings.GET("/quality", stockHandler.GetRatingQualityReport)
 			ratings.GET("/:id", stockHandler.GetRatingByID)
+			ratings.PUT("/:id", admin, stockHandler.UpdateRatingScale)
 		}
 	}
 
@@ -2,26 +2,91 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/company/stock-api/internal/domain"
 	"go.uber.org/zap"
 )
 
+// DefaultActionRules classify action names in order; the first matching rule wins
+var DefaultActionRules = []domain.ActionRule{
+	{Category: domain.ActionCategoryUpgrade, Contains: []string{"upgrade"}},
+	{Category: domain.ActionCategoryInitiate, Contains: []string{"initiate"}},
+	{Category: domain.ActionCategoryTargetRaised, Contains: []string{"target", "raised"}},
+	{Category: domain.ActionCategoryReiterate, Contains: []string{"reiterate"}},
+	{Category: domain.ActionCategoryReiterate, Contains: []string{"maintain"}},
+	{Category: domain.ActionCategoryTargetLowered, Contains: []string{"target", "lowered"}},
+	{Category: domain.ActionCategoryDowngrade, Contains: []string{"downgrade"}},
+}
+
 // ActionUseCase handles business logic for action operations
 type ActionUseCase struct {
 	repo   domain.ActionRepository
+	rules  []domain.ActionRule
 	logger *zap.Logger
 }
 
-// NewActionUseCase creates a new ActionUseCase
-func NewActionUseCase(repo domain.ActionRepository, logger *zap.Logger) *ActionUseCase {
+// NewActionUseCase creates a new ActionUseCase.
+// If rules is empty, DefaultActionRules are used to classify new actions.
+func NewActionUseCase(repo domain.ActionRepository, rules []domain.ActionRule, logger *zap.Logger) *ActionUseCase {
+	if len(rules) == 0 {
+		rules = DefaultActionRules
+	}
+
 	return &ActionUseCase{
 		repo:   repo,
+		rules:  rules,
 		logger: logger,
 	}
 }
 
+// LoadActionRules reads classification rules from a JSON file
+func LoadActionRules(path string) ([]domain.ActionRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read action rules: %w", err)
+	}
+
+	var rules []domain.ActionRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse action rules: %w", err)
+	}
+
+	for i, rule := range rules {
+		if _, ok := domain.ParseActionCategory(string(rule.Category)); !ok {
+			return nil, fmt.Errorf("action rule %d: unknown category %q", i, rule.Category)
+		}
+		if len(rule.Contains) == 0 {
+			return nil, fmt.Errorf("action rule %d: contains must not be empty", i)
+		}
+	}
+
+	return rules, nil
+}
+
+// Classify returns the category of the first rule matching the action name
+func (uc *ActionUseCase) Classify(name string) domain.ActionCategory {
+	name = strings.ToLower(name)
+
+	for _, rule := range uc.rules {
+		matched := true
+		for _, substr := range rule.Contains {
+			if !strings.Contains(name, strings.ToLower(substr)) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return rule.Category
+		}
+	}
+
+	return domain.ActionCategoryOther
+}
+
 // GetAll retrieves all actions
 func (uc *ActionUseCase) GetAll(ctx context.Context) ([]*domain.Action, error) {
 	actions, err := uc.repo.FindAll(ctx)
@@ -33,6 +98,22 @@ func (uc *ActionUseCase) GetAll(ctx context.Context) ([]*domain.Action, error) {
 	return actions, nil
 }
 
+// GetByIDs retrieves the actions with the given IDs in one query, keyed by ID
+func (uc *ActionUseCase) GetByIDs(ctx context.Context, ids []int64) (map[int64]*domain.Action, error) {
+	actions, err := uc.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		uc.logger.Error("Failed to retrieve actions by IDs", zap.Int("count", len(ids)), zap.Error(err))
+		return nil, fmt.Errorf("failed to retrieve actions: %w", err)
+	}
+
+	byID := make(map[int64]*domain.Action, len(actions))
+	for _, item := range actions {
+		byID[item.ID] = item
+	}
+
+	return byID, nil
+}
+
 // GetByID retrieves a single action by ID
 func (uc *ActionUseCase) GetByID(ctx context.Context, id int64) (*domain.Action, error) {
 	action, err := uc.repo.FindByID(id)
@@ -52,9 +133,12 @@ func (uc *ActionUseCase) GetOrCreate(ctx context.Context, name string) (*domain.
 		return action, nil
 	}
 
-	// Create new action if not found
+	// Create new action if not found, classified by the configured rules
+	category := uc.Classify(name)
 	action = &domain.Action{
-		Name: name,
+		Name:      name,
+		Category:  category,
+		Direction: category.Direction(),
 	}
 
 	err = uc.repo.Create(action)
@@ -63,6 +147,70 @@ func (uc *ActionUseCase) GetOrCreate(ctx context.Context, name string) (*domain.
 		return nil, fmt.Errorf("failed to create action: %w", err)
 	}
 
-	uc.logger.Info("Created new action", zap.String("name", name), zap.Int64("id", action.ID))
+	uc.logger.Info("Created new action",
+		zap.String("name", name),
+		zap.String("category", string(action.Category)),
+		zap.Int64("id", action.ID))
+	return action, nil
+}
+
+// UpdateCategory assigns a category to an action.
+// If direction is empty, the category's default direction is used.
+func (uc *ActionUseCase) UpdateCategory(ctx context.Context, id int64, category, direction string) (*domain.Action, error) {
+	parsedCategory, ok := domain.ParseActionCategory(category)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown action category %q", domain.ErrInvalidInput, category)
+	}
+
+	parsedDirection := parsedCategory.Direction()
+	if direction != "" {
+		if parsedDirection, ok = domain.ParseActionDirection(direction); !ok {
+			return nil, fmt.Errorf("%w: unknown action direction %q", domain.ErrInvalidInput, direction)
+		}
+	}
+
+	action := &domain.Action{
+		ID:        id,
+		Category:  parsedCategory,
+		Direction: parsedDirection,
+	}
+
+	if err := uc.repo.UpdateCategory(ctx, action); err != nil {
+		uc.logger.Error("Failed to update action category", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	uc.logger.Info("Updated action category",
+		zap.Int64("id", id),
+		zap.String("name", action.Name),
+		zap.String("category", string(action.Category)),
+		zap.String("direction", string(action.Direction)))
 	return action, nil
 }
+
+// ClassifyUncategorized assigns categories to existing actions that have none
+func (uc *ActionUseCase) ClassifyUncategorized(ctx context.Context) (int, error) {
+	actions, err := uc.repo.FindAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve actions: %w", err)
+	}
+
+	classified := 0
+	for _, action := range actions {
+		if action.Category != "" {
+			continue
+		}
+
+		action.Category = uc.Classify(action.Name)
+		action.Direction = action.Category.Direction()
+		if err := uc.repo.UpdateCategory(ctx, action); err != nil {
+			return classified, fmt.Errorf("failed to classify action %q: %w", action.Name, err)
+		}
+		classified++
+	}
+
+	if classified > 0 {
+		uc.logger.Info("Classified existing actions", zap.Int("count", classified))
+	}
+	return classified, nil
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// MockActionRepository is a mock implementation of domain.ActionRepository
+type MockActionRepository struct {
+	mock.Mock
+}
+
+func (m *MockActionRepository) Create(action *domain.Action) error {
+	args := m.Called(action)
+	return args.Error(0)
+}
+
+func (m *MockActionRepository) FindByID(id int64) (*domain.Action, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Action), args.Error(1)
+}
+
+func (m *MockActionRepository) FindByName(name string) (*domain.Action, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Action), args.Error(1)
+}
+
+func (m *MockActionRepository) FindByIDs(ctx context.Context, ids []int64) ([]*domain.Action, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Action), args.Error(1)
+}
+
+func (m *MockActionRepository) FindAll(ctx context.Context) ([]*domain.Action, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Action), args.Error(1)
+}
+
+func (m *MockActionRepository) UpdateCategory(ctx context.Context, action *domain.Action) error {
+	args := m.Called(ctx, action)
+	return args.Error(0)
+}
+
+func TestActionUseCase_Classify(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	useCase := NewActionUseCase(new(MockActionRepository), nil, logger)
+
+	tests := []struct {
+		name     string
+		expected domain.ActionCategory
+	}{
+		{"upgraded by", domain.ActionCategoryUpgrade},
+		{"downgraded by", domain.ActionCategoryDowngrade},
+		{"initiated by", domain.ActionCategoryInitiate},
+		{"target raised by", domain.ActionCategoryTargetRaised},
+		{"target lowered by", domain.ActionCategoryTargetLowered},
+		{"reiterated by", domain.ActionCategoryReiterate},
+		{"Maintained", domain.ActionCategoryReiterate},
+		{"target set by", domain.ActionCategoryOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, useCase.Classify(tt.name))
+		})
+	}
+}
+
+func TestActionUseCase_GetOrCreate(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	mockRepo := new(MockActionRepository)
+	useCase := NewActionUseCase(mockRepo, nil, logger)
+
+	mockRepo.On("FindByName", "target raised by").Return(nil, domain.ErrNotFound).Once()
+	mockRepo.On("Create", mock.MatchedBy(func(a *domain.Action) bool {
+		return a.Category == domain.ActionCategoryTargetRaised && a.Direction == domain.ActionDirectionUp
+	})).Return(nil).Once()
+
+	action, err := useCase.GetOrCreate(context.Background(), "target raised by")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ActionCategoryTargetRaised, action.Category)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestActionUseCase_UpdateCategory(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	t.Run("Direction defaults to category direction", func(t *testing.T) {
+		mockRepo := new(MockActionRepository)
+		useCase := NewActionUseCase(mockRepo, nil, logger)
+
+		mockRepo.On("UpdateCategory", mock.Anything, mock.AnythingOfType("*domain.Action")).Return(nil).Once()
+
+		action, err := useCase.UpdateCategory(context.Background(), 3, "downgrade", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ActionDirectionDown, action.Direction)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown category", func(t *testing.T) {
+		useCase := NewActionUseCase(new(MockActionRepository), nil, logger)
+
+		_, err := useCase.UpdateCategory(context.Background(), 3, "sideways", "")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	})
+
+	t.Run("Unknown direction", func(t *testing.T) {
+		useCase := NewActionUseCase(new(MockActionRepository), nil, logger)
+
+		_, err := useCase.UpdateCategory(context.Background(), 3, "other", "left")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	})
+}
+
+func TestLoadActionRules(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("Valid rules", func(t *testing.T) {
+		path := filepath.Join(dir, "rules.json")
+		os.WriteFile(path, []byte(`[{"category": "upgrade", "contains": ["raised to buy"]}]`), 0o600)
+
+		rules, err := LoadActionRules(path)
+
+		assert.NoError(t, err)
+		assert.Len(t, rules, 1)
+		assert.Equal(t, domain.ActionCategoryUpgrade, rules[0].Category)
+	})
+
+	t.Run("Unknown category", func(t *testing.T) {
+		path := filepath.Join(dir, "invalid.json")
+		os.WriteFile(path, []byte(`[{"category": "sideways", "contains": ["x"]}]`), 0o600)
+
+		_, err := LoadActionRules(path)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,321 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"go.uber.org/zap"
+)
+
+// Analytics limits
+const (
+	// maxAnalyticsBuckets bounds the buckets per series, so a day interval over years is rejected
+	maxAnalyticsBuckets = 1000
+	// defaultAnalyticsGroups and maxAnalyticsGroups bound the series returned, largest groups first
+	defaultAnalyticsGroups = 20
+	maxAnalyticsGroups     = 100
+)
+
+// GetEventAnalytics counts the events matching the query per time bucket and group, with the average
+// target change per bucket. Only the limit groups with the most events are returned. Buckets run from the
+// start of the time range (or the first event) to its end (or now), and buckets without events count zero.
+func (uc *StockUseCase) GetEventAnalytics(ctx context.Context, query domain.EventAnalyticsQuery, limit int) (*domain.EventAnalytics, error) {
+	if err := query.Filter.Validate(); err != nil {
+		return nil, err
+	}
+	if _, ok := domain.ParseAnalyticsGroupBy(string(query.GroupBy)); !ok {
+		return nil, fmt.Errorf("%w: unknown group_by %q", domain.ErrInvalidInput, query.GroupBy)
+	}
+	if query.Interval == "" {
+		query.Interval = domain.AnalyticsIntervalWeek
+	}
+	if _, ok := domain.ParseAnalyticsInterval(string(query.Interval)); !ok {
+		return nil, fmt.Errorf("%w: unknown interval %q", domain.ErrInvalidInput, query.Interval)
+	}
+	if limit <= 0 {
+		limit = defaultAnalyticsGroups
+	}
+	if limit > maxAnalyticsGroups {
+		limit = maxAnalyticsGroups
+	}
+
+	// Reject ranges with too many buckets before querying
+	end := time.Now()
+	if query.Filter.AsOf != nil {
+		end = *query.Filter.AsOf
+	}
+	if query.Filter.Time.To != nil {
+		end = *query.Filter.Time.To
+	}
+	if from := query.Filter.Time.From; from != nil && countBuckets(*from, end, query.Interval) > maxAnalyticsBuckets {
+		return nil, tooManyBucketsError(query.Interval)
+	}
+
+	aggregates, err := uc.repo.AggregateEvents(ctx, query)
+	if err != nil {
+		uc.logger.Error("Failed to aggregate events", zap.Error(err))
+		return nil, fmt.Errorf("failed to aggregate events: %w", err)
+	}
+
+	var start time.Time
+	if query.Filter.Time.From != nil {
+		start = *query.Filter.Time.From
+	} else if len(aggregates) > 0 {
+		start = aggregates[0].Bucket
+	} else {
+		start = end
+	}
+	if len(aggregates) > 0 && aggregates[len(aggregates)-1].Bucket.After(end) {
+		end = aggregates[len(aggregates)-1].Bucket
+	}
+	if countBuckets(start, end, query.Interval) > maxAnalyticsBuckets {
+		return nil, tooManyBucketsError(query.Interval)
+	}
+
+	return buildEventAnalytics(query, aggregates, start, end, limit), nil
+}
+
+// tooManyBucketsError rejects a range with more than maxAnalyticsBuckets buckets
+func tooManyBucketsError(interval domain.AnalyticsInterval) error {
+	return fmt.Errorf("%w: more than %d %s buckets; narrow the time range or use a longer interval",
+		domain.ErrInvalidInput, maxAnalyticsBuckets, interval)
+}
+
+// buildEventAnalytics turns aggregates into one zero-filled series per group, largest groups first
+func buildEventAnalytics(query domain.EventAnalyticsQuery, aggregates []*domain.EventAggregate, start, end time.Time, limit int) *domain.EventAnalytics {
+	first := truncateToInterval(start, query.Interval)
+	last := truncateToInterval(end, query.Interval)
+
+	var buckets []time.Time
+	for bucket := first; !bucket.After(last); bucket = nextInterval(bucket, query.Interval) {
+		buckets = append(buckets, bucket)
+	}
+	positions := make(map[time.Time]int, len(buckets))
+	for i, bucket := range buckets {
+		positions[bucket] = i
+	}
+
+	seriesByGroup := map[string]*domain.EventSeries{}
+	var groups []*domain.EventSeries
+	for _, aggregate := range aggregates {
+		series, ok := seriesByGroup[aggregate.Group]
+		if !ok {
+			series = &domain.EventSeries{Group: aggregate.Group, Buckets: make([]domain.EventBucket, len(buckets))}
+			for i, bucket := range buckets {
+				series.Buckets[i].Start = bucket
+			}
+			seriesByGroup[aggregate.Group] = series
+			groups = append(groups, series)
+		}
+
+		i, ok := positions[truncateToInterval(aggregate.Bucket, query.Interval)]
+		if !ok {
+			continue
+		}
+		series.Buckets[i].Count += aggregate.Count
+		series.Total += aggregate.Count
+		if aggregate.AvgTargetChangePct != nil {
+			avg := roundTo(*aggregate.AvgTargetChangePct, 2)
+			series.Buckets[i].AvgTargetChangePct = &avg
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Total != groups[j].Total {
+			return groups[i].Total > groups[j].Total
+		}
+		return groups[i].Group < groups[j].Group
+	})
+
+	analytics := &domain.EventAnalytics{
+		GroupBy:  query.GroupBy,
+		Interval: query.Interval,
+		From:     first,
+		To:       last,
+		Groups:   len(groups),
+		Series:   make([]domain.EventSeries, 0, limit),
+	}
+	for i, series := range groups {
+		if i == limit {
+			break
+		}
+		analytics.Series = append(analytics.Series, *series)
+	}
+
+	return analytics
+}
+
+// truncateToInterval returns the start of the UTC bucket containing t, like SQL date_trunc; weeks start on Monday
+func truncateToInterval(t time.Time, interval domain.AnalyticsInterval) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch interval {
+	case domain.AnalyticsIntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case domain.AnalyticsIntervalWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	default:
+		return day
+	}
+}
+
+// nextInterval returns the start of the bucket after the one starting at bucket
+func nextInterval(bucket time.Time, interval domain.AnalyticsInterval) time.Time {
+	switch interval {
+	case domain.AnalyticsIntervalMonth:
+		return bucket.AddDate(0, 1, 0)
+	case domain.AnalyticsIntervalWeek:
+		return bucket.AddDate(0, 0, 7)
+	default:
+		return bucket.AddDate(0, 0, 1)
+	}
+}
+
+// countBuckets returns the number of buckets from the one containing start to the one containing end
+func countBuckets(start, end time.Time, interval domain.AnalyticsInterval) int {
+	first := truncateToInterval(start, interval)
+	last := truncateToInterval(end, interval)
+	if last.Before(first) {
+		return 0
+	}
+
+	switch interval {
+	case domain.AnalyticsIntervalMonth:
+		return (last.Year()-first.Year())*12 + int(last.Month()-first.Month()) + 1
+	case domain.AnalyticsIntervalWeek:
+		return int(last.Sub(first).Hours()/(24*7)) + 1
+	default:
+		return int(last.Sub(first).Hours()/24) + 1
+	}
+}
+
+// GetRatingTransitions counts the events matching the query per rating_from and rating_to. Bucket matrices
+// always list every bucket; term matrices list the terms that occur, ordered by score.
+func (uc *StockUseCase) GetRatingTransitions(ctx context.Context, query domain.RatingTransitionQuery) (*domain.RatingTransitionMatrix, error) {
+	if err := query.Filter.Validate(); err != nil {
+		return nil, err
+	}
+	if query.Level == "" {
+		query.Level = domain.TransitionLevelBucket
+	}
+	if _, ok := domain.ParseTransitionLevel(string(query.Level)); !ok {
+		return nil, fmt.Errorf("%w: unknown level %q", domain.ErrInvalidInput, query.Level)
+	}
+
+	counts, err := uc.repo.AggregateRatingTransitions(ctx, query)
+	if err != nil {
+		uc.logger.Error("Failed to aggregate rating transitions", zap.Error(err))
+		return nil, fmt.Errorf("failed to aggregate rating transitions: %w", err)
+	}
+
+	return buildTransitionMatrix(query.Level, counts), nil
+}
+
+// buildTransitionMatrix arranges transition counts into a matrix; counts without both ratings are missing
+func buildTransitionMatrix(level domain.TransitionLevel, counts []*domain.RatingTransitionCount) *domain.RatingTransitionMatrix {
+	matrix := &domain.RatingTransitionMatrix{Level: level, Ratings: []string{}, Transitions: []domain.RatingTransition{}}
+
+	// Scores order the axis and tell upgrades from downgrades
+	scores := map[string]*float64{}
+	if level == domain.TransitionLevelBucket {
+		for _, bucket := range domain.RatingBuckets {
+			score := bucket.DefaultScore()
+			scores[string(bucket)] = &score
+			matrix.Ratings = append(matrix.Ratings, string(bucket))
+		}
+	}
+
+	addTerm := func(term string, score *float64) {
+		if _, seen := scores[term]; !seen {
+			scores[term] = score
+			matrix.Ratings = append(matrix.Ratings, term)
+		}
+	}
+
+	var complete []*domain.RatingTransitionCount
+	for _, count := range counts {
+		if count.From == "" || count.To == "" {
+			matrix.Missing += count.Count
+			continue
+		}
+		complete = append(complete, count)
+		if level == domain.TransitionLevelTerm {
+			addTerm(count.From, count.FromScore)
+			addTerm(count.To, count.ToScore)
+		}
+	}
+
+	if level == domain.TransitionLevelTerm {
+		// Unscored terms go last
+		sort.Slice(matrix.Ratings, func(i, j int) bool {
+			a, b := scores[matrix.Ratings[i]], scores[matrix.Ratings[j]]
+			switch {
+			case a != nil && b != nil && *a != *b:
+				return *a < *b
+			case (a == nil) != (b == nil):
+				return a != nil
+			default:
+				return matrix.Ratings[i] < matrix.Ratings[j]
+			}
+		})
+	}
+
+	positions := make(map[string]int, len(matrix.Ratings))
+	matrix.Counts = make([][]int64, len(matrix.Ratings))
+	for i, rating := range matrix.Ratings {
+		positions[rating] = i
+		matrix.Counts[i] = make([]int64, len(matrix.Ratings))
+	}
+
+	cells := map[[2]string]int64{}
+	for _, count := range complete {
+		from, okFrom := positions[count.From]
+		to, okTo := positions[count.To]
+		if !okFrom || !okTo {
+			// A bucket outside the canonical scale
+			matrix.Missing += count.Count
+			continue
+		}
+		matrix.Counts[from][to] += count.Count
+		cells[[2]string{count.From, count.To}] += count.Count
+	}
+
+	for cell, count := range cells {
+		transition := domain.RatingTransition{From: cell[0], To: cell[1], Count: count, Movement: domain.RatingMovementUnknown}
+		fromScore, toScore := scores[cell[0]], scores[cell[1]]
+		switch {
+		case cell[0] == cell[1]:
+			transition.Movement = domain.RatingMovementUnchanged
+		case fromScore != nil && toScore != nil && *toScore > *fromScore:
+			transition.Movement = domain.RatingMovementUpgrade
+			matrix.Upgrades += count
+		case fromScore != nil && toScore != nil && *toScore < *fromScore:
+			transition.Movement = domain.RatingMovementDowngrade
+			matrix.Downgrades += count
+		case fromScore != nil && toScore != nil:
+			transition.Movement = domain.RatingMovementUnchanged
+		}
+
+		matrix.Total += count
+		if cell[0] != cell[1] {
+			matrix.Changed += count
+		}
+		matrix.Transitions = append(matrix.Transitions, transition)
+	}
+
+	sort.Slice(matrix.Transitions, func(i, j int) bool {
+		a, b := matrix.Transitions[i], matrix.Transitions[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if positions[a.From] != positions[b.From] {
+			return positions[a.From] < positions[b.From]
+		}
+		return positions[a.To] < positions[b.To]
+	})
+
+	return matrix
+}
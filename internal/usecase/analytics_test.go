@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestTruncateToInterval(t *testing.T) {
+	// A Wednesday evening in New York is Thursday in UTC
+	at := time.Date(2025, 3, 12, 22, 30, 0, 0, time.FixedZone("EST", -5*3600))
+
+	assert.Equal(t, time.Date(2025, 3, 13, 0, 0, 0, 0, time.UTC), truncateToInterval(at, domain.AnalyticsIntervalDay))
+	assert.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), truncateToInterval(at, domain.AnalyticsIntervalWeek))
+	assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), truncateToInterval(at, domain.AnalyticsIntervalMonth))
+
+	sunday := time.Date(2025, 3, 16, 23, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), truncateToInterval(sunday, domain.AnalyticsIntervalWeek), "weeks start on Monday")
+}
+
+func TestCountBuckets(t *testing.T) {
+	start := time.Date(2024, 11, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 2, 3, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 81, countBuckets(start, end, domain.AnalyticsIntervalDay))
+	assert.Equal(t, 13, countBuckets(start, end, domain.AnalyticsIntervalWeek))
+	assert.Equal(t, 4, countBuckets(start, end, domain.AnalyticsIntervalMonth))
+	assert.Equal(t, 0, countBuckets(end, start, domain.AnalyticsIntervalDay))
+}
+
+func TestStockUseCase_GetEventAnalytics(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	from := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 30, 0, 0, 0, 0, time.UTC)
+	week := func(n int) time.Time { return from.AddDate(0, 0, 7*n) }
+
+	t.Run("Fills empty buckets and orders series by size", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+		query := domain.EventAnalyticsQuery{
+			Filter:   domain.StockFilter{View: domain.StockViewHistory, Time: domain.TimeRange{From: &from, To: &to}},
+			GroupBy:  domain.AnalyticsGroupByActionCategory,
+			Interval: domain.AnalyticsIntervalWeek,
+		}
+		mockRepo.On("AggregateEvents", mock.Anything, query).Return([]*domain.EventAggregate{
+			{Bucket: week(0), Group: "downgrade", Count: 1},
+			{Bucket: week(0), Group: "upgrade", Count: 2, AvgTargetChangePct: floatPtr(12.3456)},
+			{Bucket: week(2), Group: "upgrade", Count: 3},
+		}, nil).Once()
+
+		analytics, err := useCase.GetEventAnalytics(context.Background(), query, 0)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		assert.Equal(t, week(0), analytics.From)
+		assert.Equal(t, week(3), analytics.To)
+		assert.Equal(t, 2, analytics.Groups)
+		if assert.Len(t, analytics.Series, 2) {
+			upgrades := analytics.Series[0]
+			assert.Equal(t, "upgrade", upgrades.Group)
+			assert.Equal(t, int64(5), upgrades.Total)
+			assert.Equal(t, []domain.EventBucket{
+				{Start: week(0), Count: 2, AvgTargetChangePct: floatPtr(12.35)},
+				{Start: week(1)},
+				{Start: week(2), Count: 3},
+				{Start: week(3)},
+			}, upgrades.Buckets)
+
+			downgrades := analytics.Series[1]
+			assert.Equal(t, "downgrade", downgrades.Group)
+			assert.Equal(t, []int64{1, 0, 0, 0}, []int64{downgrades.Buckets[0].Count, downgrades.Buckets[1].Count, downgrades.Buckets[2].Count, downgrades.Buckets[3].Count})
+		}
+	})
+
+	t.Run("Limits the series to the largest groups", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+		query := domain.EventAnalyticsQuery{
+			Filter:   domain.StockFilter{Time: domain.TimeRange{From: &from, To: &to}},
+			GroupBy:  domain.AnalyticsGroupByTicker,
+			Interval: domain.AnalyticsIntervalMonth,
+		}
+		mockRepo.On("AggregateEvents", mock.Anything, query).Return([]*domain.EventAggregate{
+			{Bucket: from, Group: "AAPL", Count: 1},
+			{Bucket: from, Group: "MSFT", Count: 4},
+			{Bucket: from, Group: "NVDA", Count: 2},
+		}, nil).Once()
+
+		analytics, err := useCase.GetEventAnalytics(context.Background(), query, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, analytics.Groups)
+		if assert.Len(t, analytics.Series, 2) {
+			assert.Equal(t, "MSFT", analytics.Series[0].Group)
+			assert.Equal(t, "NVDA", analytics.Series[1].Group)
+			assert.Len(t, analytics.Series[0].Buckets, 1)
+		}
+	})
+
+	t.Run("Without a time range buckets start at the first event", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+		asOf := time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC)
+		query := domain.EventAnalyticsQuery{Filter: domain.StockFilter{AsOf: &asOf}, Interval: domain.AnalyticsIntervalDay}
+		mockRepo.On("AggregateEvents", mock.Anything, query).Return([]*domain.EventAggregate{
+			{Bucket: time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC), Count: 7},
+		}, nil).Once()
+
+		analytics, err := useCase.GetEventAnalytics(context.Background(), query, 0)
+
+		assert.NoError(t, err)
+		if assert.Len(t, analytics.Series, 1) {
+			assert.Equal(t, "", analytics.Series[0].Group)
+			assert.Len(t, analytics.Series[0].Buckets, 4, "buckets run up to as_of")
+		}
+	})
+
+	t.Run("No events", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+		query := domain.EventAnalyticsQuery{Filter: domain.StockFilter{Time: domain.TimeRange{From: &from, To: &to}}, Interval: domain.AnalyticsIntervalWeek}
+		mockRepo.On("AggregateEvents", mock.Anything, query).Return(nil, nil).Once()
+
+		analytics, err := useCase.GetEventAnalytics(context.Background(), query, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, analytics.Groups)
+		assert.NotNil(t, analytics.Series)
+		assert.Empty(t, analytics.Series)
+	})
+
+	t.Run("Rejects too many buckets", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+		longAgo := to.AddDate(-5, 0, 0)
+		query := domain.EventAnalyticsQuery{Filter: domain.StockFilter{Time: domain.TimeRange{From: &longAgo, To: &to}}, Interval: domain.AnalyticsIntervalDay}
+
+		_, err := useCase.GetEventAnalytics(context.Background(), query, 0)
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+		mockRepo.AssertNotCalled(t, "AggregateEvents", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Rejects unknown groupings", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		_, err := useCase.GetEventAnalytics(context.Background(), domain.EventAnalyticsQuery{GroupBy: "company"}, 0)
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+		query := domain.EventAnalyticsQuery{Filter: domain.StockFilter{Time: domain.TimeRange{From: &from, To: &to}}, Interval: domain.AnalyticsIntervalWeek}
+		mockRepo.On("AggregateEvents", mock.Anything, query).Return(nil, errors.New("database error")).Once()
+
+		_, err := useCase.GetEventAnalytics(context.Background(), query, 0)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestStockUseCase_GetRatingTransitions(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	t.Run("Bucket matrix lists every bucket", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+		query := domain.RatingTransitionQuery{Filter: domain.StockFilter{View: domain.StockViewHistory}, Level: domain.TransitionLevelBucket}
+		mockRepo.On("AggregateRatingTransitions", mock.Anything, query).Return([]*domain.RatingTransitionCount{
+			{From: "sell", To: "buy", Count: 3},
+			{From: "hold", To: "hold", Count: 10},
+			{From: "buy", To: "hold", Count: 2},
+			{From: "", To: "buy", Count: 4},
+		}, nil).Once()
+
+		matrix, err := useCase.GetRatingTransitions(context.Background(), query)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		assert.Equal(t, []string{"strong_sell", "sell", "hold", "buy", "strong_buy"}, matrix.Ratings)
+		assert.Equal(t, int64(3), matrix.Counts[1][3])
+		assert.Equal(t, int64(10), matrix.Counts[2][2])
+		assert.Equal(t, int64(2), matrix.Counts[3][2])
+		assert.Equal(t, int64(15), matrix.Total)
+		assert.Equal(t, int64(5), matrix.Changed)
+		assert.Equal(t, int64(3), matrix.Upgrades)
+		assert.Equal(t, int64(2), matrix.Downgrades)
+		assert.Equal(t, int64(4), matrix.Missing)
+		assert.Equal(t, []domain.RatingTransition{
+			{From: "hold", To: "hold", Count: 10, Movement: domain.RatingMovementUnchanged},
+			{From: "sell", To: "buy", Count: 3, Movement: domain.RatingMovementUpgrade},
+			{From: "buy", To: "hold", Count: 2, Movement: domain.RatingMovementDowngrade},
+		}, matrix.Transitions)
+	})
+
+	t.Run("Term matrix orders terms by score", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+		query := domain.RatingTransitionQuery{Level: domain.TransitionLevelTerm}
+		mockRepo.On("AggregateRatingTransitions", mock.Anything, query).Return([]*domain.RatingTransitionCount{
+			{From: "Neutral", FromScore: floatPtr(3), To: "Outperform", ToScore: floatPtr(4), Count: 2},
+			{From: "Top Pick", To: "Neutral", ToScore: floatPtr(3), Count: 1},
+			{From: "Equal Weight", FromScore: floatPtr(3), To: "Neutral", ToScore: floatPtr(3), Count: 5},
+		}, nil).Once()
+
+		matrix, err := useCase.GetRatingTransitions(context.Background(), query)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Equal Weight", "Neutral", "Outperform", "Top Pick"}, matrix.Ratings)
+		assert.Equal(t, int64(5), matrix.Counts[0][1])
+		assert.Equal(t, int64(8), matrix.Changed)
+		assert.Equal(t, int64(2), matrix.Upgrades)
+		assert.Equal(t, int64(0), matrix.Downgrades)
+		movements := map[string]domain.RatingMovement{}
+		for _, transition := range matrix.Transitions {
+			movements[transition.From+">"+transition.To] = transition.Movement
+		}
+		assert.Equal(t, domain.RatingMovementUnchanged, movements["Equal Weight>Neutral"], "different terms on the same score")
+		assert.Equal(t, domain.RatingMovementUnknown, movements["Top Pick>Neutral"])
+	})
+
+	t.Run("No events", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+		query := domain.RatingTransitionQuery{Level: domain.TransitionLevelTerm}
+		mockRepo.On("AggregateRatingTransitions", mock.Anything, query).Return(nil, nil).Once()
+
+		matrix, err := useCase.GetRatingTransitions(context.Background(), query)
+
+		assert.NoError(t, err)
+		assert.Empty(t, matrix.Ratings)
+		assert.NotNil(t, matrix.Transitions)
+	})
+
+	t.Run("Rejects unknown levels", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		_, err := useCase.GetRatingTransitions(context.Background(), domain.RatingTransitionQuery{Level: "vendor"})
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+		query := domain.RatingTransitionQuery{Level: domain.TransitionLevelBucket}
+		mockRepo.On("AggregateRatingTransitions", mock.Anything, query).Return(nil, errors.New("database error")).Once()
+
+		_, err := useCase.GetRatingTransitions(context.Background(), query)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,314 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"go.uber.org/zap"
+)
+
+// Backtest limits
+const (
+	defaultBacktestPicks   = 10
+	maxBacktestPicks       = 100
+	maxBacktestDates       = 104
+	defaultBacktestHorizon = 30 * 24 * time.Hour
+	maxBacktestHorizon     = 365 * 24 * time.Hour
+	backtestDeciles        = 10
+)
+
+// backtestEventFields are the fields evaluateBacktestPick reads from later events
+var backtestEventFields = []string{"ticker", "brokerage_id", "action_category", "target_from_amount", "target_to_amount", "time"}
+
+// Backtest replays GetRecommendations on every date of the query, scoring only the events available at that date,
+// and follows each scored stock for the horizon: later upgrades, downgrades and target moves by other brokerages
+// decide whether it was a hit. Horizons reaching past now are evaluated on the events stored so far.
+func (uc *StockUseCase) Backtest(ctx context.Context, query domain.BacktestQuery) (*domain.BacktestResult, error) {
+	now := time.Now()
+	if query.From.IsZero() || query.To.IsZero() {
+		return nil, fmt.Errorf("%w: from and to are required", domain.ErrInvalidInput)
+	}
+	if query.From.After(query.To) {
+		return nil, fmt.Errorf("%w: from must not be after to", domain.ErrInvalidInput)
+	}
+	if query.To.After(now) {
+		return nil, fmt.Errorf("%w: to must not be in the future", domain.ErrInvalidInput)
+	}
+	if query.Interval == "" {
+		query.Interval = domain.AnalyticsIntervalWeek
+	}
+	if _, ok := domain.ParseAnalyticsInterval(string(query.Interval)); !ok {
+		return nil, fmt.Errorf("%w: unknown interval %q", domain.ErrInvalidInput, query.Interval)
+	}
+	if query.Horizon == 0 {
+		query.Horizon = defaultBacktestHorizon
+	}
+	if query.Horizon < 0 || query.Horizon > maxBacktestHorizon {
+		return nil, fmt.Errorf("%w: horizon must be positive and at most %d days", domain.ErrInvalidInput, int(maxBacktestHorizon.Hours()/24))
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultBacktestPicks
+	}
+	if query.Limit > maxBacktestPicks {
+		query.Limit = maxBacktestPicks
+	}
+	strategy, err := uc.findStrategy(query.Strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	var dates []time.Time
+	for date := query.From; !date.After(query.To); date = nextInterval(date, query.Interval) {
+		dates = append(dates, date)
+		if len(dates) > maxBacktestDates {
+			return nil, fmt.Errorf("%w: more than %d backtest dates; narrow the range or use a longer interval",
+				domain.ErrInvalidInput, maxBacktestDates)
+		}
+	}
+
+	uc.logger.Info("Running recommendation backtest",
+		zap.Time("from", query.From), zap.Time("to", query.To), zap.Int("dates", len(dates)))
+
+	// Load the events following every date in one pass
+	end := query.To.Add(query.Horizon)
+	if end.After(now) {
+		end = now
+	}
+	later := map[string][]*domain.StockWithDetails{}
+	filter := domain.StockFilter{
+		View:   domain.StockViewHistory,
+		Time:   domain.TimeRange{From: &query.From, To: &end},
+		Fields: backtestEventFields,
+	}
+	err = uc.repo.Stream(ctx, filter, func(stock *domain.StockWithDetails) error {
+		later[stock.Ticker] = append(later[stock.Ticker], stock)
+		return nil
+	})
+	if err != nil {
+		uc.logger.Error("Failed to load backtest events", zap.Error(err))
+		return nil, fmt.Errorf("failed to load backtest events: %w", err)
+	}
+	for _, events := range later {
+		sort.Slice(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+	}
+
+	picks := newBacktestStats("picks")
+	universe := newBacktestStats("universe")
+	deciles := make([]*backtestStats, backtestDeciles)
+	for i := range deciles {
+		deciles[i] = newBacktestStats(fmt.Sprintf("decile_%d", i+1))
+	}
+
+	result := &domain.BacktestResult{
+		From:        query.From,
+		To:          query.To,
+		Interval:    query.Interval,
+		HorizonDays: int(query.Horizon.Hours() / 24),
+		Limit:       query.Limit,
+		Strategy:    strategy.Info().Name,
+		Dates:       dates,
+		PickList:    []domain.BacktestPick{},
+	}
+	for _, asOf := range dates {
+		recommendations, err := uc.GetRecommendations(ctx, domain.RecommendationQuery{
+			Fields:   []string{"brokerage_id"},
+			AsOf:     &asOf,
+			Strategy: query.Strategy,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for i, recommendation := range recommendations {
+			pick := evaluateBacktestPick(recommendation, later[recommendation.Stock.Ticker], asOf, asOf.Add(query.Horizon))
+			pick.Rank = i + 1
+
+			universe.add(pick)
+			deciles[i*backtestDeciles/len(recommendations)].add(pick)
+			if i < query.Limit {
+				picks.add(pick)
+				pick.AvgTargetChangePct = roundPtr(pick.AvgTargetChangePct)
+				result.PickList = append(result.PickList, pick)
+			}
+		}
+	}
+
+	result.Picks = picks.summary()
+	result.Universe = universe.summary()
+	for _, decile := range deciles {
+		result.Deciles = append(result.Deciles, decile.summary())
+	}
+
+	return result, nil
+}
+
+// evaluateBacktestPick counts the signals of the events of other brokerages after asOf up to until.
+// events must be the ticker's events sorted by time.
+func evaluateBacktestPick(recommendation *domain.StockRecommendation, events []*domain.StockWithDetails, asOf, until time.Time) domain.BacktestPick {
+	stock := recommendation.Stock
+	pick := domain.BacktestPick{
+		AsOf:      asOf,
+		Ticker:    stock.Ticker,
+		Brokerage: stock.BrokerageName,
+		Score:     roundTo(recommendation.Score, 4),
+		Outcome:   domain.BacktestOutcomeNoEvents,
+	}
+
+	var targetChanges []float64
+	start := sort.Search(len(events), func(i int) bool { return events[i].Time.After(asOf) })
+	for _, event := range events[start:] {
+		if event.Time.After(until) {
+			break
+		}
+		if stock.BrokerageID != nil && event.BrokerageID != nil && *stock.BrokerageID == *event.BrokerageID {
+			continue
+		}
+
+		pick.LaterEvents++
+		switch event.ActionCategory {
+		case domain.ActionCategoryUpgrade:
+			pick.Upgrades++
+		case domain.ActionCategoryDowngrade:
+			pick.Downgrades++
+		}
+		if from, to := event.TargetFromAmount, event.TargetToAmount; from != nil && to != nil && *from > 0 && *to > 0 {
+			switch {
+			case *to > *from:
+				pick.TargetRaises++
+			case *to < *from:
+				pick.TargetCuts++
+			}
+			targetChanges = append(targetChanges, (*to-*from) / *from * 100)
+		}
+	}
+
+	if len(targetChanges) > 0 {
+		avg := mean(targetChanges)
+		pick.AvgTargetChangePct = &avg
+	}
+	if pick.LaterEvents > 0 {
+		switch net := backtestNetSignal(pick); {
+		case net > 0:
+			pick.Outcome = domain.BacktestOutcomeHit
+		case net < 0:
+			pick.Outcome = domain.BacktestOutcomeMiss
+		default:
+			pick.Outcome = domain.BacktestOutcomeNeutral
+		}
+	}
+
+	return pick
+}
+
+// backtestNetSignal is the number of positive minus negative signals following a pick
+func backtestNetSignal(pick domain.BacktestPick) int {
+	return pick.Upgrades + pick.TargetRaises - pick.Downgrades - pick.TargetCuts
+}
+
+// backtestStats accumulates the picks of one group
+type backtestStats struct {
+	stats         domain.BacktestStats
+	netSignal     int
+	targetChanges []float64
+}
+
+// newBacktestStats starts an empty group
+func newBacktestStats(group string) *backtestStats {
+	return &backtestStats{stats: domain.BacktestStats{Group: group}}
+}
+
+// add counts a pick in the group
+func (s *backtestStats) add(pick domain.BacktestPick) {
+	if s.stats.Scored == 0 || pick.Score < s.stats.MinScore {
+		s.stats.MinScore = pick.Score
+	}
+	if s.stats.Scored == 0 || pick.Score > s.stats.MaxScore {
+		s.stats.MaxScore = pick.Score
+	}
+	s.stats.Scored++
+
+	switch pick.Outcome {
+	case domain.BacktestOutcomeHit:
+		s.stats.Hits++
+	case domain.BacktestOutcomeMiss:
+		s.stats.Misses++
+	case domain.BacktestOutcomeNeutral:
+		s.stats.Neutral++
+	default:
+		s.stats.NoEvents++
+	}
+
+	s.netSignal += backtestNetSignal(pick)
+	if pick.AvgTargetChangePct != nil {
+		s.targetChanges = append(s.targetChanges, *pick.AvgTargetChangePct)
+	}
+}
+
+// summary returns the group's statistics with rates and averages filled in
+func (s *backtestStats) summary() domain.BacktestStats {
+	stats := s.stats
+	if decided := stats.Hits + stats.Misses; decided > 0 {
+		hitRate := roundTo(float64(stats.Hits)/float64(decided), 4)
+		stats.HitRate = &hitRate
+	}
+	if stats.Scored > 0 {
+		stats.AvgNetSignal = roundTo(float64(s.netSignal)/float64(stats.Scored), 4)
+	}
+	if len(s.targetChanges) > 0 {
+		avg := roundTo(mean(s.targetChanges), 2)
+		stats.AvgTargetChangePct = &avg
+	}
+	return stats
+}
+
+// backtestCSVHeader names the columns written by WriteBacktestCSV
+var backtestCSVHeader = []string{
+	"group", "scored", "hits", "misses", "neutral", "no_events", "hit_rate",
+	"avg_net_signal", "avg_target_change_pct", "min_score", "max_score",
+}
+
+// WriteBacktestCSV writes the statistics of a backtest as CSV: one row for the picks, one for the whole
+// universe and one per score decile. Missing rates and averages are left empty.
+func WriteBacktestCSV(w io.Writer, result *domain.BacktestResult) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(backtestCSVHeader); err != nil {
+		return err
+	}
+
+	formatFloat := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	formatOptional := func(v *float64) string {
+		if v == nil {
+			return ""
+		}
+		return formatFloat(*v)
+	}
+
+	rows := append([]domain.BacktestStats{result.Picks, result.Universe}, result.Deciles...)
+	for _, stats := range rows {
+		record := []string{
+			stats.Group,
+			strconv.Itoa(stats.Scored),
+			strconv.Itoa(stats.Hits),
+			strconv.Itoa(stats.Misses),
+			strconv.Itoa(stats.Neutral),
+			strconv.Itoa(stats.NoEvents),
+			formatOptional(stats.HitRate),
+			formatFloat(stats.AvgNetSignal),
+			formatOptional(stats.AvgTargetChangePct),
+			formatFloat(stats.MinScore),
+			formatFloat(stats.MaxScore),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestEvaluateBacktestPick(t *testing.T) {
+	asOf := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	goldman, barclays := int64(1), int64(2)
+	day := func(d int) time.Time { return asOf.AddDate(0, 0, d) }
+	recommendation := &domain.StockRecommendation{
+		Stock: &domain.StockWithDetails{Ticker: "AAPL", BrokerageID: &goldman, BrokerageName: "Goldman Sachs"},
+		Score: 7.123456,
+	}
+
+	events := []*domain.StockWithDetails{
+		{ID: 1, BrokerageID: &barclays, ActionCategory: domain.ActionCategoryUpgrade, Time: day(0)},
+		{ID: 2, BrokerageID: &barclays, ActionCategory: domain.ActionCategoryUpgrade, TargetFromAmount: floatPtr(100), TargetToAmount: floatPtr(120), Time: day(2)},
+		{ID: 3, BrokerageID: &goldman, ActionCategory: domain.ActionCategoryDowngrade, Time: day(3)},
+		{ID: 4, ActionCategory: domain.ActionCategoryReiterate, TargetFromAmount: floatPtr(200), TargetToAmount: floatPtr(190), Time: day(5)},
+		{ID: 5, BrokerageID: &barclays, ActionCategory: domain.ActionCategoryDowngrade, Time: day(40)},
+	}
+
+	pick := evaluateBacktestPick(recommendation, events, asOf, day(30))
+
+	assert.Equal(t, 7.1235, pick.Score)
+	assert.Equal(t, 2, pick.LaterEvents, "events at as_of, by the same brokerage or after the horizon are skipped")
+	assert.Equal(t, 1, pick.Upgrades)
+	assert.Equal(t, 0, pick.Downgrades)
+	assert.Equal(t, 1, pick.TargetRaises)
+	assert.Equal(t, 1, pick.TargetCuts)
+	if assert.NotNil(t, pick.AvgTargetChangePct) {
+		assert.InDelta(t, 7.5, *pick.AvgTargetChangePct, 1e-9)
+	}
+	assert.Equal(t, domain.BacktestOutcomeHit, pick.Outcome)
+
+	pick = evaluateBacktestPick(recommendation, events, day(10), day(30))
+	assert.Equal(t, domain.BacktestOutcomeNoEvents, pick.Outcome)
+}
+
+func TestStockUseCase_Backtest(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	from := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	brokerage := int64(1)
+
+	t.Run("Replays every date and summarizes the outcomes", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		mockRepo.On("Stream", mock.Anything, mock.MatchedBy(func(filter domain.StockFilter) bool {
+			return filter.View == domain.StockViewHistory && filter.Time.From.Equal(from) && filter.Time.To.Equal(to.AddDate(0, 0, 30))
+		}), mock.Anything).Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(*domain.StockWithDetails) error)
+			// Streamed newest first; AAPL is upgraded after both dates, MSFT downgraded after the first only
+			fn(&domain.StockWithDetails{Ticker: "AAPL", ActionCategory: domain.ActionCategoryUpgrade, Time: to.AddDate(0, 0, 2)})
+			fn(&domain.StockWithDetails{Ticker: "MSFT", ActionCategory: domain.ActionCategoryDowngrade, Time: from.AddDate(0, 0, 2)})
+		}).Return(nil).Once()
+
+		scored := func(asOf time.Time) interface{} {
+			return mock.MatchedBy(func(filter domain.StockFilter) bool { return filter.AsOf != nil && filter.AsOf.Equal(asOf) })
+		}
+		stocks := func() []*domain.StockWithDetails {
+			return []*domain.StockWithDetails{
+				{Ticker: "AAPL", BrokerageID: &brokerage, ActionCategory: domain.ActionCategoryUpgrade, Time: from.AddDate(0, 0, -1)},
+				{Ticker: "MSFT", BrokerageID: &brokerage, ActionCategory: domain.ActionCategoryDowngrade, Time: from.AddDate(0, 0, -1)},
+			}
+		}
+		mockRepo.On("FindAll", scored(from)).Return(stocks(), nil).Once()
+		mockRepo.On("FindAll", scored(to)).Return(stocks(), nil).Once()
+
+		result, err := useCase.Backtest(context.Background(), domain.BacktestQuery{From: from, To: to, Limit: 1})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		assert.Equal(t, []time.Time{from, to}, result.Dates)
+		assert.Equal(t, domain.AnalyticsIntervalWeek, result.Interval)
+		assert.Equal(t, 30, result.HorizonDays)
+		assert.Equal(t, DefaultStrategy, result.Strategy)
+
+		// AAPL (upgraded) outscores MSFT on both dates and is the only pick
+		if assert.Len(t, result.PickList, 2) {
+			assert.Equal(t, "AAPL", result.PickList[0].Ticker)
+			assert.Equal(t, domain.BacktestOutcomeHit, result.PickList[0].Outcome)
+		}
+		assert.Equal(t, 2, result.Picks.Hits)
+		assert.Equal(t, 1.0, *result.Picks.HitRate)
+
+		assert.Equal(t, 4, result.Universe.Scored)
+		assert.Equal(t, 2, result.Universe.Hits)
+		assert.Equal(t, 1, result.Universe.Misses)
+		assert.Equal(t, 1, result.Universe.NoEvents)
+		assert.InDelta(t, 0.6667, *result.Universe.HitRate, 1e-9)
+		assert.Equal(t, 0.25, result.Universe.AvgNetSignal)
+
+		if assert.Len(t, result.Deciles, 10) {
+			assert.Equal(t, "decile_1", result.Deciles[0].Group)
+			assert.Equal(t, 2, result.Deciles[0].Scored)
+			assert.Equal(t, 2, result.Deciles[5].Scored)
+			assert.Equal(t, 0, result.Deciles[1].Scored)
+			assert.Nil(t, result.Deciles[1].HitRate)
+		}
+	})
+
+	t.Run("Rejects invalid queries", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		for _, query := range []domain.BacktestQuery{
+			{To: to},
+			{From: to, To: from},
+			{From: from, To: time.Now().Add(time.Hour)},
+			{From: from, To: to, Interval: "quarter"},
+			{From: from, To: to, Horizon: 400 * 24 * time.Hour},
+			{From: from.AddDate(-3, 0, 0), To: to},
+			{From: from, To: to, Strategy: "yolo"},
+		} {
+			_, err := useCase.Backtest(context.Background(), query)
+
+			assert.True(t, errors.Is(err, domain.ErrInvalidInput), "%+v", query)
+		}
+		mockRepo.AssertNotCalled(t, "Stream", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Stream error", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+		mockRepo.On("Stream", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("database error")).Once()
+
+		_, err := useCase.Backtest(context.Background(), domain.BacktestQuery{From: from, To: to})
+
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, domain.ErrInvalidInput))
+	})
+}
+
+func TestWriteBacktestCSV(t *testing.T) {
+	hitRate := 0.75
+	result := &domain.BacktestResult{
+		Picks:    domain.BacktestStats{Group: "picks", Scored: 4, Hits: 3, Misses: 1, HitRate: &hitRate, AvgNetSignal: 1.5, MinScore: 6.2, MaxScore: 8},
+		Universe: domain.BacktestStats{Group: "universe"},
+		Deciles:  []domain.BacktestStats{{Group: "decile_1"}},
+	}
+
+	var buf bytes.Buffer
+	err := WriteBacktestCSV(&buf, result)
+
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, []string{
+		"group,scored,hits,misses,neutral,no_events,hit_rate,avg_net_signal,avg_target_change_pct,min_score,max_score",
+		"picks,4,3,1,0,0,0.75,1.5,,6.2,8",
+		"universe,0,0,0,0,0,,0,,0,0",
+		"decile_1,0,0,0,0,0,,0,,0,0",
+	}, lines)
+}
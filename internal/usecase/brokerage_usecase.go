@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/company/stock-api/internal/domain"
 	"go.uber.org/zap"
@@ -33,6 +35,22 @@ func (uc *BrokerageUseCase) GetAll(ctx context.Context) ([]*domain.Brokerage, er
 	return brokerages, nil
 }
 
+// GetByIDs retrieves the brokerages with the given IDs in one query, keyed by ID
+func (uc *BrokerageUseCase) GetByIDs(ctx context.Context, ids []int64) (map[int64]*domain.Brokerage, error) {
+	brokerages, err := uc.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		uc.logger.Error("Failed to retrieve brokerages by IDs", zap.Int("count", len(ids)), zap.Error(err))
+		return nil, fmt.Errorf("failed to retrieve brokerages: %w", err)
+	}
+
+	byID := make(map[int64]*domain.Brokerage, len(brokerages))
+	for _, item := range brokerages {
+		byID[item.ID] = item
+	}
+
+	return byID, nil
+}
+
 // GetByID retrieves a single brokerage by ID
 func (uc *BrokerageUseCase) GetByID(ctx context.Context, id int64) (*domain.Brokerage, error) {
 	brokerage, err := uc.repo.FindByID(id)
@@ -44,6 +62,75 @@ func (uc *BrokerageUseCase) GetByID(ctx context.Context, id int64) (*domain.Brok
 	return brokerage, nil
 }
 
+// GetProfile retrieves a brokerage with its activity over its full history and its most recent events
+func (uc *BrokerageUseCase) GetProfile(ctx context.Context, id int64, recent int) (*domain.BrokerageProfile, error) {
+	if recent <= 0 {
+		recent = 10
+	}
+	if recent > 100 {
+		recent = 100
+	}
+
+	brokerage, err := uc.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, err
+		}
+		uc.logger.Error("Failed to retrieve brokerage", zap.Int64("id", id), zap.Error(err))
+		return nil, fmt.Errorf("failed to retrieve brokerage: %w", err)
+	}
+
+	activity, err := uc.repo.FindActivity(ctx, id)
+	if err != nil {
+		uc.logger.Error("Failed to retrieve brokerage activity", zap.Int64("id", id), zap.Error(err))
+		return nil, fmt.Errorf("failed to retrieve brokerage activity: %w", err)
+	}
+	activity.Upgrades = activity.EventsByCategory[domain.ActionCategoryUpgrade]
+	activity.Downgrades = activity.EventsByCategory[domain.ActionCategoryDowngrade]
+	if activity.Downgrades > 0 {
+		ratio := roundTo(float64(activity.Upgrades)/float64(activity.Downgrades), 2)
+		activity.UpgradeDowngradeRatio = &ratio
+	}
+	activity.AvgTargetChangePct = roundPtr(activity.AvgTargetChangePct)
+
+	events, err := uc.repo.FindRecentEvents(ctx, id, recent)
+	if err != nil {
+		uc.logger.Error("Failed to retrieve brokerage events", zap.Int64("id", id), zap.Error(err))
+		return nil, fmt.Errorf("failed to retrieve brokerage events: %w", err)
+	}
+
+	return &domain.BrokerageProfile{
+		Brokerage:    brokerage,
+		Activity:     activity,
+		RecentEvents: events,
+	}, nil
+}
+
+// GetLeaderboard ranks the brokerages with events between from and to by activity, most active first
+func (uc *BrokerageUseCase) GetLeaderboard(ctx context.Context, from, to time.Time, limit int) ([]*domain.BrokerageLeaderboardEntry, error) {
+	if from.After(to) {
+		return nil, fmt.Errorf("%w: window must start before it ends", domain.ErrInvalidInput)
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	entries, err := uc.repo.FindLeaderboard(ctx, from, to, limit)
+	if err != nil {
+		uc.logger.Error("Failed to retrieve brokerage leaderboard", zap.Error(err))
+		return nil, fmt.Errorf("failed to retrieve brokerage leaderboard: %w", err)
+	}
+
+	for _, entry := range entries {
+		entry.AvgTargetChangePct = roundPtr(entry.AvgTargetChangePct)
+	}
+
+	return entries, nil
+}
+
 // GetOrCreate retrieves a brokerage by name or creates it if it doesn't exist
 func (uc *BrokerageUseCase) GetOrCreate(ctx context.Context, name string) (*domain.Brokerage, error) {
 	// Try to find existing brokerage
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// MockBrokerageRepository is a mock implementation of domain.BrokerageRepository
+type MockBrokerageRepository struct {
+	mock.Mock
+}
+
+func (m *MockBrokerageRepository) Create(brokerage *domain.Brokerage) error {
+	args := m.Called(brokerage)
+	return args.Error(0)
+}
+
+func (m *MockBrokerageRepository) FindByID(id int64) (*domain.Brokerage, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Brokerage), args.Error(1)
+}
+
+func (m *MockBrokerageRepository) FindByName(name string) (*domain.Brokerage, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Brokerage), args.Error(1)
+}
+
+func (m *MockBrokerageRepository) FindAll(ctx context.Context) ([]*domain.Brokerage, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Brokerage), args.Error(1)
+}
+
+func (m *MockBrokerageRepository) FindByIDs(ctx context.Context, ids []int64) ([]*domain.Brokerage, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Brokerage), args.Error(1)
+}
+
+func (m *MockBrokerageRepository) FindActivity(ctx context.Context, id int64) (*domain.BrokerageActivity, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BrokerageActivity), args.Error(1)
+}
+
+func (m *MockBrokerageRepository) FindRecentEvents(ctx context.Context, id int64, limit int) ([]*domain.StockWithDetails, error) {
+	args := m.Called(ctx, id, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.StockWithDetails), args.Error(1)
+}
+
+func (m *MockBrokerageRepository) FindLeaderboard(ctx context.Context, from, to time.Time, limit int) ([]*domain.BrokerageLeaderboardEntry, error) {
+	args := m.Called(ctx, from, to, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.BrokerageLeaderboardEntry), args.Error(1)
+}
+
+func TestBrokerageUseCase_GetProfile(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	brokerage := &domain.Brokerage{ID: 7, Name: "Goldman Sachs"}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockBrokerageRepository)
+		useCase := NewBrokerageUseCase(mockRepo, logger)
+		mockRepo.On("FindByID", int64(7)).Return(brokerage, nil).Once()
+		mockRepo.On("FindActivity", mock.Anything, int64(7)).Return(&domain.BrokerageActivity{
+			Events:         10,
+			TickersCovered: 4,
+			EventsByCategory: map[domain.ActionCategory]int64{
+				domain.ActionCategoryUpgrade:   5,
+				domain.ActionCategoryDowngrade: 3,
+				domain.ActionCategoryOther:     2,
+			},
+			AvgTargetChangePct: floatPtr(4.56789),
+		}, nil).Once()
+		events := []*domain.StockWithDetails{{ID: 1, Ticker: "AAPL"}}
+		mockRepo.On("FindRecentEvents", mock.Anything, int64(7), 10).Return(events, nil).Once()
+
+		profile, err := useCase.GetProfile(context.Background(), 7, 0)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		assert.Equal(t, "Goldman Sachs", profile.Name)
+		assert.Equal(t, int64(5), profile.Activity.Upgrades)
+		assert.Equal(t, int64(3), profile.Activity.Downgrades)
+		if assert.NotNil(t, profile.Activity.UpgradeDowngradeRatio) {
+			assert.Equal(t, 1.67, *profile.Activity.UpgradeDowngradeRatio)
+		}
+		assert.Equal(t, 4.57, *profile.Activity.AvgTargetChangePct)
+		assert.Equal(t, events, profile.RecentEvents)
+	})
+
+	t.Run("No downgrades leaves the ratio empty", func(t *testing.T) {
+		mockRepo := new(MockBrokerageRepository)
+		useCase := NewBrokerageUseCase(mockRepo, logger)
+		mockRepo.On("FindByID", int64(7)).Return(brokerage, nil).Once()
+		mockRepo.On("FindActivity", mock.Anything, int64(7)).Return(&domain.BrokerageActivity{
+			EventsByCategory: map[domain.ActionCategory]int64{domain.ActionCategoryUpgrade: 2},
+		}, nil).Once()
+		mockRepo.On("FindRecentEvents", mock.Anything, int64(7), 100).Return([]*domain.StockWithDetails{}, nil).Once()
+
+		profile, err := useCase.GetProfile(context.Background(), 7, 500)
+
+		assert.NoError(t, err)
+		assert.Nil(t, profile.Activity.UpgradeDowngradeRatio)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		mockRepo := new(MockBrokerageRepository)
+		useCase := NewBrokerageUseCase(mockRepo, logger)
+		mockRepo.On("FindByID", int64(99)).Return(nil, domain.ErrNotFound).Once()
+
+		_, err := useCase.GetProfile(context.Background(), 99, 10)
+
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		mockRepo.AssertNotCalled(t, "FindActivity", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Activity error", func(t *testing.T) {
+		mockRepo := new(MockBrokerageRepository)
+		useCase := NewBrokerageUseCase(mockRepo, logger)
+		mockRepo.On("FindByID", int64(7)).Return(brokerage, nil).Once()
+		mockRepo.On("FindActivity", mock.Anything, int64(7)).Return(nil, errors.New("database error")).Once()
+
+		_, err := useCase.GetProfile(context.Background(), 7, 10)
+
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, domain.ErrNotFound))
+	})
+}
+
+func TestBrokerageUseCase_GetLeaderboard(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	to := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, 0, -30)
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockBrokerageRepository)
+		useCase := NewBrokerageUseCase(mockRepo, logger)
+		mockRepo.On("FindLeaderboard", mock.Anything, from, to, 20).Return([]*domain.BrokerageLeaderboardEntry{
+			{Rank: 1, BrokerageID: 7, Name: "Goldman Sachs", Events: 12, AvgTargetChangePct: floatPtr(3.14159)},
+			{Rank: 2, BrokerageID: 3, Name: "Barclays", Events: 5},
+		}, nil).Once()
+
+		entries, err := useCase.GetLeaderboard(context.Background(), from, to, 0)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		if assert.Len(t, entries, 2) {
+			assert.Equal(t, 3.14, *entries[0].AvgTargetChangePct)
+			assert.Nil(t, entries[1].AvgTargetChangePct)
+		}
+	})
+
+	t.Run("Caps the limit", func(t *testing.T) {
+		mockRepo := new(MockBrokerageRepository)
+		useCase := NewBrokerageUseCase(mockRepo, logger)
+		mockRepo.On("FindLeaderboard", mock.Anything, from, to, 100).Return([]*domain.BrokerageLeaderboardEntry{}, nil).Once()
+
+		_, err := useCase.GetLeaderboard(context.Background(), from, to, 1000)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Rejects a window that ends before it starts", func(t *testing.T) {
+		mockRepo := new(MockBrokerageRepository)
+		useCase := NewBrokerageUseCase(mockRepo, logger)
+
+		_, err := useCase.GetLeaderboard(context.Background(), to, from, 20)
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+	})
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+)
+
+// brokerageOpinion is a brokerage's latest rating and latest price target in a window
+type brokerageOpinion struct {
+	rating *domain.StockWithDetails
+	target *domain.StockWithDetails
+}
+
+// buildConsensus computes the consensus of a ticker from its events in the window, newest first.
+// Events without a brokerage only count towards upgrades and downgrades.
+func buildConsensus(ticker string, from, to time.Time, events []*domain.StockWithDetails) *domain.Consensus {
+	consensus := &domain.Consensus{
+		Ticker:     ticker,
+		WindowFrom: from,
+		WindowTo:   to,
+		Ratings:    make(map[domain.RatingBucket]int, len(domain.RatingBuckets)),
+	}
+	for _, bucket := range domain.RatingBuckets {
+		consensus.Ratings[bucket] = 0
+	}
+
+	opinions := map[int64]*brokerageOpinion{}
+	var order []int64
+	for _, event := range events {
+		switch event.ActionCategory {
+		case domain.ActionCategoryUpgrade:
+			consensus.Upgrades++
+		case domain.ActionCategoryDowngrade:
+			consensus.Downgrades++
+		}
+
+		if event.BrokerageID == nil {
+			continue
+		}
+		opinion, ok := opinions[*event.BrokerageID]
+		if !ok {
+			opinion = &brokerageOpinion{}
+			opinions[*event.BrokerageID] = opinion
+			order = append(order, *event.BrokerageID)
+		}
+		// Events are newest first, so the first rating and target seen are the latest
+		if opinion.rating == nil && event.RatingToTerm != "" {
+			opinion.rating = event
+		}
+		if opinion.target == nil && event.TargetToAmount != nil {
+			opinion.target = event
+		}
+	}
+	consensus.NetUpgrades = consensus.Upgrades - consensus.Downgrades
+	consensus.CoveringBrokerages = len(opinions)
+
+	var scores []float64
+	var targets []*domain.StockWithDetails
+	for _, id := range order {
+		opinion := opinions[id]
+		if opinion.rating != nil && opinion.rating.RatingToBucket != "" {
+			consensus.Ratings[opinion.rating.RatingToBucket]++
+		} else {
+			consensus.Unrated++
+		}
+		if opinion.rating != nil && opinion.rating.RatingToScore != nil {
+			scores = append(scores, *opinion.rating.RatingToScore)
+		}
+		if opinion.target != nil {
+			targets = append(targets, opinion.target)
+		}
+	}
+
+	if len(scores) > 0 {
+		score := roundTo(mean(scores), 2)
+		consensus.MeanRatingScore = &score
+	}
+	consensus.Target = targetConsensus(targets)
+
+	return consensus
+}
+
+// targetConsensus summarizes the targets in the currency most of them use; ties go to the
+// alphabetically first currency. It returns nil without targets.
+func targetConsensus(targets []*domain.StockWithDetails) *domain.TargetConsensus {
+	if len(targets) == 0 {
+		return nil
+	}
+
+	counts := map[string]int{}
+	for _, target := range targets {
+		counts[target.TargetCurrency]++
+	}
+	currency := ""
+	for candidate, count := range counts {
+		if count > counts[currency] || (count == counts[currency] && candidate < currency) {
+			currency = candidate
+		}
+	}
+
+	var amounts []float64
+	for _, target := range targets {
+		if target.TargetCurrency == currency {
+			amounts = append(amounts, *target.TargetToAmount)
+		}
+	}
+	sort.Float64s(amounts)
+
+	average := mean(amounts)
+	var variance float64
+	for _, amount := range amounts {
+		variance += (amount - average) * (amount - average)
+	}
+	variance /= float64(len(amounts))
+
+	median := amounts[len(amounts)/2]
+	if len(amounts)%2 == 0 {
+		median = (amounts[len(amounts)/2-1] + median) / 2
+	}
+
+	return &domain.TargetConsensus{
+		Currency: currency,
+		Count:    len(amounts),
+		Mean:     roundTo(average, 2),
+		Median:   roundTo(median, 2),
+		High:     amounts[len(amounts)-1],
+		Low:      amounts[0],
+		StdDev:   roundTo(math.Sqrt(variance), 2),
+		Excluded: len(targets) - len(amounts),
+	}
+}
+
+// mean returns the arithmetic mean of values, which must not be empty
+func mean(values []float64) float64 {
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
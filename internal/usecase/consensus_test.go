@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestBuildConsensus(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	goldman, barclays, citi, jefferies := int64(1), int64(2), int64(3), int64(4)
+	day := func(d int) time.Time { return from.AddDate(0, 0, d) }
+
+	// Newest first, as the repository returns them
+	events := []*domain.StockWithDetails{
+		{ID: 8, BrokerageID: &goldman, ActionCategory: domain.ActionCategoryUpgrade, RatingToTerm: "Buy", RatingToBucket: domain.RatingBucketBuy, RatingToScore: floatPtr(4), Time: day(60)},
+		{ID: 7, BrokerageID: &barclays, ActionCategory: domain.ActionCategoryTargetLowered, RatingToTerm: "Underweight", RatingToBucket: domain.RatingBucketSell, RatingToScore: floatPtr(2),
+			TargetToAmount: floatPtr(150), TargetCurrency: "USD", Time: day(50)},
+		{ID: 6, BrokerageID: &citi, ActionCategory: domain.ActionCategoryInitiate, RatingToTerm: "Top Pick",
+			TargetToAmount: floatPtr(200), TargetCurrency: "EUR", Time: day(40)},
+		{ID: 5, ActionCategory: domain.ActionCategoryDowngrade, RatingToTerm: "Hold", Time: day(30)},
+		{ID: 4, BrokerageID: &goldman, ActionCategory: domain.ActionCategoryDowngrade, RatingToTerm: "Hold", RatingToBucket: domain.RatingBucketHold, RatingToScore: floatPtr(3),
+			TargetToAmount: floatPtr(210), TargetCurrency: "USD", Time: day(20)},
+		{ID: 3, BrokerageID: &jefferies, ActionCategory: domain.ActionCategoryReiterate, RatingToTerm: "Buy", RatingToBucket: domain.RatingBucketBuy, RatingToScore: floatPtr(4),
+			TargetToAmount: floatPtr(240), TargetCurrency: "USD", Time: day(10)},
+		{ID: 2, BrokerageID: &barclays, ActionCategory: domain.ActionCategoryUpgrade, RatingToTerm: "Equal Weight", RatingToBucket: domain.RatingBucketHold, RatingToScore: floatPtr(3),
+			TargetToAmount: floatPtr(300), TargetCurrency: "USD", Time: day(5)},
+	}
+
+	consensus := buildConsensus("AAPL", from, to, events)
+
+	assert.Equal(t, "AAPL", consensus.Ticker)
+	assert.Equal(t, from, consensus.WindowFrom)
+	assert.Equal(t, to, consensus.WindowTo)
+	assert.Equal(t, 4, consensus.CoveringBrokerages, "events without a brokerage do not cover the ticker")
+	assert.Equal(t, map[domain.RatingBucket]int{
+		domain.RatingBucketStrongSell: 0,
+		domain.RatingBucketSell:       1,
+		domain.RatingBucketHold:       0,
+		domain.RatingBucketBuy:        2,
+		domain.RatingBucketStrongBuy:  0,
+	}, consensus.Ratings, "each brokerage counts once with its latest rating")
+	assert.Equal(t, 1, consensus.Unrated)
+	if assert.NotNil(t, consensus.MeanRatingScore) {
+		assert.Equal(t, 3.33, *consensus.MeanRatingScore)
+	}
+
+	// Latest targets: Goldman 210 (its latest event has none), Barclays 150, Jefferies 240; Citi's EUR target is excluded
+	if assert.NotNil(t, consensus.Target) {
+		assert.Equal(t, domain.TargetConsensus{
+			Currency: "USD", Count: 3, Mean: 200, Median: 210, High: 240, Low: 150, StdDev: 37.42, Excluded: 1,
+		}, *consensus.Target)
+	}
+
+	assert.Equal(t, 2, consensus.Upgrades)
+	assert.Equal(t, 2, consensus.Downgrades)
+	assert.Equal(t, 0, consensus.NetUpgrades)
+}
+
+func TestBuildConsensus_NoEvents(t *testing.T) {
+	consensus := buildConsensus("AAPL", time.Now().AddDate(0, 0, -90), time.Now(), nil)
+
+	assert.Equal(t, 0, consensus.CoveringBrokerages)
+	assert.Len(t, consensus.Ratings, len(domain.RatingBuckets))
+	assert.Nil(t, consensus.MeanRatingScore)
+	assert.Nil(t, consensus.Target)
+}
+
+func TestTargetConsensus_EvenCount(t *testing.T) {
+	targets := []*domain.StockWithDetails{
+		{TargetToAmount: floatPtr(100), TargetCurrency: "USD"},
+		{TargetToAmount: floatPtr(90), TargetCurrency: "EUR"},
+		{TargetToAmount: floatPtr(110), TargetCurrency: "EUR"},
+		{TargetToAmount: floatPtr(120), TargetCurrency: "USD"},
+	}
+
+	target := targetConsensus(targets)
+
+	if assert.NotNil(t, target) {
+		assert.Equal(t, "EUR", target.Currency, "ties go to the alphabetically first currency")
+		assert.Equal(t, 100.0, target.Median)
+		assert.Equal(t, 10.0, target.StdDev)
+		assert.Equal(t, 2, target.Excluded)
+	}
+}
+
+func TestStockUseCase_GetConsensus(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	to := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, 0, -90)
+	window := mock.MatchedBy(func(timeRange domain.TimeRange) bool {
+		return timeRange.From.Equal(from) && timeRange.To.Equal(to)
+	})
+	tickerFilter := domain.StockFilter{Ticker: domain.ValueFilter{In: []string{"AAPL"}}}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+		brokerage := int64(1)
+		mockRepo.On("FindByTicker", "AAPL", window, []string(nil)).Return([]*domain.StockWithDetails{
+			{ID: 1, BrokerageID: &brokerage, RatingToTerm: "Buy", RatingToBucket: domain.RatingBucketBuy, Time: to.AddDate(0, 0, -1)},
+		}, nil).Once()
+
+		consensus, err := useCase.GetConsensus(context.Background(), "AAPL", from, to)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, consensus.CoveringBrokerages)
+		assert.Equal(t, 1, consensus.Ratings[domain.RatingBucketBuy])
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Known ticker without events in the window", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+		mockRepo.On("FindByTicker", "AAPL", window, []string(nil)).Return(nil, domain.ErrNotFound).Once()
+		mockRepo.On("Count", tickerFilter).Return(int64(1), nil).Once()
+
+		consensus, err := useCase.GetConsensus(context.Background(), "AAPL", from, to)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, consensus.CoveringBrokerages)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown ticker", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+		mockRepo.On("FindByTicker", "AAPL", window, []string(nil)).Return(nil, domain.ErrNotFound).Once()
+		mockRepo.On("Count", tickerFilter).Return(int64(0), nil).Once()
+
+		_, err := useCase.GetConsensus(context.Background(), "AAPL", from, to)
+
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+		mockRepo.On("FindByTicker", "AAPL", window, []string(nil)).Return(nil, errors.New("database error")).Once()
+
+		_, err := useCase.GetConsensus(context.Background(), "AAPL", from, to)
+
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, domain.ErrNotFound))
+	})
+
+	t.Run("Rejects a window that ends before it starts", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		_, err := useCase.GetConsensus(context.Background(), "AAPL", to, from)
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+		mockRepo.AssertNotCalled(t, "FindByTicker", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,441 @@
+package usecase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/company/stock-api/internal/domain"
+)
+
+// Limits that keep filter expressions cheap to parse and compile
+const (
+	maxFilterExprLength = 2000
+	maxFilterExprDepth  = 32
+)
+
+type filterTokenKind int
+
+const (
+	tokenEOF filterTokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenDuration
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+	tokenPlus
+	tokenMinus
+)
+
+// filterToken is a lexical token; pos is the 1-based position of its first character
+type filterToken struct {
+	kind filterTokenKind
+	text string
+	pos  int
+}
+
+// describe renders a token for error messages
+func (t filterToken) describe() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenString:
+		return strconv.Quote(t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// ParseFilterExpr parses and type-checks a q= filter expression such as
+//
+//	rating_to in ("Buy","Outperform") and target_change_pct > 15 and brokerage ~ "goldman" and time > now-30d
+//
+// Comparisons reference fields from domain.FilterFields and combine with and, or, not and parentheses.
+// Operators: = != < <= > >= on numbers and times, = != ~ !~ (contains, case-insensitive) on strings,
+// and in/not in lists on strings, enums and numbers. Time values are now, now-30d/now+2w (h, d, w units)
+// or quoted RFC 3339/YYYY-MM-DD strings, evaluated against now.
+func ParseFilterExpr(input string, now time.Time) (domain.FilterExpr, error) {
+	if len(input) > maxFilterExprLength {
+		return nil, &domain.FilterSyntaxError{Position: maxFilterExprLength + 1, Message: fmt.Sprintf("expression longer than %d characters", maxFilterExprLength)}
+	}
+
+	tokens, err := lexFilterExpr(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{tokens: tokens, now: now}
+	expr, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.errorf(tok, "unexpected %s", tok.describe())
+	}
+
+	return expr, nil
+}
+
+// lexFilterExpr splits input into tokens
+func lexFilterExpr(input string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(':
+			tokens = append(tokens, filterToken{kind: tokenLParen, text: "(", pos: pos})
+			i++
+
+		case r == ')':
+			tokens = append(tokens, filterToken{kind: tokenRParen, text: ")", pos: pos})
+			i++
+
+		case r == ',':
+			tokens = append(tokens, filterToken{kind: tokenComma, text: ",", pos: pos})
+			i++
+
+		case r == '+':
+			tokens = append(tokens, filterToken{kind: tokenPlus, text: "+", pos: pos})
+			i++
+
+		case r == '-':
+			tokens = append(tokens, filterToken{kind: tokenMinus, text: "-", pos: pos})
+			i++
+
+		case r == '"' || r == '\'':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				sb.WriteRune(runes[j])
+			}
+			if j >= len(runes) {
+				return nil, &domain.FilterSyntaxError{Position: pos, Message: "unterminated string"}
+			}
+			tokens = append(tokens, filterToken{kind: tokenString, text: sb.String(), pos: pos})
+			i = j + 1
+
+		case r == '=' || r == '~':
+			tokens = append(tokens, filterToken{kind: tokenOperator, text: string(r), pos: pos})
+			i++
+
+		case r == '!' || r == '<' || r == '>':
+			op := string(r)
+			if i+1 < len(runes) && (runes[i+1] == '=' || (r == '!' && runes[i+1] == '~')) {
+				op += string(runes[i+1])
+			}
+			if op == "!" {
+				return nil, &domain.FilterSyntaxError{Position: pos, Message: `expected "!=" or "!~"`}
+			}
+			tokens = append(tokens, filterToken{kind: tokenOperator, text: op, pos: pos})
+			i += len(op)
+
+		case unicode.IsDigit(r) || r == '.':
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			kind := tokenNumber
+			// A number directly followed by letters is a duration such as 30d
+			if j < len(runes) && unicode.IsLetter(runes[j]) {
+				kind = tokenDuration
+				for j < len(runes) && unicode.IsLetter(runes[j]) {
+					j++
+				}
+			}
+			tokens = append(tokens, filterToken{kind: kind, text: string(runes[i:j]), pos: pos})
+			i = j
+
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+				j++
+			}
+			tokens = append(tokens, filterToken{kind: tokenIdent, text: string(runes[i:j]), pos: pos})
+			i = j
+
+		default:
+			return nil, &domain.FilterSyntaxError{Position: pos, Message: fmt.Sprintf("unexpected character %q", r)}
+		}
+	}
+
+	return append(tokens, filterToken{kind: tokenEOF, pos: len(runes) + 1}), nil
+}
+
+// filterParser is a recursive descent parser over lexed tokens
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+	now    time.Time
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() filterToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// isKeyword reports whether tok is the given case-insensitive keyword
+func isKeyword(tok filterToken, keyword string) bool {
+	return tok.kind == tokenIdent && strings.EqualFold(tok.text, keyword)
+}
+
+func (p *filterParser) errorf(tok filterToken, format string, args ...interface{}) error {
+	return &domain.FilterSyntaxError{Position: tok.pos, Message: fmt.Sprintf(format, args...)}
+}
+
+// parseOr parses: and_expr ("or" and_expr)*
+func (p *filterParser) parseOr(depth int) (domain.FilterExpr, error) {
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	for isKeyword(p.peek(), "or") {
+		p.next()
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = domain.FilterOr{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+// parseAnd parses: unary ("and" unary)*
+func (p *filterParser) parseAnd(depth int) (domain.FilterExpr, error) {
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	for isKeyword(p.peek(), "and") {
+		p.next()
+		right, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = domain.FilterAnd{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+// parseUnary parses: "not" unary | "(" or_expr ")" | comparison
+func (p *filterParser) parseUnary(depth int) (domain.FilterExpr, error) {
+	tok := p.peek()
+	if depth > maxFilterExprDepth {
+		return nil, p.errorf(tok, "expression nested deeper than %d levels", maxFilterExprDepth)
+	}
+
+	if isKeyword(tok, "not") {
+		p.next()
+		expr, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return domain.FilterNot{Expr: expr}, nil
+	}
+
+	if tok.kind == tokenLParen {
+		p.next()
+		expr, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, p.errorf(closing, `expected ")" but found %s`, closing.describe())
+		}
+		return expr, nil
+	}
+
+	return p.parseComparison()
+}
+
+// parseComparison parses: field op value | field ["not"] "in" "(" value ("," value)* ")"
+func (p *filterParser) parseComparison() (domain.FilterExpr, error) {
+	fieldTok := p.next()
+	if fieldTok.kind != tokenIdent {
+		return nil, p.errorf(fieldTok, "expected a field name but found %s", fieldTok.describe())
+	}
+
+	name := strings.ToLower(fieldTok.text)
+	field, ok := domain.FilterFields[name]
+	if !ok {
+		return nil, p.errorf(fieldTok, "unknown field %q", fieldTok.text)
+	}
+
+	opTok := p.next()
+	var op domain.FilterOp
+	switch {
+	case opTok.kind == tokenOperator:
+		op = domain.FilterOp(opTok.text)
+	case isKeyword(opTok, "in"):
+		op = domain.FilterOpIn
+	case isKeyword(opTok, "not") && isKeyword(p.peek(), "in"):
+		p.next()
+		op = domain.FilterOpNotIn
+	default:
+		return nil, p.errorf(opTok, "expected an operator after %q but found %s", name, opTok.describe())
+	}
+
+	if !filterOpAllowed(field.Type, op) {
+		return nil, p.errorf(opTok, "operator %q cannot be used with %s field %q", op, field.Type, name)
+	}
+
+	comparison := domain.FilterComparison{Field: name, Op: op}
+
+	if op != domain.FilterOpIn && op != domain.FilterOpNotIn {
+		value, err := p.parseValue(name, field)
+		if err != nil {
+			return nil, err
+		}
+		comparison.Values = []interface{}{value}
+		return comparison, nil
+	}
+
+	if open := p.next(); open.kind != tokenLParen {
+		return nil, p.errorf(open, `expected "(" after %q but found %s`, op, open.describe())
+	}
+	for {
+		value, err := p.parseValue(name, field)
+		if err != nil {
+			return nil, err
+		}
+		comparison.Values = append(comparison.Values, value)
+		if len(comparison.Values) > domain.MaxFilterValues {
+			return nil, p.errorf(p.peek(), "lists accept at most %d values", domain.MaxFilterValues)
+		}
+
+		sep := p.next()
+		if sep.kind == tokenRParen {
+			break
+		}
+		if sep.kind != tokenComma {
+			return nil, p.errorf(sep, `expected "," or ")" but found %s`, sep.describe())
+		}
+	}
+
+	return comparison, nil
+}
+
+// filterOpAllowed reports whether op can be applied to fields of type fieldType
+func filterOpAllowed(fieldType domain.FilterFieldType, op domain.FilterOp) bool {
+	switch op {
+	case domain.FilterOpEq, domain.FilterOpNotEq:
+		return true
+	case domain.FilterOpGt, domain.FilterOpGte, domain.FilterOpLt, domain.FilterOpLte:
+		return fieldType == domain.FilterFieldNumber || fieldType == domain.FilterFieldTime
+	case domain.FilterOpContains, domain.FilterOpNotContains:
+		return fieldType == domain.FilterFieldString
+	case domain.FilterOpIn, domain.FilterOpNotIn:
+		return fieldType != domain.FilterFieldTime
+	default:
+		return false
+	}
+}
+
+// parseValue parses a literal and checks it against the field type
+func (p *filterParser) parseValue(name string, field domain.FilterField) (interface{}, error) {
+	tok := p.next()
+
+	switch field.Type {
+	case domain.FilterFieldString:
+		if tok.kind != tokenString {
+			return nil, p.errorf(tok, "expected a quoted string for %q but found %s", name, tok.describe())
+		}
+		return tok.text, nil
+
+	case domain.FilterFieldEnum:
+		if tok.kind != tokenString && tok.kind != tokenIdent {
+			return nil, p.errorf(tok, "expected a value for %q but found %s", name, tok.describe())
+		}
+		value, ok := field.Normalize(tok.text)
+		if !ok {
+			return nil, p.errorf(tok, "invalid value %q for %q", tok.text, name)
+		}
+		return value, nil
+
+	case domain.FilterFieldNumber:
+		negative := false
+		if tok.kind == tokenMinus {
+			negative = true
+			tok = p.next()
+		}
+		if tok.kind != tokenNumber {
+			return nil, p.errorf(tok, "expected a number for %q but found %s", name, tok.describe())
+		}
+		value, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, p.errorf(tok, "invalid number %q", tok.text)
+		}
+		if negative {
+			value = -value
+		}
+		return value, nil
+
+	case domain.FilterFieldTime:
+		return p.parseTimeValue(tok, name)
+	}
+
+	return nil, p.errorf(tok, "unsupported field %q", name)
+}
+
+// parseTimeValue parses now, now-<n><unit>, now+<n><unit> or a quoted timestamp
+func (p *filterParser) parseTimeValue(tok filterToken, name string) (time.Time, error) {
+	if tok.kind == tokenString {
+		t, err := ParseTimeBound(tok.text, p.now)
+		if err != nil {
+			return time.Time{}, p.errorf(tok, "invalid time %q (use RFC 3339 or YYYY-MM-DD)", tok.text)
+		}
+		return t, nil
+	}
+
+	if !isKeyword(tok, "now") {
+		return time.Time{}, p.errorf(tok, "expected now, now-30d or a quoted date for %q but found %s", name, tok.describe())
+	}
+
+	sign := p.peek()
+	if sign.kind != tokenMinus && sign.kind != tokenPlus {
+		return p.now, nil
+	}
+	p.next()
+
+	durTok := p.next()
+	match := relativeTimePattern.FindStringSubmatch(strings.ToLower(durTok.text))
+	if durTok.kind != tokenDuration || match == nil {
+		return time.Time{}, p.errorf(durTok, "expected a duration like 30d, 24h or 2w but found %s", durTok.describe())
+	}
+	n, err := strconv.Atoi(match[1])
+	if err != nil {
+		return time.Time{}, p.errorf(durTok, "invalid duration %q", durTok.text)
+	}
+
+	offset := time.Duration(n) * relativeTimeUnits[match[2]]
+	if sign.kind == tokenMinus {
+		offset = -offset
+	}
+	return p.now.Add(offset), nil
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFilterExpr(t *testing.T) {
+	now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Full example", func(t *testing.T) {
+		expr, err := ParseFilterExpr(`rating_to in ("Buy","Outperform") and target_change_pct > 15 and brokerage ~ "goldman" and time > now-30d`, now)
+
+		assert.NoError(t, err)
+		expected := domain.FilterAnd{
+			Left: domain.FilterAnd{
+				Left: domain.FilterAnd{
+					Left:  domain.FilterComparison{Field: "rating_to", Op: domain.FilterOpIn, Values: []interface{}{"Buy", "Outperform"}},
+					Right: domain.FilterComparison{Field: "target_change_pct", Op: domain.FilterOpGt, Values: []interface{}{15.0}},
+				},
+				Right: domain.FilterComparison{Field: "brokerage", Op: domain.FilterOpContains, Values: []interface{}{"goldman"}},
+			},
+			Right: domain.FilterComparison{Field: "time", Op: domain.FilterOpGt, Values: []interface{}{now.AddDate(0, 0, -30)}},
+		}
+		assert.Equal(t, expected, expr)
+	})
+
+	t.Run("Precedence, not and grouping", func(t *testing.T) {
+		expr, err := ParseFilterExpr(`ticker = "AAPL" or not (action_category = upgrade and target_to <= -1.5)`, now)
+
+		assert.NoError(t, err)
+		expected := domain.FilterOr{
+			Left: domain.FilterComparison{Field: "ticker", Op: domain.FilterOpEq, Values: []interface{}{"AAPL"}},
+			Right: domain.FilterNot{Expr: domain.FilterAnd{
+				Left:  domain.FilterComparison{Field: "action_category", Op: domain.FilterOpEq, Values: []interface{}{"upgrade"}},
+				Right: domain.FilterComparison{Field: "target_to", Op: domain.FilterOpLte, Values: []interface{}{-1.5}},
+			}},
+		}
+		assert.Equal(t, expected, expr)
+	})
+
+	t.Run("Keywords are case-insensitive and enums are normalized", func(t *testing.T) {
+		expr, err := ParseFilterExpr(`rating_to_bucket NOT IN ('Strong Sell', "sell") AND time >= '2025-01-01'`, now)
+
+		assert.NoError(t, err)
+		expected := domain.FilterAnd{
+			Left:  domain.FilterComparison{Field: "rating_to_bucket", Op: domain.FilterOpNotIn, Values: []interface{}{"strong_sell", "sell"}},
+			Right: domain.FilterComparison{Field: "time", Op: domain.FilterOpGte, Values: []interface{}{time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		}
+		assert.Equal(t, expected, expr)
+	})
+
+	t.Run("Escaped quotes in strings", func(t *testing.T) {
+		expr, err := ParseFilterExpr(`company ~ "O\"Reilly"`, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.FilterComparison{Field: "company", Op: domain.FilterOpContains, Values: []interface{}{`O"Reilly`}}, expr)
+	})
+
+	errorCases := []struct {
+		name     string
+		input    string
+		position int
+		message  string
+	}{
+		{"unknown field", `price > 10`, 1, `unknown field "price"`},
+		{"missing operator", `ticker "AAPL"`, 8, "expected an operator"},
+		{"unterminated string", `ticker = "AAPL`, 10, "unterminated string"},
+		{"unexpected character", `ticker = "AAPL" & time > now`, 17, "unexpected character"},
+		{"number for string field", `ticker = 5`, 10, "expected a quoted string"},
+		{"string for number field", `target_to > "100"`, 13, "expected a number"},
+		{"ordering on string field", `rating_to > "Buy"`, 11, `operator ">" cannot be used with string field`},
+		{"contains on number field", `target_to ~ 5`, 11, `operator "~" cannot be used`},
+		{"invalid enum value", `action_category = sideways`, 19, `invalid value "sideways"`},
+		{"bad duration", `time > now-30y`, 12, "expected a duration"},
+		{"missing closing paren", `(ticker = "AAPL"`, 17, `expected ")"`},
+		{"trailing tokens", `ticker = "AAPL" "MSFT"`, 17, `unexpected "MSFT"`},
+		{"dangling and", `ticker = "AAPL" and`, 20, "expected a field name but found end of expression"},
+		{"unclosed list", `ticker in ("AAPL" "MSFT")`, 19, `expected "," or ")"`},
+	}
+
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseFilterExpr(tc.input, now)
+
+			var syntaxErr *domain.FilterSyntaxError
+			if assert.True(t, errors.As(err, &syntaxErr), "expected a syntax error, got %v", err) {
+				assert.Equal(t, tc.position, syntaxErr.Position)
+				assert.Contains(t, syntaxErr.Message, tc.message)
+			}
+			assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+		})
+	}
+
+	t.Run("Rejects deeply nested expressions", func(t *testing.T) {
+		input := strings.Repeat("(", 100) + `ticker = "AAPL"` + strings.Repeat(")", 100)
+
+		_, err := ParseFilterExpr(input, now)
+
+		assert.ErrorContains(t, err, "nested deeper")
+	})
+}
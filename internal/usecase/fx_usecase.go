@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"go.uber.org/zap"
+)
+
+// FXUseCase handles business logic for FX rates and currency conversion
+type FXUseCase struct {
+	repo   domain.FXRateRepository
+	logger *zap.Logger
+}
+
+// NewFXUseCase creates a new FXUseCase
+func NewFXUseCase(repo domain.FXRateRepository, logger *zap.Logger) *FXUseCase {
+	return &FXUseCase{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// ParseCurrency validates and normalizes a three-letter ISO 4217 currency code
+func ParseCurrency(s string) (string, error) {
+	currency := strings.ToUpper(strings.TrimSpace(s))
+	if !isCurrencyCode(currency) {
+		return "", fmt.Errorf("%w: invalid currency code %q", domain.ErrInvalidInput, s)
+	}
+	return currency, nil
+}
+
+// GetRates retrieves stored FX rates, optionally for a single currency
+func (uc *FXUseCase) GetRates(ctx context.Context, currency string) ([]*domain.FXRate, error) {
+	if currency != "" {
+		var err error
+		if currency, err = ParseCurrency(currency); err != nil {
+			return nil, err
+		}
+	}
+
+	rates, err := uc.repo.FindAll(ctx, currency)
+	if err != nil {
+		uc.logger.Error("Failed to retrieve fx rates", zap.Error(err))
+		return nil, fmt.Errorf("failed to retrieve fx rates: %w", err)
+	}
+
+	return rates, nil
+}
+
+// Import validates and stores FX rates, replacing rates for the same currency and date
+func (uc *FXUseCase) Import(ctx context.Context, rates []*domain.FXRate) (int, error) {
+	for i, rate := range rates {
+		currency, err := ParseCurrency(rate.Currency)
+		if err != nil {
+			return 0, fmt.Errorf("rate %d: %w", i+1, err)
+		}
+		if rate.USDRate <= 0 {
+			return 0, fmt.Errorf("%w: rate %d: usd_rate must be positive", domain.ErrInvalidInput, i+1)
+		}
+		if rate.Date.IsZero() {
+			return 0, fmt.Errorf("%w: rate %d: date is required", domain.ErrInvalidInput, i+1)
+		}
+
+		rate.Currency = currency
+		rate.Date = rate.Date.UTC().Truncate(24 * time.Hour)
+	}
+
+	if err := uc.repo.Upsert(ctx, rates); err != nil {
+		uc.logger.Error("Failed to store fx rates", zap.Error(err))
+		return 0, fmt.Errorf("failed to store fx rates: %w", err)
+	}
+
+	uc.logger.Info("Imported fx rates", zap.Int("count", len(rates)))
+	return len(rates), nil
+}
+
+// ImportCSV reads FX rates from CSV with a "date,currency,usd_rate" header and stores them
+func (uc *FXUseCase) ImportCSV(ctx context.Context, r io.Reader) (int, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to read csv header: %v", domain.ErrInvalidInput, err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"date", "currency", "usd_rate"} {
+		if _, ok := columns[name]; !ok {
+			return 0, fmt.Errorf("%w: csv is missing the %q column", domain.ErrInvalidInput, name)
+		}
+	}
+
+	var rates []*domain.FXRate
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("%w: line %d: %v", domain.ErrInvalidInput, line, err)
+		}
+
+		date, err := time.Parse("2006-01-02", strings.TrimSpace(record[columns["date"]]))
+		if err != nil {
+			return 0, fmt.Errorf("%w: line %d: date must be YYYY-MM-DD", domain.ErrInvalidInput, line)
+		}
+		usdRate, err := strconv.ParseFloat(strings.TrimSpace(record[columns["usd_rate"]]), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: line %d: usd_rate must be a number", domain.ErrInvalidInput, line)
+		}
+
+		rates = append(rates, &domain.FXRate{
+			Currency: record[columns["currency"]],
+			Date:     date,
+			USDRate:  usdRate,
+		})
+	}
+
+	return uc.Import(ctx, rates)
+}
+
+// ConvertStocks converts the price targets of each stock into the reporting currency,
+// using the latest rates on or before the date of each event.
+// Stocks without a currency or without a rate for their date are left unconverted.
+func (uc *FXUseCase) ConvertStocks(ctx context.Context, stocks []*domain.StockWithDetails, currency string) error {
+	if len(stocks) == 0 {
+		return nil
+	}
+
+	// Load every rate needed up to the newest event in one query
+	currencies := map[string]bool{currency: true}
+	var until time.Time
+	for _, stock := range stocks {
+		if stock.TargetCurrency != "" {
+			currencies[stock.TargetCurrency] = true
+		}
+		if stock.Time.After(until) {
+			until = stock.Time
+		}
+	}
+	delete(currencies, domain.ReportingBaseCurrency)
+
+	var rates fxRateTable
+	if len(currencies) > 0 {
+		codes := make([]string, 0, len(currencies))
+		for code := range currencies {
+			codes = append(codes, code)
+		}
+
+		stored, err := uc.repo.FindUpTo(ctx, codes, until)
+		if err != nil {
+			uc.logger.Error("Failed to retrieve fx rates", zap.Error(err))
+			return fmt.Errorf("failed to retrieve fx rates: %w", err)
+		}
+		rates = newFXRateTable(stored)
+	}
+
+	for _, stock := range stocks {
+		if stock.TargetCurrency == "" {
+			continue
+		}
+
+		rate, rateDate, ok := rates.crossRate(stock.TargetCurrency, currency, stock.Time)
+		if !ok {
+			continue
+		}
+
+		converted := &domain.ConvertedTargets{
+			Currency: currency,
+			Rate:     rate,
+			RateDate: rateDate,
+		}
+		if stock.TargetFromAmount != nil {
+			amount := *stock.TargetFromAmount * rate
+			converted.TargetFrom = &amount
+		}
+		if stock.TargetToAmount != nil {
+			amount := *stock.TargetToAmount * rate
+			converted.TargetTo = &amount
+		}
+		stock.Converted = converted
+	}
+
+	return nil
+}
+
+// fxRateTable holds USD rates per currency, sorted by date
+type fxRateTable map[string][]*domain.FXRate
+
+// newFXRateTable groups rates by currency; rates must be sorted by date
+func newFXRateTable(rates []*domain.FXRate) fxRateTable {
+	table := fxRateTable{}
+	for _, rate := range rates {
+		table[rate.Currency] = append(table[rate.Currency], rate)
+	}
+	return table
+}
+
+// usdRate returns the latest USD rate of a currency on or before the given time
+func (t fxRateTable) usdRate(currency string, at time.Time) (float64, time.Time, bool) {
+	if currency == domain.ReportingBaseCurrency {
+		return 1, at.UTC().Truncate(24 * time.Hour), true
+	}
+
+	rates := t[currency]
+	i := sort.Search(len(rates), func(i int) bool {
+		return rates[i].Date.After(at)
+	})
+	if i == 0 {
+		return 0, time.Time{}, false
+	}
+
+	rate := rates[i-1]
+	return rate.USDRate, rate.Date, true
+}
+
+// crossRate returns the rate converting from one currency into another at the given time,
+// along with the date of the oldest rate used
+func (t fxRateTable) crossRate(from, to string, at time.Time) (float64, time.Time, bool) {
+	if from == to {
+		return 1, at.UTC().Truncate(24 * time.Hour), true
+	}
+
+	fromRate, fromDate, ok := t.usdRate(from, at)
+	if !ok {
+		return 0, time.Time{}, false
+	}
+	toRate, toDate, ok := t.usdRate(to, at)
+	if !ok {
+		return 0, time.Time{}, false
+	}
+
+	rateDate := fromDate
+	if toDate.Before(rateDate) {
+		rateDate = toDate
+	}
+	return fromRate / toRate, rateDate, true
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// MockFXRateRepository is a mock implementation of domain.FXRateRepository
+type MockFXRateRepository struct {
+	mock.Mock
+}
+
+func (m *MockFXRateRepository) Upsert(ctx context.Context, rates []*domain.FXRate) error {
+	args := m.Called(ctx, rates)
+	return args.Error(0)
+}
+
+func (m *MockFXRateRepository) FindAll(ctx context.Context, currency string) ([]*domain.FXRate, error) {
+	args := m.Called(ctx, currency)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.FXRate), args.Error(1)
+}
+
+func (m *MockFXRateRepository) FindUpTo(ctx context.Context, currencies []string, until time.Time) ([]*domain.FXRate, error) {
+	args := m.Called(ctx, currencies, until)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.FXRate), args.Error(1)
+}
+
+func mustDate(s string) time.Time {
+	t, _ := time.Parse("2006-01-02", s)
+	return t
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestFXUseCase_ConvertStocks(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	rates := []*domain.FXRate{
+		{Currency: "EUR", Date: mustDate("2025-01-01"), USDRate: 1.10},
+		{Currency: "EUR", Date: mustDate("2025-02-01"), USDRate: 1.20},
+		{Currency: "GBP", Date: mustDate("2025-01-01"), USDRate: 1.25},
+	}
+
+	t.Run("Uses the latest rate on or before the event date", func(t *testing.T) {
+		mockRepo := new(MockFXRateRepository)
+		useCase := NewFXUseCase(mockRepo, logger)
+		mockRepo.On("FindUpTo", mock.Anything, []string{"EUR"}, mock.Anything).Return(rates, nil).Once()
+
+		stocks := []*domain.StockWithDetails{
+			{Ticker: "SAP", TargetToAmount: floatPtr(100), TargetCurrency: "EUR", Time: mustDate("2025-01-15")},
+			{Ticker: "ASML", TargetToAmount: floatPtr(100), TargetCurrency: "EUR", Time: mustDate("2025-02-10")},
+			{Ticker: "AAPL", TargetToAmount: floatPtr(200), TargetCurrency: "USD", Time: mustDate("2025-02-10")},
+		}
+
+		err := useCase.ConvertStocks(context.Background(), stocks, "USD")
+
+		assert.NoError(t, err)
+		assert.InDelta(t, 110.0, *stocks[0].Converted.TargetTo, 1e-9)
+		assert.Equal(t, mustDate("2025-01-01"), stocks[0].Converted.RateDate)
+		assert.InDelta(t, 120.0, *stocks[1].Converted.TargetTo, 1e-9)
+		assert.Equal(t, 1.0, stocks[2].Converted.Rate)
+		assert.Equal(t, 200.0, *stocks[2].Converted.TargetTo)
+	})
+
+	t.Run("Cross rate between non-USD currencies", func(t *testing.T) {
+		mockRepo := new(MockFXRateRepository)
+		useCase := NewFXUseCase(mockRepo, logger)
+		mockRepo.On("FindUpTo", mock.Anything, mock.Anything, mock.Anything).Return(rates, nil).Once()
+
+		stocks := []*domain.StockWithDetails{
+			{Ticker: "SAP", TargetToAmount: floatPtr(125), TargetCurrency: "GBP", Time: mustDate("2025-01-15")},
+		}
+
+		err := useCase.ConvertStocks(context.Background(), stocks, "EUR")
+
+		assert.NoError(t, err)
+		assert.InDelta(t, 1.25/1.10, stocks[0].Converted.Rate, 1e-9)
+	})
+
+	t.Run("Missing rate leaves the stock unconverted", func(t *testing.T) {
+		mockRepo := new(MockFXRateRepository)
+		useCase := NewFXUseCase(mockRepo, logger)
+		mockRepo.On("FindUpTo", mock.Anything, []string{"EUR"}, mock.Anything).Return(rates, nil).Once()
+
+		stocks := []*domain.StockWithDetails{
+			{Ticker: "SAP", TargetToAmount: floatPtr(100), TargetCurrency: "EUR", Time: mustDate("2024-06-01")},
+		}
+
+		err := useCase.ConvertStocks(context.Background(), stocks, "USD")
+
+		assert.NoError(t, err)
+		assert.Nil(t, stocks[0].Converted)
+	})
+}
+
+func TestFXUseCase_ImportCSV(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockFXRateRepository)
+		useCase := NewFXUseCase(mockRepo, logger)
+		mockRepo.On("Upsert", mock.Anything, mock.MatchedBy(func(rates []*domain.FXRate) bool {
+			return len(rates) == 2 && rates[0].Currency == "EUR" && rates[1].USDRate == 1.27
+		})).Return(nil).Once()
+
+		csv := "date,currency,usd_rate\n2025-01-02,eur,1.09\n2025-01-02,GBP,1.27\n"
+		count, err := useCase.ImportCSV(context.Background(), strings.NewReader(csv))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid rate", func(t *testing.T) {
+		useCase := NewFXUseCase(new(MockFXRateRepository), logger)
+
+		csv := "date,currency,usd_rate\n2025-01-02,EUR,abc\n"
+		_, err := useCase.ImportCSV(context.Background(), strings.NewReader(csv))
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	})
+
+	t.Run("Missing column", func(t *testing.T) {
+		useCase := NewFXUseCase(new(MockFXRateRepository), logger)
+
+		_, err := useCase.ImportCSV(context.Background(), strings.NewReader("date,currency\n"))
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	})
+}
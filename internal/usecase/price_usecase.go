@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"go.uber.org/zap"
+)
+
+// Price bar limits
+const (
+	defaultPriceBarLimit = 250
+	maxPriceBarLimit     = 5000
+	// recentPriceAge is how old the last close may be and still count as the current price,
+	// long enough to bridge weekends and holidays
+	recentPriceAge = 7 * 24 * time.Hour
+)
+
+// PriceUseCase handles business logic for daily price bars
+type PriceUseCase struct {
+	repo   domain.PriceBarRepository
+	logger *zap.Logger
+}
+
+// NewPriceUseCase creates a new PriceUseCase
+func NewPriceUseCase(repo domain.PriceBarRepository, logger *zap.Logger) *PriceUseCase {
+	return &PriceUseCase{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// GetPriceBars retrieves the bars of a ticker within the time range, newest first
+func (uc *PriceUseCase) GetPriceBars(ctx context.Context, ticker string, timeRange domain.TimeRange, limit int) ([]*domain.PriceBar, error) {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	if ticker == "" {
+		return nil, fmt.Errorf("%w: ticker is required", domain.ErrInvalidInput)
+	}
+	if timeRange.From != nil && timeRange.To != nil && timeRange.From.After(*timeRange.To) {
+		return nil, fmt.Errorf("%w: time_from must not be after time_to", domain.ErrInvalidInput)
+	}
+	if limit <= 0 {
+		limit = defaultPriceBarLimit
+	}
+	if limit > maxPriceBarLimit {
+		limit = maxPriceBarLimit
+	}
+
+	bars, err := uc.repo.FindByTicker(ctx, ticker, timeRange, limit)
+	if err != nil {
+		uc.logger.Error("Failed to retrieve price bars", zap.String("ticker", ticker), zap.Error(err))
+		return nil, fmt.Errorf("failed to retrieve price bars: %w", err)
+	}
+
+	return bars, nil
+}
+
+// RecentCloses returns the latest bar of each ticker dated within recentPriceAge before now, keyed by ticker.
+// Only bars whose day has ended by now count, so an as_of date never sees its own close.
+// Tickers without a recent bar are left out.
+func (uc *PriceUseCase) RecentCloses(ctx context.Context, tickers []string, now time.Time) (map[string]*domain.PriceBar, error) {
+	closes := map[string]*domain.PriceBar{}
+	if len(tickers) == 0 {
+		return closes, nil
+	}
+
+	bars, err := uc.repo.FindLatest(ctx, tickers, now.Add(-recentPriceAge), now)
+	if err != nil {
+		uc.logger.Error("Failed to retrieve latest price bars", zap.Error(err))
+		return nil, fmt.Errorf("failed to retrieve latest price bars: %w", err)
+	}
+
+	for _, bar := range bars {
+		if closeKnownAt(bar, now) {
+			closes[bar.Ticker] = bar
+		}
+	}
+	return closes, nil
+}
+
+// closeKnownAt reports whether a daily bar's close is known at t, which is once the bar's day has ended
+func closeKnownAt(bar *domain.PriceBar, t time.Time) bool {
+	return !bar.Date.AddDate(0, 0, 1).After(t)
+}
+
+// Import validates and stores price bars, replacing bars for the same ticker and date.
+// Missing open, high and low prices default to the close; a missing currency is USD.
+func (uc *PriceUseCase) Import(ctx context.Context, bars []*domain.PriceBar) (int, error) {
+	for i, bar := range bars {
+		if err := normalizePriceBar(bar); err != nil {
+			return 0, fmt.Errorf("bar %d: %w", i+1, err)
+		}
+	}
+
+	if err := uc.repo.Upsert(ctx, bars); err != nil {
+		uc.logger.Error("Failed to store price bars", zap.Error(err))
+		return 0, fmt.Errorf("failed to store price bars: %w", err)
+	}
+
+	uc.logger.Info("Imported price bars", zap.Int("count", len(bars)))
+	return len(bars), nil
+}
+
+// normalizePriceBar validates a bar and fills in its defaults
+func normalizePriceBar(bar *domain.PriceBar) error {
+	bar.Ticker = strings.ToUpper(strings.TrimSpace(bar.Ticker))
+	if bar.Ticker == "" {
+		return fmt.Errorf("%w: ticker is required", domain.ErrInvalidInput)
+	}
+	if bar.Date.IsZero() {
+		return fmt.Errorf("%w: date is required", domain.ErrInvalidInput)
+	}
+	if bar.Close <= 0 {
+		return fmt.Errorf("%w: close must be positive", domain.ErrInvalidInput)
+	}
+	if bar.Open < 0 || bar.High < 0 || bar.Low < 0 || bar.Volume < 0 {
+		return fmt.Errorf("%w: prices and volume must not be negative", domain.ErrInvalidInput)
+	}
+
+	for _, price := range []*float64{&bar.Open, &bar.High, &bar.Low} {
+		if *price == 0 {
+			*price = bar.Close
+		}
+	}
+	if bar.Low > math.Min(bar.Open, bar.Close) || bar.High < math.Max(bar.Open, bar.Close) {
+		return fmt.Errorf("%w: low and high must bound open and close", domain.ErrInvalidInput)
+	}
+
+	if bar.Currency == "" {
+		bar.Currency = domain.ReportingBaseCurrency
+	}
+	currency, err := ParseCurrency(bar.Currency)
+	if err != nil {
+		return err
+	}
+	bar.Currency = currency
+	bar.Date = bar.Date.UTC().Truncate(24 * time.Hour)
+
+	return nil
+}
+
+// ImportCSV reads price bars from CSV and stores them. The header must name the date, ticker and close
+// columns; open, high, low, volume and currency are optional.
+func (uc *PriceUseCase) ImportCSV(ctx context.Context, r io.Reader) (int, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to read csv header: %v", domain.ErrInvalidInput, err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"date", "ticker", "close"} {
+		if _, ok := columns[name]; !ok {
+			return 0, fmt.Errorf("%w: csv is missing the %q column", domain.ErrInvalidInput, name)
+		}
+	}
+
+	var bars []*domain.PriceBar
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("%w: line %d: %v", domain.ErrInvalidInput, line, err)
+		}
+
+		value := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		date, err := time.Parse("2006-01-02", value("date"))
+		if err != nil {
+			return 0, fmt.Errorf("%w: line %d: date must be YYYY-MM-DD", domain.ErrInvalidInput, line)
+		}
+
+		bar := &domain.PriceBar{Ticker: value("ticker"), Date: date, Currency: value("currency")}
+		prices := []struct {
+			name   string
+			target *float64
+		}{
+			{"open", &bar.Open},
+			{"high", &bar.High},
+			{"low", &bar.Low},
+			{"close", &bar.Close},
+		}
+		for _, price := range prices {
+			if value(price.name) == "" {
+				continue
+			}
+			if *price.target, err = strconv.ParseFloat(value(price.name), 64); err != nil {
+				return 0, fmt.Errorf("%w: line %d: %s must be a number", domain.ErrInvalidInput, line, price.name)
+			}
+		}
+		if value("volume") != "" {
+			if bar.Volume, err = strconv.ParseInt(value("volume"), 10, 64); err != nil {
+				return 0, fmt.Errorf("%w: line %d: volume must be an integer", domain.ErrInvalidInput, line)
+			}
+		}
+
+		bars = append(bars, bar)
+	}
+
+	return uc.Import(ctx, bars)
+}
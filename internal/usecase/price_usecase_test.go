@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// MockPriceBarRepository is a mock implementation of domain.PriceBarRepository
+type MockPriceBarRepository struct {
+	mock.Mock
+}
+
+func (m *MockPriceBarRepository) Upsert(ctx context.Context, bars []*domain.PriceBar) error {
+	args := m.Called(ctx, bars)
+	return args.Error(0)
+}
+
+func (m *MockPriceBarRepository) FindByTicker(ctx context.Context, ticker string, timeRange domain.TimeRange, limit int) ([]*domain.PriceBar, error) {
+	args := m.Called(ctx, ticker, timeRange, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PriceBar), args.Error(1)
+}
+
+func (m *MockPriceBarRepository) FindLatest(ctx context.Context, tickers []string, from, until time.Time) ([]*domain.PriceBar, error) {
+	args := m.Called(ctx, tickers, from, until)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PriceBar), args.Error(1)
+}
+
+func TestPriceUseCase_ImportCSV(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	t.Run("Success with optional columns", func(t *testing.T) {
+		mockRepo := new(MockPriceBarRepository)
+		useCase := NewPriceUseCase(mockRepo, logger)
+		var stored []*domain.PriceBar
+		mockRepo.On("Upsert", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(1).([]*domain.PriceBar)
+		}).Return(nil).Once()
+
+		csv := "date,ticker,open,high,low,close,volume\n2025-03-03,aapl,240,245.5,238,241.2,51000000\n2025-03-03,SAP,,,,250,\n"
+		count, err := useCase.ImportCSV(context.Background(), strings.NewReader(csv))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+		mockRepo.AssertExpectations(t)
+		if assert.Len(t, stored, 2) {
+			assert.Equal(t, domain.PriceBar{
+				Ticker: "AAPL", Date: mustDate("2025-03-03"), Open: 240, High: 245.5, Low: 238, Close: 241.2, Volume: 51000000, Currency: "USD",
+			}, *stored[0])
+			assert.Equal(t, 250.0, stored[1].Open, "missing prices default to the close")
+			assert.Equal(t, 250.0, stored[1].Low)
+		}
+	})
+
+	t.Run("Rejects a low above the close", func(t *testing.T) {
+		useCase := NewPriceUseCase(new(MockPriceBarRepository), logger)
+
+		csv := "date,ticker,low,close\n2025-03-03,AAPL,250,241.2\n"
+		_, err := useCase.ImportCSV(context.Background(), strings.NewReader(csv))
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	})
+
+	t.Run("Rejects a missing close", func(t *testing.T) {
+		useCase := NewPriceUseCase(new(MockPriceBarRepository), logger)
+
+		csv := "date,ticker,close\n2025-03-03,AAPL,\n"
+		_, err := useCase.ImportCSV(context.Background(), strings.NewReader(csv))
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	})
+
+	t.Run("Missing column", func(t *testing.T) {
+		useCase := NewPriceUseCase(new(MockPriceBarRepository), logger)
+
+		_, err := useCase.ImportCSV(context.Background(), strings.NewReader("date,ticker,open\n"))
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	})
+}
+
+func TestPriceUseCase_GetPriceBars(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	t.Run("Normalizes the ticker and caps the limit", func(t *testing.T) {
+		mockRepo := new(MockPriceBarRepository)
+		useCase := NewPriceUseCase(mockRepo, logger)
+		bars := []*domain.PriceBar{{Ticker: "AAPL", Date: mustDate("2025-03-03"), Close: 241.2}}
+		mockRepo.On("FindByTicker", mock.Anything, "AAPL", domain.TimeRange{}, maxPriceBarLimit).Return(bars, nil).Once()
+
+		result, err := useCase.GetPriceBars(context.Background(), " aapl ", domain.TimeRange{}, 100000)
+
+		assert.NoError(t, err)
+		assert.Equal(t, bars, result)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		mockRepo := new(MockPriceBarRepository)
+		useCase := NewPriceUseCase(mockRepo, logger)
+		mockRepo.On("FindByTicker", mock.Anything, "AAPL", domain.TimeRange{}, defaultPriceBarLimit).Return(nil, errors.New("database error")).Once()
+
+		_, err := useCase.GetPriceBars(context.Background(), "AAPL", domain.TimeRange{}, 0)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPriceUseCase_RecentCloses(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	asOf := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	t.Run("A bar dated on the as_of day is not known yet", func(t *testing.T) {
+		repo := new(MockPriceBarRepository)
+		repo.On("FindLatest", mock.Anything, []string{"AAPL"}, asOf.Add(-recentPriceAge), asOf).Return([]*domain.PriceBar{
+			{Ticker: "AAPL", Date: mustDate("2025-03-05"), Close: 240, Currency: "USD"},
+		}, nil).Once()
+		useCase := NewPriceUseCase(repo, logger)
+
+		closes, err := useCase.RecentCloses(context.Background(), []string{"AAPL"}, asOf)
+
+		assert.NoError(t, err)
+		assert.Empty(t, closes)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("The previous day's bar is known", func(t *testing.T) {
+		repo := new(MockPriceBarRepository)
+		repo.On("FindLatest", mock.Anything, []string{"AAPL"}, asOf.Add(-recentPriceAge), asOf).Return([]*domain.PriceBar{
+			{Ticker: "AAPL", Date: mustDate("2025-03-04"), Close: 240, Currency: "USD"},
+		}, nil).Once()
+		useCase := NewPriceUseCase(repo, logger)
+
+		closes, err := useCase.RecentCloses(context.Background(), []string{"AAPL"}, asOf)
+
+		assert.NoError(t, err)
+		if assert.Contains(t, closes, "AAPL") {
+			assert.Equal(t, 240.0, closes["AAPL"].Close)
+		}
+	})
+}
+
+func TestStockUseCase_GetRecommendations_ImpliedUpside(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	asOf := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+	stocks := func() []*domain.StockWithDetails {
+		return []*domain.StockWithDetails{
+			{Ticker: "AAPL", TargetToAmount: floatPtr(300), TargetCurrency: "USD", Time: asOf.Add(-time.Hour)},
+			{Ticker: "SAP", TargetToAmount: floatPtr(300), TargetCurrency: "EUR", Time: asOf.Add(-time.Hour)},
+			{Ticker: "MSFT", TargetToAmount: floatPtr(300), TargetCurrency: "USD", Time: asOf.Add(-time.Hour)},
+		}
+	}
+
+	// Implied upside is opt-in: this profile gives it 15% and the other factors their default ratios
+	path := filepath.Join(t.TempDir(), "profile.yaml")
+	os.WriteFile(path, []byte("weights: {action: 0.255, rating: 0.2125, target: 0.17, recency: 0.1275, brokerage: 0.085, implied_upside: 0.15}\n"), 0o600)
+	scoring, err := NewScoringProfileStore(path, logger)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	stockRepo := new(MockStockRepository)
+	priceRepo := new(MockPriceBarRepository)
+	stockRepo.On("FindAll", mock.Anything).Return(stocks(), nil).Once()
+	priceRepo.On("FindLatest", mock.Anything, []string{"AAPL", "SAP", "MSFT"}, asOf.Add(-recentPriceAge), asOf).Return([]*domain.PriceBar{
+		{Ticker: "AAPL", Date: mustDate("2025-03-04"), Close: 240, Currency: "USD"},
+		{Ticker: "SAP", Date: mustDate("2025-03-04"), Close: 250, Currency: "USD"},
+	}, nil).Once()
+	useCase := NewStockUseCase(stockRepo, nil, nil, nil, nil, NewPriceUseCase(priceRepo, logger), scoring, logger)
+
+	recommendations, err := useCase.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 10, AsOf: &asOf})
+
+	assert.NoError(t, err)
+	priceRepo.AssertExpectations(t)
+	if !assert.Len(t, recommendations, 3) {
+		t.FailNow()
+	}
+
+	// 25% upside scores 10 points at its weight; the others have no comparable close and score 0 on it
+	weights := scoring.Profile().Weights
+	aapl := recommendations[0]
+	assert.Equal(t, "AAPL", aapl.Stock.Ticker)
+	if assert.NotNil(t, aapl.ImpliedUpside) {
+		assert.Equal(t, 25.0, *aapl.ImpliedUpside)
+	}
+	assert.Equal(t, 240.0, *aapl.LastClose)
+	assert.Contains(t, aapl.Reason, "25.0% implied upside to target")
+	for _, recommendation := range recommendations[1:] {
+		assert.Nil(t, recommendation.ImpliedUpside, recommendation.Stock.Ticker)
+		assert.InDelta(t, recommendation.Score+10*weights.ImpliedUpside, aapl.Score, 0.01)
+		if recommendation.Stock.Ticker == "SAP" {
+			assert.NotNil(t, recommendation.LastClose, "the close is shown even when the target currency differs")
+		}
+	}
+
+	t.Run("Without prices the factor is skipped", func(t *testing.T) {
+		stockRepo := new(MockStockRepository)
+		stockRepo.On("FindAll", mock.Anything).Return(stocks(), nil).Once()
+		useCase := NewStockUseCase(stockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		recommendations, err := useCase.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 10, AsOf: &asOf})
+
+		assert.NoError(t, err)
+		for _, recommendation := range recommendations {
+			assert.Nil(t, recommendation.ImpliedUpside)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/company/stock-api/internal/domain"
 	"go.uber.org/zap"
@@ -33,6 +34,22 @@ func (uc *RatingUseCase) GetAll(ctx context.Context) ([]*domain.Rating, error) {
 	return ratings, nil
 }
 
+// GetByIDs retrieves the ratings with the given IDs in one query, keyed by ID
+func (uc *RatingUseCase) GetByIDs(ctx context.Context, ids []int64) (map[int64]*domain.Rating, error) {
+	ratings, err := uc.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		uc.logger.Error("Failed to retrieve ratings by IDs", zap.Int("count", len(ids)), zap.Error(err))
+		return nil, fmt.Errorf("failed to retrieve ratings: %w", err)
+	}
+
+	byID := make(map[int64]*domain.Rating, len(ratings))
+	for _, item := range ratings {
+		byID[item.ID] = item
+	}
+
+	return byID, nil
+}
+
 // GetByID retrieves a single rating by ID
 func (uc *RatingUseCase) GetByID(ctx context.Context, id int64) (*domain.Rating, error) {
 	rating, err := uc.repo.FindByID(id)
@@ -52,10 +69,13 @@ func (uc *RatingUseCase) GetOrCreate(ctx context.Context, term string) (*domain.
 		return rating, nil
 	}
 
-	// Create new rating if not found
+	// Create new rating if not found, mapped onto the canonical scale when the term is known
 	rating = &domain.Rating{
 		Term: term,
 	}
+	if !applyDefaultScale(rating) {
+		uc.logger.Warn("Rating term has no canonical bucket", zap.String("term", term))
+	}
 
 	err = uc.repo.Create(rating)
 	if err != nil {
@@ -70,3 +90,128 @@ func (uc *RatingUseCase) GetOrCreate(ctx context.Context, term string) (*domain.
 		zap.Int64("id", rating.ID))
 	return rating, nil
 }
+
+// defaultRatingScale maps well-known vendor rating terms onto the canonical scale.
+// Terms not listed here stay unmapped until an admin assigns them a bucket.
+// Plain "sell" keeps the bottom score of 1 although it shares the sell bucket.
+var defaultRatingScale = map[string]struct {
+	bucket domain.RatingBucket
+	score  float64
+}{
+	"strong-buy":        {domain.RatingBucketStrongBuy, 5.0},
+	"strong buy":        {domain.RatingBucketStrongBuy, 5.0},
+	"buy":               {domain.RatingBucketBuy, 4.0},
+	"speculative buy":   {domain.RatingBucketBuy, 4.0},
+	"overweight":        {domain.RatingBucketBuy, 4.0},
+	"outperform":        {domain.RatingBucketBuy, 4.0},
+	"market outperform": {domain.RatingBucketBuy, 4.0},
+	"sector outperform": {domain.RatingBucketBuy, 4.0},
+	"positive":          {domain.RatingBucketBuy, 4.0},
+	"hold":              {domain.RatingBucketHold, 3.0},
+	"neutral":           {domain.RatingBucketHold, 3.0},
+	"in-line":           {domain.RatingBucketHold, 3.0},
+	"market perform":    {domain.RatingBucketHold, 3.0},
+	"sector perform":    {domain.RatingBucketHold, 3.0},
+	"equal weight":      {domain.RatingBucketHold, 3.0},
+	"equal-weight":      {domain.RatingBucketHold, 3.0},
+	"underweight":       {domain.RatingBucketSell, 2.0},
+	"underperform":      {domain.RatingBucketSell, 2.0},
+	"reduce":            {domain.RatingBucketSell, 2.0},
+	"sell":              {domain.RatingBucketSell, 1.0},
+	"strong sell":       {domain.RatingBucketStrongSell, 1.0},
+	"strong-sell":       {domain.RatingBucketStrongSell, 1.0},
+}
+
+// applyDefaultScale assigns the default bucket and score for a known term.
+// It returns false if the term has no default mapping.
+func applyDefaultScale(rating *domain.Rating) bool {
+	scale, ok := defaultRatingScale[strings.ToLower(strings.TrimSpace(rating.Term))]
+	if !ok {
+		return false
+	}
+
+	score := scale.score
+	rating.Bucket = scale.bucket
+	rating.Score = &score
+	return true
+}
+
+// UpdateScale assigns a canonical bucket and score to a rating.
+// If score is nil, the bucket's default score is used.
+func (uc *RatingUseCase) UpdateScale(ctx context.Context, id int64, bucket string, score *float64) (*domain.Rating, error) {
+	parsedBucket, ok := domain.ParseRatingBucket(bucket)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown rating bucket %q", domain.ErrInvalidInput, bucket)
+	}
+
+	if score == nil {
+		defaultScore := parsedBucket.DefaultScore()
+		score = &defaultScore
+	}
+	if *score < 1 || *score > 5 {
+		return nil, fmt.Errorf("%w: rating score must be between 1 and 5", domain.ErrInvalidInput)
+	}
+
+	rating := &domain.Rating{
+		ID:     id,
+		Bucket: parsedBucket,
+		Score:  score,
+	}
+
+	if err := uc.repo.UpdateScale(ctx, rating); err != nil {
+		uc.logger.Error("Failed to update rating scale", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	uc.logger.Info("Updated rating scale",
+		zap.Int64("id", id),
+		zap.String("term", rating.Term),
+		zap.String("bucket", string(rating.Bucket)),
+		zap.Float64("score", *rating.Score))
+	return rating, nil
+}
+
+// SeedDefaultScale maps existing unmapped ratings whose terms have a known default
+func (uc *RatingUseCase) SeedDefaultScale(ctx context.Context) (int, error) {
+	ratings, err := uc.repo.FindAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve ratings: %w", err)
+	}
+
+	seeded := 0
+	for _, rating := range ratings {
+		if rating.Bucket != "" || !applyDefaultScale(rating) {
+			continue
+		}
+		if err := uc.repo.UpdateScale(ctx, rating); err != nil {
+			return seeded, fmt.Errorf("failed to seed rating %q: %w", rating.Term, err)
+		}
+		seeded++
+	}
+
+	if seeded > 0 {
+		uc.logger.Info("Seeded default rating scale", zap.Int("count", seeded))
+	}
+	return seeded, nil
+}
+
+// GetQualityReport reports which rating terms are not mapped to the canonical scale
+func (uc *RatingUseCase) GetQualityReport(ctx context.Context) (*domain.RatingQualityReport, error) {
+	ratings, err := uc.repo.FindAll(ctx)
+	if err != nil {
+		uc.logger.Error("Failed to retrieve ratings", zap.Error(err))
+		return nil, fmt.Errorf("failed to retrieve ratings: %w", err)
+	}
+
+	unmapped, err := uc.repo.FindUnmapped(ctx)
+	if err != nil {
+		uc.logger.Error("Failed to retrieve unmapped ratings", zap.Error(err))
+		return nil, fmt.Errorf("failed to retrieve unmapped ratings: %w", err)
+	}
+
+	return &domain.RatingQualityReport{
+		TotalTerms:    len(ratings),
+		MappedTerms:   len(ratings) - len(unmapped),
+		UnmappedTerms: unmapped,
+	}, nil
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// MockRatingRepository is a mock implementation of domain.RatingRepository
+type MockRatingRepository struct {
+	mock.Mock
+}
+
+func (m *MockRatingRepository) Create(rating *domain.Rating) error {
+	args := m.Called(rating)
+	return args.Error(0)
+}
+
+func (m *MockRatingRepository) FindByID(id int64) (*domain.Rating, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Rating), args.Error(1)
+}
+
+func (m *MockRatingRepository) FindByTerm(term string) (*domain.Rating, error) {
+	args := m.Called(term)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Rating), args.Error(1)
+}
+
+func (m *MockRatingRepository) FindByIDs(ctx context.Context, ids []int64) ([]*domain.Rating, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Rating), args.Error(1)
+}
+
+func (m *MockRatingRepository) FindAll(ctx context.Context) ([]*domain.Rating, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Rating), args.Error(1)
+}
+
+func (m *MockRatingRepository) FindUnmapped(ctx context.Context) ([]*domain.UnmappedRating, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.UnmappedRating), args.Error(1)
+}
+
+func (m *MockRatingRepository) UpdateScale(ctx context.Context, rating *domain.Rating) error {
+	args := m.Called(ctx, rating)
+	return args.Error(0)
+}
+
+func TestRatingUseCase_GetOrCreate(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	t.Run("Known term is mapped on creation", func(t *testing.T) {
+		mockRepo := new(MockRatingRepository)
+		useCase := NewRatingUseCase(mockRepo, logger)
+
+		mockRepo.On("FindByTerm", "Overweight").Return(nil, errors.New("not found")).Once()
+		mockRepo.On("Create", mock.MatchedBy(func(r *domain.Rating) bool {
+			return r.Bucket == domain.RatingBucketBuy && r.Score != nil && *r.Score == 4.0
+		})).Return(nil).Once()
+
+		rating, err := useCase.GetOrCreate(context.Background(), "Overweight")
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RatingBucketBuy, rating.Bucket)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Sell keeps its score of 1 in the sell bucket", func(t *testing.T) {
+		mockRepo := new(MockRatingRepository)
+		useCase := NewRatingUseCase(mockRepo, logger)
+
+		mockRepo.On("FindByTerm", "Sell").Return(nil, errors.New("not found")).Once()
+		mockRepo.On("FindByTerm", "Buy").Return(nil, errors.New("not found")).Once()
+		mockRepo.On("Create", mock.Anything).Return(nil).Twice()
+
+		sell, err := useCase.GetOrCreate(context.Background(), "Sell")
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		buy, err := useCase.GetOrCreate(context.Background(), "Buy")
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		assert.Equal(t, domain.RatingBucketSell, sell.Bucket)
+		if assert.NotNil(t, sell.Score) && assert.NotNil(t, buy.Score) {
+			assert.Equal(t, 1.0, *sell.Score)
+			// buy 4 × 2 + improvement (4 - 1) × 2
+			assert.Equal(t, 14.0, getRatingImprovementScore(sell.Score, buy.Score))
+		}
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown term stays unmapped", func(t *testing.T) {
+		mockRepo := new(MockRatingRepository)
+		useCase := NewRatingUseCase(mockRepo, logger)
+
+		mockRepo.On("FindByTerm", "Peer Perform").Return(nil, errors.New("not found")).Once()
+		mockRepo.On("Create", mock.MatchedBy(func(r *domain.Rating) bool {
+			return r.Bucket == "" && r.Score == nil
+		})).Return(nil).Once()
+
+		rating, err := useCase.GetOrCreate(context.Background(), "Peer Perform")
+
+		assert.NoError(t, err)
+		assert.Empty(t, rating.Bucket)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestRatingUseCase_UpdateScale(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	t.Run("Score defaults to bucket position", func(t *testing.T) {
+		mockRepo := new(MockRatingRepository)
+		useCase := NewRatingUseCase(mockRepo, logger)
+
+		mockRepo.On("UpdateScale", mock.Anything, mock.AnythingOfType("*domain.Rating")).Return(nil).Once()
+
+		rating, err := useCase.UpdateScale(context.Background(), 7, "Strong Buy", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RatingBucketStrongBuy, rating.Bucket)
+		assert.Equal(t, 5.0, *rating.Score)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown bucket", func(t *testing.T) {
+		mockRepo := new(MockRatingRepository)
+		useCase := NewRatingUseCase(mockRepo, logger)
+
+		rating, err := useCase.UpdateScale(context.Background(), 7, "accumulate", nil)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+		assert.Nil(t, rating)
+		mockRepo.AssertNotCalled(t, "UpdateScale", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Score out of range", func(t *testing.T) {
+		mockRepo := new(MockRatingRepository)
+		useCase := NewRatingUseCase(mockRepo, logger)
+		score := 7.5
+
+		rating, err := useCase.UpdateScale(context.Background(), 7, "buy", &score)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+		assert.Nil(t, rating)
+	})
+}
@@ -0,0 +1,339 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultScoringSource is the source of the built-in scoring profile
+const defaultScoringSource = "default"
+
+// scoringWeightTolerance is how far the weights may sum from 1
+const scoringWeightTolerance = 1e-6
+
+// DefaultScoringProfile returns the built-in scoring profile
+func DefaultScoringProfile() *domain.ScoringProfile {
+	return &domain.ScoringProfile{
+		Weights: domain.ScoringWeights{
+			Action:    0.30,
+			Rating:    0.25,
+			Target:    0.20,
+			Recency:   0.15,
+			Brokerage: 0.10,
+			// Opt-in by profile: weighing implied upside takes weight from the other factors
+		},
+		ActionScores: map[domain.ActionCategory]float64{
+			domain.ActionCategoryUpgrade:       10,
+			domain.ActionCategoryInitiate:      8,
+			domain.ActionCategoryTargetRaised:  7,
+			domain.ActionCategoryReiterate:     6,
+			domain.ActionCategoryTargetLowered: 3,
+			domain.ActionCategoryDowngrade:     2,
+			domain.ActionCategoryOther:         5,
+		},
+		RecencyBuckets: []domain.RecencyBucket{
+			{MaxDays: 1, Score: 10},
+			{MaxDays: 7, Score: 8},
+			{MaxDays: 30, Score: 6},
+			{MaxDays: 90, Score: 4},
+		},
+		RecencyOlderScore: 2,
+		BrokerageTiers: []domain.BrokerageTier{
+			{Name: "top", Score: 10, Match: []string{"goldman sachs", "morgan stanley", "jp morgan", "jpmorgan", "barclays"}},
+			{Name: "mid", Score: 8, Match: []string{"citigroup", "credit suisse", "deutsche bank", "ubs", "wells fargo"}},
+		},
+		OtherBrokerageScore:   6,
+		UnknownBrokerageScore: 5,
+		Momentum: domain.MomentumProfile{
+			WindowDays: 30,
+			Weights:    domain.MomentumWeights{Action: 0.35, Target: 0.35, Recency: 0.30},
+		},
+		Consensus: domain.ConsensusProfile{
+			MinBrokerages:      2,
+			BullishRatingScore: 4,
+			Weights:            domain.ConsensusWeights{Agreement: 0.5, MeanRating: 0.3, Breadth: 0.2},
+		},
+	}
+}
+
+// LoadScoringProfile reads a scoring profile from a YAML or JSON file (by its .json extension).
+// Keys the file leaves out keep their default values; lists replace the default lists.
+func LoadScoringProfile(path string) (*domain.ScoringProfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scoring profile: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, fmt.Errorf("scoring profile %s is empty", path)
+	}
+
+	profile := DefaultScoringProfile()
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(profile)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(profile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse scoring profile: %w", err)
+	}
+
+	if err := validateScoringProfile(profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// namedWeight is a weight with its profile key, for validation
+type namedWeight struct {
+	name   string
+	weight float64
+}
+
+// validateScoringProfile checks the weights and factor tables of a profile
+func validateScoringProfile(profile *domain.ScoringProfile) error {
+	if err := validateWeights("weights", []namedWeight{
+		{"action", profile.Weights.Action},
+		{"rating", profile.Weights.Rating},
+		{"target", profile.Weights.Target},
+		{"recency", profile.Weights.Recency},
+		{"brokerage", profile.Weights.Brokerage},
+		{"implied_upside", profile.Weights.ImpliedUpside},
+	}); err != nil {
+		return err
+	}
+	if profile.Weights.Base() <= 0 {
+		return fmt.Errorf("weights: action, rating, target, recency and brokerage must not all be 0")
+	}
+
+	for category, score := range profile.ActionScores {
+		if parsed, ok := domain.ParseActionCategory(string(category)); !ok || parsed != category {
+			return fmt.Errorf("action_scores: unknown category %q", category)
+		}
+		if err := validateFactorScore(score); err != nil {
+			return fmt.Errorf("action_scores: %s %w", category, err)
+		}
+	}
+
+	if len(profile.RecencyBuckets) == 0 {
+		return fmt.Errorf("recency_buckets must not be empty")
+	}
+	for i, bucket := range profile.RecencyBuckets {
+		if bucket.MaxDays <= 0 {
+			return fmt.Errorf("recency bucket %d: max_days must be positive", i)
+		}
+		if i > 0 && bucket.MaxDays <= profile.RecencyBuckets[i-1].MaxDays {
+			return fmt.Errorf("recency bucket %d: max_days must be greater than the previous bucket's", i)
+		}
+		if err := validateFactorScore(bucket.Score); err != nil {
+			return fmt.Errorf("recency bucket %d: score %w", i, err)
+		}
+	}
+	if err := validateFactorScore(profile.RecencyOlderScore); err != nil {
+		return fmt.Errorf("recency_older_score %w", err)
+	}
+
+	for i, tier := range profile.BrokerageTiers {
+		if tier.Name == "" {
+			return fmt.Errorf("brokerage tier %d: name is required", i)
+		}
+		if len(tier.Match) == 0 {
+			return fmt.Errorf("brokerage tier %d: match must not be empty", i)
+		}
+		for _, match := range tier.Match {
+			if strings.TrimSpace(match) == "" {
+				return fmt.Errorf("brokerage tier %d: match must not contain empty names", i)
+			}
+		}
+		if err := validateFactorScore(tier.Score); err != nil {
+			return fmt.Errorf("brokerage tier %d: score %w", i, err)
+		}
+	}
+	if err := validateFactorScore(profile.OtherBrokerageScore); err != nil {
+		return fmt.Errorf("other_brokerage_score %w", err)
+	}
+	if err := validateFactorScore(profile.UnknownBrokerageScore); err != nil {
+		return fmt.Errorf("unknown_brokerage_score %w", err)
+	}
+
+	momentum := profile.Momentum
+	if momentum.WindowDays <= 0 {
+		return fmt.Errorf("momentum: window_days must be positive")
+	}
+	if err := validateWeights("momentum.weights", []namedWeight{
+		{"action", momentum.Weights.Action},
+		{"target", momentum.Weights.Target},
+		{"recency", momentum.Weights.Recency},
+	}); err != nil {
+		return err
+	}
+
+	consensus := profile.Consensus
+	if consensus.MinBrokerages < 1 {
+		return fmt.Errorf("consensus: min_brokerages must be at least 1")
+	}
+	if consensus.BullishRatingScore < 1 || consensus.BullishRatingScore > 5 {
+		return fmt.Errorf("consensus: bullish_rating_score must be between 1 and 5")
+	}
+	if err := validateWeights("consensus.weights", []namedWeight{
+		{"agreement", consensus.Weights.Agreement},
+		{"mean_rating", consensus.Weights.MeanRating},
+		{"breadth", consensus.Weights.Breadth},
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateWeights checks that each weight of a section is between 0 and 1 and that they sum to 1
+func validateWeights(section string, weights []namedWeight) error {
+	sum := 0.0
+	names := make([]string, len(weights))
+	for i, w := range weights {
+		if w.weight < 0 || w.weight > 1 {
+			return fmt.Errorf("%s: %s must be between 0 and 1", section, w.name)
+		}
+		sum += w.weight
+		names[i] = w.name
+	}
+	if math.Abs(sum-1) > scoringWeightTolerance {
+		return fmt.Errorf("%s: %s must sum to 1, got %g", section, strings.Join(names, ", "), sum)
+	}
+	return nil
+}
+
+// validateFactorScore checks that a factor score is on the 0-10 scale
+func validateFactorScore(score float64) error {
+	if score < 0 || score > 10 {
+		return fmt.Errorf("must be between 0 and 10")
+	}
+	return nil
+}
+
+// ScoringProfileStore holds the active scoring profile and reloads it from its file
+type ScoringProfileStore struct {
+	path   string
+	logger *zap.Logger
+
+	mu      sync.RWMutex
+	active  domain.ActiveScoringProfile
+	modTime time.Time
+}
+
+// NewScoringProfileStore loads the scoring profile at path, or the default profile when path is empty
+func NewScoringProfileStore(path string, logger *zap.Logger) (*ScoringProfileStore, error) {
+	store := &ScoringProfileStore{path: path, logger: logger}
+	if path == "" {
+		store.active = domain.ActiveScoringProfile{
+			Source:   defaultScoringSource,
+			LoadedAt: time.Now(),
+			Profile:  DefaultScoringProfile(),
+		}
+		return store, nil
+	}
+
+	if err := store.Reload(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// Active returns the active scoring profile. The profile must not be modified.
+func (s *ScoringProfileStore) Active() domain.ActiveScoringProfile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.active
+}
+
+// Profile returns the active profile's weights and tables. The profile must not be modified.
+func (s *ScoringProfileStore) Profile() *domain.ScoringProfile {
+	return s.Active().Profile
+}
+
+// Reload reads the profile file again. An invalid file keeps the active profile.
+func (s *ScoringProfileStore) Reload() error {
+	if s.path == "" {
+		return nil
+	}
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to read scoring profile: %w", err)
+	}
+	profile, err := LoadScoringProfile(s.path)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.active = domain.ActiveScoringProfile{Source: s.path, LoadedAt: time.Now(), Profile: profile}
+	s.modTime = info.ModTime()
+	s.mu.Unlock()
+
+	s.logger.Info("Loaded scoring profile", zap.String("path", s.path))
+	return nil
+}
+
+// Watch reloads the profile every interval when its file was modified, until ctx is done.
+// Failed reloads are logged and keep the active profile.
+func (s *ScoringProfileStore) Watch(ctx context.Context, interval time.Duration) {
+	if s.path == "" || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.modified() {
+				continue
+			}
+			if err := s.Reload(); err != nil {
+				s.logger.Error("Failed to reload scoring profile; keeping the active profile", zap.Error(err))
+				// Do not retry the same broken file every tick
+				s.markSeen()
+			}
+		}
+	}
+}
+
+// modified reports whether the profile file changed since it was last loaded or seen
+func (s *ScoringProfileStore) modified() bool {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return !info.ModTime().Equal(s.modTime)
+}
+
+// markSeen records the file's current modification time without loading it
+func (s *ScoringProfileStore) markSeen() {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	s.modTime = info.ModTime()
+	s.mu.Unlock()
+}
@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestDefaultScoringProfile(t *testing.T) {
+	profile := DefaultScoringProfile()
+
+	assert.NoError(t, validateScoringProfile(profile))
+	assert.Equal(t, 10.0, factorScore(profile.ActionScore(domain.ActionCategoryUpgrade)))
+	assert.Equal(t, 5.0, factorScore(profile.ActionScore("unclassified")), "unknown categories score as other")
+	assert.Equal(t, 10.0, factorScore(profile.RecencyScore(0.5)))
+	assert.Equal(t, 6.0, factorScore(profile.RecencyScore(30)))
+	assert.Equal(t, 2.0, factorScore(profile.RecencyScore(365)))
+	assert.Equal(t, 10.0, factorScore(profile.BrokerageScore(" JPMorgan Chase & Co. ")))
+	assert.Equal(t, 8.0, factorScore(profile.BrokerageScore("UBS Group")))
+	assert.Equal(t, 6.0, factorScore(profile.BrokerageScore("Needham")))
+	assert.Equal(t, 5.0, factorScore(profile.BrokerageScore("")))
+}
+
+func TestLoadScoringProfile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, []byte(content), 0o600)
+		return path
+	}
+
+	t.Run("YAML overrides the defaults", func(t *testing.T) {
+		path := write("profile.yaml", `
+weights:
+  action: 0.3
+  rating: 0.2
+  target: 0.15
+  recency: 0.1
+  brokerage: 0.1
+  implied_upside: 0.15
+action_scores:
+  reiterate: 4
+brokerage_tiers:
+  - name: favorites
+    score: 9
+    match: [needham]
+`)
+
+		profile, err := LoadScoringProfile(path)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.3, profile.Weights.Action)
+		assert.Equal(t, 0.15, profile.Weights.ImpliedUpside)
+		assert.Equal(t, 4.0, factorScore(profile.ActionScore(domain.ActionCategoryReiterate)))
+		assert.Equal(t, 10.0, factorScore(profile.ActionScore(domain.ActionCategoryUpgrade)))
+		assert.Equal(t, 9.0, factorScore(profile.BrokerageScore("Needham & Co")))
+		assert.Equal(t, 6.0, factorScore(profile.BrokerageScore("Goldman Sachs")), "tiers replace the default tiers")
+	})
+
+	t.Run("Strategy sections", func(t *testing.T) {
+		path := write("strategies.yaml", `
+momentum:
+  window_days: 14
+consensus:
+  min_brokerages: 3
+  weights: {agreement: 0.6, mean_rating: 0.2, breadth: 0.2}
+`)
+
+		profile, err := LoadScoringProfile(path)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 14.0, profile.Momentum.WindowDays)
+		assert.Equal(t, 0.35, profile.Momentum.Weights.Action, "keys left out keep their defaults")
+		assert.Equal(t, 3, profile.Consensus.MinBrokerages)
+		assert.Equal(t, 4.0, profile.Consensus.BullishRatingScore)
+		assert.Equal(t, 0.6, profile.Consensus.Weights.Agreement)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		path := write("profile.json", `{"recency_buckets": [{"max_days": 14, "score": 9}], "recency_older_score": 1}`)
+
+		profile, err := LoadScoringProfile(path)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 9.0, factorScore(profile.RecencyScore(10)))
+		assert.Equal(t, 1.0, factorScore(profile.RecencyScore(20)))
+	})
+
+	invalid := map[string]string{
+		"weights not summing to 1":    "weights:\n  action: 0.5\n",
+		"implied upside on top":       "weights: {implied_upside: 0.15}\n",
+		"only implied upside":         "weights: {action: 0, rating: 0, target: 0, recency: 0, brokerage: 0, implied_upside: 1}\n",
+		"unknown key":                 "weigths:\n  action: 0.3\n",
+		"unknown weight":              "weights:\n  momentum: 0.1\n",
+		"unknown action category":     "action_scores:\n  sideways: 5\n",
+		"action score out of range":   "action_scores:\n  upgrade: 11\n",
+		"unsorted recency buckets":    "recency_buckets:\n  - {max_days: 7, score: 8}\n  - {max_days: 1, score: 10}\n",
+		"empty recency buckets":       "recency_buckets: []\n",
+		"brokerage tier without name": "brokerage_tiers:\n  - {score: 9, match: [ubs]}\n",
+		"momentum without a window":   "momentum:\n  window_days: 0\n",
+		"momentum weights":            "momentum:\n  weights: {action: 0.5, target: 0.5, recency: 0.5}\n",
+		"consensus min_brokerages":    "consensus:\n  min_brokerages: 0\n",
+		"consensus bullish score":     "consensus:\n  bullish_rating_score: 6\n",
+		"consensus weights":           "consensus:\n  weights: {agreement: 1, mean_rating: 0.3}\n",
+		"empty file":                  "",
+	}
+	for name, content := range invalid {
+		t.Run("Rejects "+name, func(t *testing.T) {
+			_, err := LoadScoringProfile(write("invalid.yaml", content))
+
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("Unknown JSON key", func(t *testing.T) {
+		_, err := LoadScoringProfile(write("invalid.json", `{"weights": {"action": 0.3}, "extra": true}`))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := LoadScoringProfile(filepath.Join(dir, "missing.yaml"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestScoringProfileStore(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	t.Run("Default profile without a file", func(t *testing.T) {
+		store, err := NewScoringProfileStore("", logger)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "default", store.Active().Source)
+		assert.Equal(t, DefaultScoringProfile(), store.Profile())
+	})
+
+	t.Run("Invalid file at startup", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "profile.yaml")
+		os.WriteFile(path, []byte("weights:\n  action: 0.9\n"), 0o600)
+
+		_, err := NewScoringProfileStore(path, logger)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Watch reloads changed files and keeps the profile on invalid ones", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "profile.yaml")
+		os.WriteFile(path, []byte("action_scores:\n  reiterate: 4\n"), 0o600)
+		store, err := NewScoringProfileStore(path, logger)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		assert.Equal(t, path, store.Active().Source)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go store.Watch(ctx, 10*time.Millisecond)
+
+		// Modification times may not advance between quick writes, so set them explicitly
+		os.WriteFile(path, []byte("action_scores:\n  reiterate: 3\n"), 0o600)
+		os.Chtimes(path, time.Now(), time.Now().Add(time.Minute))
+		assert.Eventually(t, func() bool {
+			return factorScore(store.Profile().ActionScore(domain.ActionCategoryReiterate)) == 3
+		}, time.Second, 10*time.Millisecond)
+
+		os.WriteFile(path, []byte("weights:\n  action: 0.9\n"), 0o600)
+		os.Chtimes(path, time.Now(), time.Now().Add(2*time.Minute))
+		assert.Eventually(t, func() bool { return !store.modified() }, time.Second, 10*time.Millisecond)
+		assert.Equal(t, 3.0, factorScore(store.Profile().ActionScore(domain.ActionCategoryReiterate)))
+
+		assert.Error(t, store.Reload())
+	})
+}
+
+func TestStockUseCase_GetRecommendations_ScoringProfile(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	asOf := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+	stocks := func() []*domain.StockWithDetails {
+		return []*domain.StockWithDetails{
+			{Ticker: "AAPL", ActionCategory: domain.ActionCategoryUpgrade, BrokerageName: "Needham", Time: asOf.Add(-60 * 24 * time.Hour)},
+			{Ticker: "MSFT", ActionCategory: domain.ActionCategoryReiterate, BrokerageName: "Needham", Time: asOf.Add(-time.Hour)},
+		}
+	}
+
+	t.Run("Default profile favors the upgrade", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		mockRepo.On("FindAll", mock.Anything).Return(stocks(), nil).Once()
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		recommendations, err := useCase.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 10, AsOf: &asOf})
+
+		assert.NoError(t, err)
+		if assert.Len(t, recommendations, 2) {
+			assert.Equal(t, "AAPL", recommendations[0].Stock.Ticker)
+		}
+	})
+
+	t.Run("A recency-heavy profile favors the recent event", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "profile.yaml")
+		os.WriteFile(path, []byte("weights: {action: 0.1, rating: 0.1, target: 0.1, recency: 0.6, brokerage: 0.1}\n"), 0o600)
+		store, err := NewScoringProfileStore(path, logger)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		mockRepo := new(MockStockRepository)
+		mockRepo.On("FindAll", mock.Anything).Return(stocks(), nil).Once()
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, store, logger)
+
+		recommendations, err := useCase.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 10, AsOf: &asOf})
+
+		assert.NoError(t, err)
+		if assert.Len(t, recommendations, 2) {
+			assert.Equal(t, "MSFT", recommendations[0].Stock.Ticker)
+		}
+		assert.Equal(t, path, useCase.ScoringProfile().Source)
+	})
+}
+
+// factorScore drops the rule from a scoring profile lookup
+func factorScore(score float64, _ string) float64 {
+	return score
+}
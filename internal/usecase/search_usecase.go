@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/company/stock-api/internal/domain"
+	"go.uber.org/zap"
+)
+
+// Search limits
+const (
+	maxSearchQueryLength   = 100
+	defaultSearchLimit     = 20
+	maxSearchLimit         = 100
+	defaultSuggestionLimit = 10
+	maxSuggestionLimit     = 25
+)
+
+// SearchUseCase handles free-text search and typeahead suggestions
+type SearchUseCase struct {
+	repo      domain.SearchRepository
+	threshold float64
+	logger    *zap.Logger
+}
+
+// NewSearchUseCase creates a new SearchUseCase; threshold is the minimum trigram similarity for fuzzy matches
+func NewSearchUseCase(repo domain.SearchRepository, threshold float64, logger *zap.Logger) *SearchUseCase {
+	return &SearchUseCase{
+		repo:      repo,
+		threshold: threshold,
+		logger:    logger,
+	}
+}
+
+// Search returns tickers, companies and brokerages ranked by similarity to query, with the matched field highlighted
+func (uc *SearchUseCase) Search(ctx context.Context, query string, limit int) ([]*domain.SearchResult, error) {
+	query, err := normalizeSearchQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	limit = clampLimit(limit, defaultSearchLimit, maxSearchLimit)
+
+	results, err := uc.repo.Search(ctx, query, uc.threshold, limit)
+	if err != nil {
+		uc.logger.Error("Failed to search", zap.String("query", query), zap.Error(err))
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+
+	for _, result := range results {
+		value := result.Brokerage
+		switch result.MatchedField {
+		case "ticker":
+			value = result.Ticker
+		case "company":
+			value = result.Company
+		}
+		result.Highlight = highlightMatch(value, query, uc.threshold)
+	}
+
+	return results, nil
+}
+
+// Suggest returns prefix completions for search boxes
+func (uc *SearchUseCase) Suggest(ctx context.Context, prefix string, limit int) ([]*domain.SearchSuggestion, error) {
+	prefix, err := normalizeSearchQuery(prefix)
+	if err != nil {
+		return nil, err
+	}
+	limit = clampLimit(limit, defaultSuggestionLimit, maxSuggestionLimit)
+
+	suggestions, err := uc.repo.Suggest(ctx, prefix, limit)
+	if err != nil {
+		uc.logger.Error("Failed to get suggestions", zap.String("prefix", prefix), zap.Error(err))
+		return nil, fmt.Errorf("failed to get suggestions: %w", err)
+	}
+
+	return suggestions, nil
+}
+
+// normalizeSearchQuery trims the query and checks its length
+func normalizeSearchQuery(query string) (string, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return "", fmt.Errorf("%w: q is required", domain.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(query) > maxSearchQueryLength {
+		return "", fmt.Errorf("%w: q must be at most %d characters", domain.ErrInvalidInput, maxSearchQueryLength)
+	}
+	return query, nil
+}
+
+// clampLimit applies a default to non-positive limits and caps large ones
+func clampLimit(limit, defaultLimit, maxLimit int) int {
+	if limit <= 0 {
+		return defaultLimit
+	}
+	if limit > maxLimit {
+		return maxLimit
+	}
+	return limit
+}
+
+// highlightMatch HTML-escapes value and wraps the parts matching query in <mark> tags.
+// Case-insensitive substrings of the query (or of its words) are preferred; for fuzzy
+// matches, words of value whose trigram similarity to a query word reaches threshold are marked.
+func highlightMatch(value, query string, threshold float64) string {
+	runes := []rune(value)
+	lower := lowerRunes(value)
+	marked := make([]bool, len(runes))
+
+	markSubstring := func(term string) bool {
+		termRunes := lowerRunes(term)
+		found := false
+		for i := 0; len(termRunes) > 0 && i+len(termRunes) <= len(lower); i++ {
+			if string(lower[i:i+len(termRunes)]) == string(termRunes) {
+				for j := i; j < i+len(termRunes); j++ {
+					marked[j] = true
+				}
+				found = true
+			}
+		}
+		return found
+	}
+
+	if !markSubstring(query) {
+		found := false
+		for _, term := range strings.Fields(query) {
+			if markSubstring(term) {
+				found = true
+			}
+		}
+
+		if !found {
+			for _, word := range wordSpans(runes) {
+				for _, term := range strings.Fields(query) {
+					if trigramSimilarity(string(runes[word[0]:word[1]]), term) >= threshold {
+						for j := word[0]; j < word[1]; j++ {
+							marked[j] = true
+						}
+					}
+				}
+			}
+		}
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && marked[j] == marked[i] {
+			j++
+		}
+		segment := html.EscapeString(string(runes[i:j]))
+		if marked[i] {
+			sb.WriteString("<mark>" + segment + "</mark>")
+		} else {
+			sb.WriteString(segment)
+		}
+		i = j
+	}
+	return sb.String()
+}
+
+// lowerRunes lower-cases s rune by rune, keeping offsets aligned with []rune(s)
+func lowerRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+// wordSpans returns [start, end) rune offsets of alphanumeric words
+func wordSpans(runes []rune) [][2]int {
+	var spans [][2]int
+	start := -1
+	for i, r := range runes {
+		isWordRune := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isWordRune && start < 0 {
+			start = i
+		}
+		if !isWordRune && start >= 0 {
+			spans = append(spans, [2]int{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, [2]int{start, len(runes)})
+	}
+	return spans
+}
+
+// trigramSimilarity mirrors pg_trgm's similarity(): shared trigrams over all distinct trigrams
+func trigramSimilarity(a, b string) float64 {
+	trigramsA, trigramsB := trigrams(a), trigrams(b)
+	if len(trigramsA) == 0 || len(trigramsB) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for trigram := range trigramsA {
+		if trigramsB[trigram] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(trigramsA)+len(trigramsB)-shared)
+}
+
+// trigrams extracts pg_trgm-style trigrams: each lower-cased word padded with two leading spaces and one trailing space
+func trigrams(s string) map[string]bool {
+	set := map[string]bool{}
+	runes := lowerRunes(s)
+	for _, span := range wordSpans(runes) {
+		padded := append([]rune("  "), runes[span[0]:span[1]]...)
+		padded = append(padded, ' ')
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+	}
+	return set
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// MockSearchRepository is a mock implementation of domain.SearchRepository
+type MockSearchRepository struct {
+	mock.Mock
+}
+
+func (m *MockSearchRepository) Search(ctx context.Context, query string, threshold float64, limit int) ([]*domain.SearchResult, error) {
+	args := m.Called(ctx, query, threshold, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.SearchResult), args.Error(1)
+}
+
+func (m *MockSearchRepository) Suggest(ctx context.Context, prefix string, limit int) ([]*domain.SearchSuggestion, error) {
+	args := m.Called(ctx, prefix, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.SearchSuggestion), args.Error(1)
+}
+
+func TestSearchUseCase_Search(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	t.Run("Highlights the matched field", func(t *testing.T) {
+		mockRepo := new(MockSearchRepository)
+		useCase := NewSearchUseCase(mockRepo, 0.3, logger)
+
+		mockRepo.On("Search", mock.Anything, "goldman", 0.3, 20).Return([]*domain.SearchResult{
+			{Type: domain.SearchResultBrokerage, BrokerageID: 7, Brokerage: "The Goldman Sachs Group", MatchedField: "brokerage", Score: 0.42},
+			{Type: domain.SearchResultStock, Ticker: "GS", Company: "Goldman Sachs & Co.", MatchedField: "company", Score: 0.4},
+		}, nil).Once()
+
+		results, err := useCase.Search(context.Background(), "  goldman ", 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, "The <mark>Goldman</mark> Sachs Group", results[0].Highlight)
+		assert.Equal(t, "<mark>Goldman</mark> Sachs &amp; Co.", results[1].Highlight)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Caps the limit", func(t *testing.T) {
+		mockRepo := new(MockSearchRepository)
+		useCase := NewSearchUseCase(mockRepo, 0.5, logger)
+
+		mockRepo.On("Search", mock.Anything, "AAPL", 0.5, 100).Return([]*domain.SearchResult{}, nil).Once()
+
+		_, err := useCase.Search(context.Background(), "AAPL", 5000)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Rejects empty and overly long queries", func(t *testing.T) {
+		mockRepo := new(MockSearchRepository)
+		useCase := NewSearchUseCase(mockRepo, 0.3, logger)
+
+		_, err := useCase.Search(context.Background(), "   ", 10)
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+		_, err = useCase.Search(context.Background(), strings.Repeat("a", maxSearchQueryLength+1), 10)
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+		mockRepo.AssertNotCalled(t, "Search")
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		mockRepo := new(MockSearchRepository)
+		useCase := NewSearchUseCase(mockRepo, 0.3, logger)
+
+		mockRepo.On("Search", mock.Anything, "apple", 0.3, 20).Return(nil, errors.New("database error")).Once()
+
+		results, err := useCase.Search(context.Background(), "apple", 0)
+
+		assert.Error(t, err)
+		assert.Nil(t, results)
+	})
+}
+
+func TestSearchUseCase_Suggest(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	mockRepo := new(MockSearchRepository)
+	useCase := NewSearchUseCase(mockRepo, 0.3, logger)
+
+	expected := []*domain.SearchSuggestion{
+		{Field: "ticker", Value: "AAPL", Ticker: "AAPL"},
+		{Field: "company", Value: "Apple Inc.", Ticker: "AAPL"},
+	}
+	mockRepo.On("Suggest", mock.Anything, "ap", 10).Return(expected, nil).Once()
+
+	suggestions, err := useCase.Suggest(context.Background(), "ap", 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, suggestions)
+
+	_, err = useCase.Suggest(context.Background(), "", 10)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestHighlightMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		query    string
+		expected string
+	}{
+		{"whole query substring", "Apple Inc.", "app", "<mark>App</mark>le Inc."},
+		{"adjacent occurrences merge", "Banana", "an", "B<mark>anan</mark>a"},
+		{"query words", "Morgan Stanley", "stanley morgan", "<mark>Morgan</mark> <mark>Stanley</mark>"},
+		{"fuzzy word match", "Goldman Sachs", "goldmann", "<mark>Goldman</mark> Sachs"},
+		{"no match", "Tesla", "xyz", "Tesla"},
+		{"escapes html", "A<B> & Co", "co", "A&lt;B&gt; &amp; <mark>Co</mark>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, highlightMatch(tt.value, tt.query, 0.3))
+		})
+	}
+}
+
+func TestTrigramSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, trigramSimilarity("Apple", "apple"))
+	assert.Equal(t, 0.0, trigramSimilarity("apple", "xyz"))
+	// "word" and "two words": pg_trgm returns 0.363636
+	assert.InDelta(t, 0.363636, trigramSimilarity("word", "two words"), 0.0001)
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/company/stock-api/internal/domain"
+)
+
+// stockFieldDependencies lists the fields that must be loaded to compute a requested field
+var stockFieldDependencies = map[string][]string{
+	"converted": {"target_from_amount", "target_to_amount", "target_currency", "time"},
+}
+
+// recommendationFields are the fields the scoring strategies read from the latest event
+var recommendationFields = []string{
+	"action", "action_category", "rating_from_score", "rating_to", "rating_to_score",
+	"target_from_amount", "target_to_amount", "target_currency", "time", "brokerage", "ticker",
+}
+
+// ParseStockFields parses a comma-separated fields= value into StockWithDetails JSON field names.
+// An empty value returns nil, meaning all fields.
+func ParseStockFields(value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	seen := map[string]bool{}
+	for _, field := range strings.Split(value, ",") {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" || seen[field] {
+			continue
+		}
+		if !domain.IsStockField(field) {
+			return nil, fmt.Errorf("%w: unknown field %q in fields (allowed: %s)", domain.ErrInvalidInput, field, strings.Join(domain.StockFields, ", "))
+		}
+		seen[field] = true
+		fields = append(fields, field)
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: fields must name at least one field", domain.ErrInvalidInput)
+	}
+
+	return fields, nil
+}
+
+// withStockFields returns the fields to load from the repository: the requested fields, the fields
+// they are computed from, and any extra fields the caller needs. Nil requested fields stay nil (all fields).
+func withStockFields(requested []string, extra ...string) []string {
+	if requested == nil {
+		return nil
+	}
+
+	var fields []string
+	seen := map[string]bool{}
+	add := func(field string) {
+		if !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+
+	for _, field := range requested {
+		add(field)
+		for _, dependency := range stockFieldDependencies[field] {
+			add(dependency)
+		}
+	}
+	for _, field := range extra {
+		add(field)
+	}
+
+	return fields
+}
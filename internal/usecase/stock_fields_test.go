@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestParseStockFields(t *testing.T) {
+	t.Run("Empty means all fields", func(t *testing.T) {
+		fields, err := ParseStockFields("  ")
+
+		assert.NoError(t, err)
+		assert.Nil(t, fields)
+	})
+
+	t.Run("Normalizes and deduplicates", func(t *testing.T) {
+		fields, err := ParseStockFields(" Ticker,rating_to,,target_to,ticker ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ticker", "rating_to", "target_to"}, fields)
+	})
+
+	t.Run("Unknown field", func(t *testing.T) {
+		_, err := ParseStockFields("ticker,price")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+		assert.Contains(t, err.Error(), `"price"`)
+	})
+
+	t.Run("Only separators", func(t *testing.T) {
+		_, err := ParseStockFields(",,")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	})
+}
+
+func TestWithStockFields(t *testing.T) {
+	assert.Nil(t, withStockFields(nil, "time"))
+	assert.Equal(t,
+		[]string{"ticker", "converted", "target_from_amount", "target_to_amount", "target_currency", "time", "brokerage"},
+		withStockFields([]string{"ticker", "converted"}, "time", "brokerage"))
+}
+
+func TestStockUseCase_SparseFieldsets(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	t.Run("Ticker history loads requested fields and their dependencies", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		expected := []string{"ticker", "converted", "target_from_amount", "target_to_amount", "target_currency", "time"}
+		mockRepo.On("FindByTicker", "AAPL", domain.TimeRange{}, expected).Return([]*domain.StockWithDetails{{Ticker: "AAPL"}}, nil).Once()
+
+		_, err := useCase.GetStocksByTicker(context.Background(), "AAPL", domain.TimeRange{}, []string{"ticker", "converted"})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Recommendations always load scoring fields", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		mockRepo.On("FindAll", mock.MatchedBy(func(filter domain.StockFilter) bool {
+			return assert.ObjectsAreEqual(append([]string{"company"}, recommendationFields...), filter.Fields)
+		})).Return([]*domain.StockWithDetails{{Ticker: "AAPL", Time: time.Now()}}, nil).Once()
+
+		recommendations, err := useCase.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 10, Fields: []string{"company"}})
+
+		assert.NoError(t, err)
+		assert.Len(t, recommendations, 1)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Recommendations without fields load everything", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		mockRepo.On("FindAll", domain.StockFilter{Limit: 1000}).Return([]*domain.StockWithDetails{}, nil).Once()
+
+		_, err := useCase.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 10})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -2,10 +2,12 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/company/stock-api/internal/domain"
 	"go.uber.org/zap"
@@ -18,17 +20,26 @@ type StockUseCase struct {
 	brokerageUC *BrokerageUseCase
 	actionUC    *ActionUseCase
 	ratingUC    *RatingUseCase
+	priceUC     *PriceUseCase
+	scoring     *ScoringProfileStore
+	strategies  []ScoringStrategy
 	logger      *zap.Logger
 }
 
 // NewStockUseCase creates a new StockUseCase
-func NewStockUseCase(repo domain.StockRepository, apiClient domain.StockAPIClient, brokerageUC *BrokerageUseCase, actionUC *ActionUseCase, ratingUC *RatingUseCase, logger *zap.Logger) *StockUseCase {
+func NewStockUseCase(repo domain.StockRepository, apiClient domain.StockAPIClient, brokerageUC *BrokerageUseCase, actionUC *ActionUseCase, ratingUC *RatingUseCase, priceUC *PriceUseCase, scoring *ScoringProfileStore, logger *zap.Logger) *StockUseCase {
+	if scoring == nil {
+		scoring, _ = NewScoringProfileStore("", logger)
+	}
 	return &StockUseCase{
 		repo:        repo,
 		apiClient:   apiClient,
 		brokerageUC: brokerageUC,
 		actionUC:    actionUC,
 		ratingUC:    ratingUC,
+		priceUC:     priceUC,
+		scoring:     scoring,
+		strategies:  builtinStrategies(),
 		logger:      logger,
 	}
 }
@@ -62,6 +73,8 @@ func (uc *StockUseCase) SyncStocksFromAPI(ctx context.Context) (int, error) {
 			return 0, fmt.Errorf("failed to resolve foreign keys for stock %s: %w", stock.Ticker, err)
 		}
 
+		uc.setTargetAmounts(stock)
+
 		// Log progress every 100 stocks
 		if (i+1)%100 == 0 {
 			uc.logger.Info("Foreign key resolution progress",
@@ -174,6 +187,10 @@ func (uc *StockUseCase) resolveForeignKeysWithCache(
 
 // GetStocks retrieves stocks with filters
 func (uc *StockUseCase) GetStocks(ctx context.Context, filter domain.StockFilter) ([]*domain.StockWithDetails, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
 	// Set default pagination if not provided
 	if filter.Limit == 0 {
 		filter.Limit = 50
@@ -182,6 +199,8 @@ func (uc *StockUseCase) GetStocks(ctx context.Context, filter domain.StockFilter
 		filter.Limit = 1000
 	}
 
+	filter.Fields = withStockFields(filter.Fields)
+
 	stocks, err := uc.repo.FindAll(filter)
 	if err != nil {
 		uc.logger.Error("Failed to retrieve stocks", zap.Error(err))
@@ -191,6 +210,57 @@ func (uc *StockUseCase) GetStocks(ctx context.Context, filter domain.StockFilter
 	return stocks, nil
 }
 
+// ExportStocks calls fn for every stock matching the filter without loading them all into memory.
+// Unlike GetStocks there is no default limit; a zero limit exports every match.
+func (uc *StockUseCase) ExportStocks(ctx context.Context, filter domain.StockFilter, fn func(*domain.StockWithDetails) error) error {
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+	for _, field := range filter.Fields {
+		if field == "converted" {
+			return fmt.Errorf("%w: field %q cannot be exported", domain.ErrInvalidInput, field)
+		}
+	}
+
+	if err := uc.repo.Stream(ctx, filter, fn); err != nil {
+		uc.logger.Error("Failed to export stocks", zap.Error(err))
+		return fmt.Errorf("failed to export stocks: %w", err)
+	}
+
+	return nil
+}
+
+// watermarkLag is how far export watermarks trail the database clock. created_at is set when a sync
+// transaction starts, so rows of a transaction still running during an export can be stored with an earlier
+// created_at than the watermark; the lag outlasts the sync's per-chunk transaction timeout.
+const watermarkLag = 2 * time.Minute
+
+// ExportWatermark returns the time an export can read stored events up to without missing rows that are
+// committed later: the database clock less watermarkLag
+func (uc *StockUseCase) ExportWatermark(ctx context.Context) (time.Time, error) {
+	now, err := uc.repo.CurrentTime(ctx)
+	if err != nil {
+		uc.logger.Error("Failed to read database time", zap.Error(err))
+		return time.Time{}, fmt.Errorf("failed to read database time: %w", err)
+	}
+
+	return now.Add(-watermarkLag), nil
+}
+
+// StreamEvents calls fn for every stock event stored within the window, oldest event first
+func (uc *StockUseCase) StreamEvents(ctx context.Context, window domain.EventWindow, fn func(*domain.StockWithDetails) error) error {
+	if window.Since != nil && !window.Since.Before(window.Until) {
+		return fmt.Errorf("%w: since must be before %s", domain.ErrInvalidInput, window.Until.Format(time.RFC3339))
+	}
+
+	if err := uc.repo.StreamEvents(ctx, window, fn); err != nil {
+		uc.logger.Error("Failed to stream stock events", zap.Error(err))
+		return fmt.Errorf("failed to stream stock events: %w", err)
+	}
+
+	return nil
+}
+
 // GetStockByID retrieves a single stock by ID
 func (uc *StockUseCase) GetStockByID(ctx context.Context, id int64) (*domain.StockWithDetails, error) {
 	stock, err := uc.repo.FindByID(id)
@@ -202,9 +272,10 @@ func (uc *StockUseCase) GetStockByID(ctx context.Context, id int64) (*domain.Sto
 	return stock, nil
 }
 
-// GetStocksByTicker retrieves all historical versions of a stock by ticker
-func (uc *StockUseCase) GetStocksByTicker(ctx context.Context, ticker string) ([]*domain.StockWithDetails, error) {
-	stocks, err := uc.repo.FindByTicker(ticker)
+// GetStocksByTicker retrieves all historical versions of a stock by ticker within a time range.
+// fields limits the loaded fields (nil loads all).
+func (uc *StockUseCase) GetStocksByTicker(ctx context.Context, ticker string, timeRange domain.TimeRange, fields []string) ([]*domain.StockWithDetails, error) {
+	stocks, err := uc.repo.FindByTicker(ticker, timeRange, withStockFields(fields))
 	if err != nil {
 		uc.logger.Error("Failed to retrieve stocks by ticker", zap.String("ticker", ticker), zap.Error(err))
 		return nil, err
@@ -213,8 +284,97 @@ func (uc *StockUseCase) GetStocksByTicker(ctx context.Context, ticker string) ([
 	return stocks, nil
 }
 
+// GetTimeline retrieves a page of a ticker's events, each annotated with what changed since the
+// previous event by any brokerage and by the same brokerage
+func (uc *StockUseCase) GetTimeline(ctx context.Context, ticker string, filter domain.TimelineFilter) ([]*domain.TimelineEvent, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Set default pagination if not provided
+	if filter.Limit <= 0 {
+		filter.Limit = 50
+	}
+	if filter.Limit > 1000 {
+		filter.Limit = 1000
+	}
+
+	entries, err := uc.repo.FindTimeline(ctx, ticker, filter)
+	if err != nil {
+		uc.logger.Error("Failed to retrieve timeline", zap.String("ticker", ticker), zap.Error(err))
+		return nil, fmt.Errorf("failed to retrieve timeline: %w", err)
+	}
+
+	events := make([]*domain.TimelineEvent, len(entries))
+	for i, entry := range entries {
+		events[i] = &domain.TimelineEvent{
+			StockWithDetails: entry.Stock,
+			SincePrevious:    diffEvents(entry.Previous, entry.Stock),
+			SinceBrokerage:   diffEvents(entry.PreviousByBrokerage, entry.Stock),
+		}
+	}
+
+	return events, nil
+}
+
+// GetTimelineCount returns the number of a ticker's events matching the filter.
+// A ticker without matching events counts 0; an unknown ticker returns ErrNotFound.
+func (uc *StockUseCase) GetTimelineCount(ctx context.Context, ticker string, filter domain.TimelineFilter) (int64, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+
+	count, err := uc.repo.CountTimeline(ctx, ticker, filter)
+	if err == nil && count == 0 {
+		// No matching events; tell a filtered-out ticker apart from an unknown one
+		var total int64
+		total, err = uc.repo.Count(domain.StockFilter{Ticker: domain.ValueFilter{In: []string{ticker}}})
+		if err == nil && total == 0 {
+			return 0, domain.ErrNotFound
+		}
+	}
+	if err != nil {
+		uc.logger.Error("Failed to count timeline", zap.String("ticker", ticker), zap.Error(err))
+		return 0, fmt.Errorf("failed to count timeline: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetConsensus computes the consensus of the brokerages covering ticker from its events between from and to.
+// A ticker without events in the window gets an empty consensus; an unknown ticker returns ErrNotFound.
+func (uc *StockUseCase) GetConsensus(ctx context.Context, ticker string, from, to time.Time) (*domain.Consensus, error) {
+	if from.After(to) {
+		return nil, fmt.Errorf("%w: window must start before it ends", domain.ErrInvalidInput)
+	}
+
+	events, err := uc.repo.FindByTicker(ticker, domain.TimeRange{From: &from, To: &to}, nil)
+	if errors.Is(err, domain.ErrNotFound) {
+		// No events in the window; tell an uncovered ticker apart from an unknown one
+		count, countErr := uc.repo.Count(domain.StockFilter{Ticker: domain.ValueFilter{In: []string{ticker}}})
+		if countErr != nil {
+			err = countErr
+		} else if count > 0 {
+			return buildConsensus(ticker, from, to, nil), nil
+		}
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, err
+		}
+		uc.logger.Error("Failed to retrieve consensus events", zap.String("ticker", ticker), zap.Error(err))
+		return nil, fmt.Errorf("failed to retrieve consensus events: %w", err)
+	}
+
+	return buildConsensus(ticker, from, to, events), nil
+}
+
 // GetStockCount returns the total count of stocks matching the filter
 func (uc *StockUseCase) GetStockCount(ctx context.Context, filter domain.StockFilter) (int64, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+
 	count, err := uc.repo.Count(filter)
 	if err != nil {
 		uc.logger.Error("Failed to count stocks", zap.Error(err))
@@ -224,13 +384,30 @@ func (uc *StockUseCase) GetStockCount(ctx context.Context, filter domain.StockFi
 	return count, nil
 }
 
-// GetRecommendations analyzes stocks and returns the best investment recommendations
-func (uc *StockUseCase) GetRecommendations(ctx context.Context, limit int) ([]*domain.StockRecommendation, error) {
-	uc.logger.Info("Generating stock recommendations", zap.Int("limit", limit))
+// ScoringProfile returns the scoring profile recommendations are currently scored with
+func (uc *StockUseCase) ScoringProfile() domain.ActiveScoringProfile {
+	return uc.scoring.Active()
+}
+
+// GetRecommendations analyzes stocks with the query's strategy and returns the best investment recommendations
+func (uc *StockUseCase) GetRecommendations(ctx context.Context, query domain.RecommendationQuery) ([]*domain.StockRecommendation, error) {
+	strategy, err := uc.findStrategy(query.Strategy)
+	if err != nil {
+		return nil, err
+	}
+	uc.logger.Info("Generating stock recommendations",
+		zap.Int("limit", query.Limit), zap.String("strategy", strategy.Info().Name))
+
+	now := time.Now()
+	if query.AsOf != nil {
+		now = *query.AsOf
+	}
 
 	// Get all latest stocks (deduplicated by ticker)
 	filter := domain.StockFilter{
-		Limit: 1000, // Get a large set to analyze
+		Limit:  1000, // Get a large set to analyze
+		Fields: withStockFields(query.Fields, recommendationFields...),
+		AsOf:   query.AsOf,
 	}
 	stocks, err := uc.repo.FindAll(filter)
 	if err != nil {
@@ -242,17 +419,56 @@ func (uc *StockUseCase) GetRecommendations(ctx context.Context, limit int) ([]*d
 		return []*domain.StockRecommendation{}, nil
 	}
 
+	// Last closes for the implied upside, when price bars are available
+	closes := map[string]*domain.PriceBar{}
+	if uc.priceUC != nil {
+		tickers := make([]string, 0, len(stocks))
+		for _, stock := range stocks {
+			tickers = append(tickers, stock.Ticker)
+		}
+		if closes, err = uc.priceUC.RecentCloses(ctx, tickers, now); err != nil {
+			return nil, err
+		}
+	}
+
+	var coverage map[string][]*domain.StockWithDetails
+	if strategy.NeedsCoverage() {
+		if coverage, err = uc.loadCoverage(ctx, now, query.AsOf); err != nil {
+			return nil, err
+		}
+	}
+
+	// Score every stock with the same profile, even if it is reloaded meanwhile
+	profile := uc.scoring.Profile()
+
 	// Calculate scores for each stock
 	recommendations := make([]*domain.StockRecommendation, 0, len(stocks))
 	for _, stock := range stocks {
-		score, reason, targetIncrease := uc.calculateStockScore(stock)
+		lastClose := closes[stock.Ticker]
+		score, ok := strategy.Score(ScoringInput{
+			Stock:     stock,
+			LastClose: lastClose,
+			Coverage:  coverage[stock.Ticker],
+			Profile:   profile,
+			Now:       now,
+		})
+		if !ok {
+			continue
+		}
 
-		recommendations = append(recommendations, &domain.StockRecommendation{
+		recommendation := &domain.StockRecommendation{
 			Stock:          stock,
-			Score:          score,
-			Reason:         reason,
-			TargetIncrease: targetIncrease,
-		})
+			Score:          score.Score,
+			Reason:         strings.Join(score.Reasons, "; "),
+			TargetIncrease: score.TargetIncrease,
+			ImpliedUpside:  roundPtr(score.ImpliedUpside),
+			Factors:        explainFactors(score.Factors, query.Explain),
+		}
+		if lastClose != nil {
+			recommendation.LastClose = &lastClose.Close
+			recommendation.LastCloseDate = &lastClose.Date
+		}
+		recommendations = append(recommendations, recommendation)
 	}
 
 	// Sort by score (descending)
@@ -265,8 +481,8 @@ func (uc *StockUseCase) GetRecommendations(ctx context.Context, limit int) ([]*d
 	}
 
 	// Return top N recommendations
-	if limit > 0 && limit < len(recommendations) {
-		recommendations = recommendations[:limit]
+	if query.Limit > 0 && query.Limit < len(recommendations) {
+		recommendations = recommendations[:query.Limit]
 	}
 
 	uc.logger.Info("Generated recommendations",
@@ -276,112 +492,62 @@ func (uc *StockUseCase) GetRecommendations(ctx context.Context, limit int) ([]*d
 	return recommendations, nil
 }
 
-// calculateStockScore calculates a score for a stock based on multiple factors
-func (uc *StockUseCase) calculateStockScore(stock *domain.StockWithDetails) (float64, string, float64) {
-	var score float64
-	reasons := []string{}
-
-	// 1. Action Score (30% weight) - upgrade is best
-	actionScore := uc.getActionScore(stock.ActionName)
-	score += actionScore * 0.30
-	if actionScore > 3 {
-		reasons = append(reasons, fmt.Sprintf("Recent %s", stock.ActionName))
-	}
-
-	// 2. Rating Improvement Score (25% weight)
-	ratingScore := uc.getRatingImprovementScore(stock.RatingFromTerm, stock.RatingToTerm)
-	score += ratingScore * 0.25
-	if ratingScore > 3 {
-		reasons = append(reasons, fmt.Sprintf("Rating improved to %s", stock.RatingToTerm))
-	}
-
-	// 3. Target Price Increase (20% weight)
-	targetIncrease := uc.calculateTargetPriceIncrease(stock.TargetFrom, stock.TargetTo)
-	if targetIncrease != 0 {
-		// Normalize: 10% increase = 5 points, 20% = 10 points, etc.
-		targetScore := (targetIncrease / 2.0)
-		if targetScore > 10 {
-			targetScore = 10 // Cap at 10
-		}
-		if targetScore < -10 {
-			targetScore = -10 // Floor at -10
-		}
-		score += targetScore * 0.20
-		if targetIncrease > 5 {
-			reasons = append(reasons, fmt.Sprintf("%.1f%% price target increase", targetIncrease))
-		} else if targetIncrease < -5 {
-			reasons = append(reasons, fmt.Sprintf("%.1f%% price target decrease", targetIncrease))
+// explainFactors rounds factor scores for display and drops their rules unless explain is set
+func explainFactors(factors []domain.ScoreFactor, explain bool) []domain.ScoreFactor {
+	explained := make([]domain.ScoreFactor, len(factors))
+	for i, factor := range factors {
+		factor.Score = roundTo(factor.Score, 4)
+		factor.Weight = roundTo(factor.Weight, 4)
+		factor.Contribution = roundTo(factor.Contribution, 4)
+		if !explain {
+			factor.Rule = ""
 		}
+		explained[i] = factor
 	}
+	return explained
+}
 
-	// 4. Recency Score (15% weight) - more recent is better
-	recencyScore := uc.getRecencyScore(stock.Time)
-	score += recencyScore * 0.15
-
-	// 5. Brokerage Reputation (10% weight)
-	brokerageScore := uc.getBrokerageScore(stock.BrokerageName)
-	score += brokerageScore * 0.10
-	if brokerageScore >= 8 && stock.BrokerageName != "" {
-		reasons = append(reasons, fmt.Sprintf("Rated by %s", stock.BrokerageName))
+// loadCoverage loads the latest event of every brokerage covering each ticker within the coverage
+// window before now, newest first
+func (uc *StockUseCase) loadCoverage(ctx context.Context, now time.Time, asOf *time.Time) (map[string][]*domain.StockWithDetails, error) {
+	from := now.Add(-strategyCoverageWindow)
+	filter := domain.StockFilter{
+		View:   domain.StockViewByBrokerage,
+		Time:   domain.TimeRange{From: &from},
+		AsOf:   asOf,
+		Fields: coverageFields,
 	}
 
-	// Build reason string
-	reason := strings.Join(reasons, "; ")
-	if reason == "" {
-		reason = "Positive outlook"
+	coverage := map[string][]*domain.StockWithDetails{}
+	err := uc.repo.Stream(ctx, filter, func(stock *domain.StockWithDetails) error {
+		coverage[stock.Ticker] = append(coverage[stock.Ticker], stock)
+		return nil
+	})
+	if err != nil {
+		uc.logger.Error("Failed to load brokerage coverage for recommendations", zap.Error(err))
+		return nil, fmt.Errorf("failed to load brokerage coverage: %w", err)
 	}
-
-	return score, reason, targetIncrease
+	return coverage, nil
 }
 
-// getActionScore returns a score based on the action type
-func (uc *StockUseCase) getActionScore(action string) float64 {
-	action = strings.ToLower(action)
-	switch {
-	case strings.Contains(action, "upgrade"):
-		return 10.0
-	case strings.Contains(action, "initiated") || strings.Contains(action, "initiate"):
-		return 8.0
-	case strings.Contains(action, "target") && strings.Contains(action, "raised"):
-		return 7.0
-	case strings.Contains(action, "reiterate") || strings.Contains(action, "maintain"):
-		return 6.0
-	case strings.Contains(action, "target") && strings.Contains(action, "lowered"):
-		return 3.0 // Negative signal
-	case strings.Contains(action, "downgrade"):
-		return 2.0
-	default:
-		return 5.0 // neutral
+// calculateImpliedUpside calculates the percentage from the last close to target_to. It returns nil without
+// a close or a target, or when the target is in another currency than the close.
+func calculateImpliedUpside(stock *domain.StockWithDetails, lastClose *domain.PriceBar) *float64 {
+	if lastClose == nil || lastClose.Close <= 0 || stock.TargetToAmount == nil || *stock.TargetToAmount <= 0 {
+		return nil
+	}
+	if stock.TargetCurrency != "" && stock.TargetCurrency != lastClose.Currency {
+		return nil
 	}
+
+	upside := (*stock.TargetToAmount - lastClose.Close) / lastClose.Close * 100
+	return &upside
 }
 
-// getRatingImprovementScore compares rating_from to rating_to
-func (uc *StockUseCase) getRatingImprovementScore(ratingFrom, ratingTo string) float64 {
-	ratingValues := map[string]float64{
-		"strong-buy":        5.0,
-		"strong buy":        5.0,
-		"buy":               4.0,
-		"speculative buy":   4.0,
-		"overweight":        4.0,
-		"outperform":        4.0,
-		"market outperform": 4.0,
-		"sector outperform": 4.0,
-		"positive":          4.0,
-		"hold":              3.0,
-		"neutral":           3.0,
-		"in-line":           3.0,
-		"market perform":    3.0,
-		"sector perform":    3.0,
-		"equal weight":      3.0,
-		"equal-weight":      3.0,
-		"underweight":       2.0,
-		"underperform":      2.0,
-		"reduce":            2.0,
-		"sell":              1.0,
-	}
-
-	fromValue := uc.getRatingValue(ratingFrom, ratingValues)
-	toValue := uc.getRatingValue(ratingTo, ratingValues)
+// getRatingImprovementScore compares rating_from to rating_to using their canonical scores
+func getRatingImprovementScore(ratingFromScore, ratingToScore *float64) float64 {
+	fromValue := getRatingValue(ratingFromScore)
+	toValue := getRatingValue(ratingToScore)
 
 	// Calculate improvement bonus
 	improvementBonus := 0.0
@@ -398,94 +564,151 @@ func (uc *StockUseCase) getRatingImprovementScore(ratingFrom, ratingTo string) f
 	return (toValue * 2.0) + improvementBonus
 }
 
-// getRatingValue gets the numeric value for a rating
-func (uc *StockUseCase) getRatingValue(rating string, ratingValues map[string]float64) float64 {
-	rating = strings.ToLower(strings.TrimSpace(rating))
-
-	// Handle empty rating
-	if rating == "" {
-		return 3.0 // Default to neutral
+// getRatingValue gets the numeric value for a rating on the 1-5 canonical scale
+func getRatingValue(score *float64) float64 {
+	// Unmapped or missing ratings count as neutral
+	if score == nil {
+		return domain.NeutralRatingScore
 	}
-
-	if val, ok := ratingValues[rating]; ok {
-		return val
-	}
-	// Default to neutral if unknown
-	return 3.0
+	return *score
 }
 
 // calculateTargetPriceIncrease calculates the percentage increase from target_from to target_to
-func (uc *StockUseCase) calculateTargetPriceIncrease(targetFrom, targetTo string) float64 {
-	from := uc.parsePrice(targetFrom)
-	to := uc.parsePrice(targetTo)
-
-	if from <= 0 || to <= 0 {
+func calculateTargetPriceIncrease(targetFrom, targetTo *float64) float64 {
+	if targetFrom == nil || targetTo == nil || *targetFrom <= 0 || *targetTo <= 0 {
 		return 0
 	}
 
-	increase := ((to - from) / from) * 100
+	increase := ((*targetTo - *targetFrom) / *targetFrom) * 100
 	return increase
 }
 
-// parsePrice extracts numeric value from price strings like "$200.00", "$2,700.00" or "$85"
-func (uc *StockUseCase) parsePrice(priceStr string) float64 {
-	// Remove currency symbols and commas
+// currencySymbols maps price symbols to ISO 4217 codes; longer symbols come first
+var currencySymbols = []struct {
+	symbol string
+	code   string
+}{
+	{"US$", "USD"},
+	{"C$", "CAD"},
+	{"A$", "AUD"},
+	{"R$", "BRL"},
+	{"$", "USD"},
+	{"€", "EUR"},
+	{"£", "GBP"},
+	{"¥", "JPY"},
+}
+
+// parsePriceTarget extracts the amount and currency from price strings like "$200.00", "$2,700.00", "€85" or "85 EUR".
+// It returns a nil amount if the string holds no number, and an empty currency if none is given.
+func (uc *StockUseCase) parsePriceTarget(priceStr string) (*float64, string) {
 	priceStr = strings.TrimSpace(priceStr)
-	priceStr = strings.ReplaceAll(priceStr, "$", "")
-	priceStr = strings.ReplaceAll(priceStr, "€", "")
+	if priceStr == "" {
+		return nil, ""
+	}
+
+	currency := ""
+	for _, cs := range currencySymbols {
+		if strings.Contains(priceStr, cs.symbol) {
+			currency = cs.code
+			priceStr = strings.ReplaceAll(priceStr, cs.symbol, "")
+			break
+		}
+	}
+
+	// ISO codes, e.g. "USD 50" or "50 EUR"
+	if currency == "" {
+		fields := strings.Fields(priceStr)
+		if len(fields) == 2 {
+			for i, field := range fields {
+				if isCurrencyCode(field) {
+					currency = strings.ToUpper(field)
+					priceStr = fields[1-i]
+					break
+				}
+			}
+		}
+	}
+
 	priceStr = strings.ReplaceAll(priceStr, ",", "") // Handle $2,700.00 format
 	priceStr = strings.ReplaceAll(priceStr, " ", "")
 
 	price, err := strconv.ParseFloat(priceStr, 64)
 	if err != nil {
-		return 0
+		return nil, currency
 	}
-	return price
+	return &price, currency
 }
 
-// getRecencyScore scores based on how recent the stock data is
-func (uc *StockUseCase) getRecencyScore(t time.Time) float64 {
-	daysSince := time.Since(t).Hours() / 24
-
-	switch {
-	case daysSince <= 1:
-		return 10.0 // Today
-	case daysSince <= 7:
-		return 8.0 // This week
-	case daysSince <= 30:
-		return 6.0 // This month
-	case daysSince <= 90:
-		return 4.0 // Last 3 months
-	default:
-		return 2.0 // Older
+// isCurrencyCode reports whether s looks like a three-letter ISO 4217 code
+func isCurrencyCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			return false
+		}
 	}
+	return true
 }
 
-// getBrokerageScore scores based on brokerage reputation
-func (uc *StockUseCase) getBrokerageScore(brokerage string) float64 {
-	brokerage = strings.ToLower(strings.TrimSpace(brokerage))
+// setTargetAmounts parses the raw price targets of a stock into numeric amounts and a currency.
+// Both amounts share one currency, so when the targets name different currencies only the new target is kept;
+// otherwise the change between them would be computed across currencies.
+func (uc *StockUseCase) setTargetAmounts(stock *domain.Stock) {
+	fromAmount, fromCurrency := uc.parsePriceTarget(stock.TargetFrom)
+	toAmount, toCurrency := uc.parsePriceTarget(stock.TargetTo)
 
-	// Handle empty brokerage
-	if brokerage == "" {
-		return 5.0 // Neutral score for unknown brokerage
+	if fromCurrency != "" && toCurrency != "" && fromCurrency != toCurrency {
+		uc.logger.Warn("Price targets have different currencies, dropping target_from amount",
+			zap.String("ticker", stock.Ticker),
+			zap.String("target_from", stock.TargetFrom),
+			zap.String("target_to", stock.TargetTo))
+		fromAmount = nil
 	}
 
-	// Top-tier brokerages
-	topTier := []string{"goldman sachs", "morgan stanley", "jp morgan", "jpmorgan", "barclays"}
-	for _, top := range topTier {
-		if strings.Contains(brokerage, top) {
-			return 10.0
-		}
+	stock.TargetFromAmount = fromAmount
+	stock.TargetToAmount = toAmount
+	stock.TargetCurrency = toCurrency
+	if stock.TargetCurrency == "" {
+		stock.TargetCurrency = fromCurrency
 	}
+}
+
+// BackfillTargetAmounts parses the price targets of stocks stored before numeric targets existed.
+// Every pending stock is marked parsed; the count only includes stocks that got an amount.
+func (uc *StockUseCase) BackfillTargetAmounts(ctx context.Context) (int, error) {
+	const batchSize = 500
+
+	updated := 0
+	var afterID int64
+	for {
+		stocks, err := uc.repo.FindPendingTargetBackfill(ctx, afterID, batchSize)
+		if err != nil {
+			return updated, fmt.Errorf("failed to retrieve stocks for target backfill: %w", err)
+		}
+		if len(stocks) == 0 {
+			break
+		}
+
+		for _, stock := range stocks {
+			uc.setTargetAmounts(stock)
+		}
 
-	// Mid-tier brokerages
-	midTier := []string{"citigroup", "credit suisse", "deutsche bank", "ubs", "wells fargo"}
-	for _, mid := range midTier {
-		if strings.Contains(brokerage, mid) {
-			return 8.0
+		if err := uc.repo.UpdateTargetAmounts(ctx, stocks); err != nil {
+			return updated, fmt.Errorf("failed to backfill target amounts: %w", err)
 		}
+
+		for _, stock := range stocks {
+			if stock.TargetFromAmount != nil || stock.TargetToAmount != nil {
+				updated++
+			}
+		}
+		afterID = stocks[len(stocks)-1].ID
 	}
 
-	// Default score for other brokerages
-	return 6.0
+	if updated > 0 {
+		uc.logger.Info("Backfilled numeric price targets", zap.Int("count", updated))
+	}
+	return updated, nil
 }
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -43,14 +44,71 @@ func (m *MockStockRepository) Count(filter domain.StockFilter) (int64, error) {
 	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockStockRepository) FindByTicker(ticker string) ([]*domain.StockWithDetails, error) {
-	args := m.Called(ticker)
+func (m *MockStockRepository) Stream(ctx context.Context, filter domain.StockFilter, fn func(*domain.StockWithDetails) error) error {
+	args := m.Called(ctx, filter, fn)
+	return args.Error(0)
+}
+
+func (m *MockStockRepository) StreamEvents(ctx context.Context, window domain.EventWindow, fn func(*domain.StockWithDetails) error) error {
+	args := m.Called(ctx, window, fn)
+	return args.Error(0)
+}
+
+func (m *MockStockRepository) CurrentTime(ctx context.Context) (time.Time, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockStockRepository) FindTimeline(ctx context.Context, ticker string, filter domain.TimelineFilter) ([]*domain.TimelineEntry, error) {
+	args := m.Called(ctx, ticker, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.TimelineEntry), args.Error(1)
+}
+
+func (m *MockStockRepository) CountTimeline(ctx context.Context, ticker string, filter domain.TimelineFilter) (int64, error) {
+	args := m.Called(ctx, ticker, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStockRepository) FindByTicker(ticker string, timeRange domain.TimeRange, fields []string) ([]*domain.StockWithDetails, error) {
+	args := m.Called(ticker, timeRange, fields)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*domain.StockWithDetails), args.Error(1)
 }
 
+func (m *MockStockRepository) AggregateEvents(ctx context.Context, query domain.EventAnalyticsQuery) ([]*domain.EventAggregate, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.EventAggregate), args.Error(1)
+}
+
+func (m *MockStockRepository) AggregateRatingTransitions(ctx context.Context, query domain.RatingTransitionQuery) ([]*domain.RatingTransitionCount, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.RatingTransitionCount), args.Error(1)
+}
+
+func (m *MockStockRepository) FindPendingTargetBackfill(ctx context.Context, afterID int64, limit int) ([]*domain.Stock, error) {
+	args := m.Called(ctx, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Stock), args.Error(1)
+}
+
+func (m *MockStockRepository) UpdateTargetAmounts(ctx context.Context, stocks []*domain.Stock) error {
+	args := m.Called(ctx, stocks)
+	return args.Error(0)
+}
+
 // MockStockAPIClient is a mock implementation of the stock API client
 type MockStockAPIClient struct {
 	mock.Mock
@@ -69,7 +127,7 @@ func TestStockUseCase_GetStockByID(t *testing.T) {
 	mockRepo := new(MockStockRepository)
 
 	// Create mock use cases (passing nil for now since they're not used in this test)
-	useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, logger)
+	useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
 
 	t.Run("Success", func(t *testing.T) {
 		expectedStock := &domain.StockWithDetails{
@@ -106,7 +164,7 @@ func TestStockUseCase_GetStocks(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
 	mockRepo := new(MockStockRepository)
 
-	useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, logger)
+	useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
 
 	t.Run("Success with default pagination", func(t *testing.T) {
 		expectedStocks := []*domain.StockWithDetails{
@@ -130,7 +188,7 @@ func TestStockUseCase_GetStocks(t *testing.T) {
 		}
 
 		filter := domain.StockFilter{
-			Ticker: "AAPL",
+			Ticker: domain.ValueFilter{In: []string{"AAPL"}},
 			Limit:  10,
 			Offset: 0,
 		}
@@ -144,14 +202,36 @@ func TestStockUseCase_GetStocks(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("Success with by_brokerage view", func(t *testing.T) {
+		expectedStocks := []*domain.StockWithDetails{
+			{ID: 1, Ticker: "AAPL", BrokerageName: "Goldman Sachs", RatingToTerm: "Buy", Time: time.Now()},
+			{ID: 2, Ticker: "AAPL", BrokerageName: "Barclays", RatingToTerm: "Sell", Time: time.Now().Add(-time.Hour)},
+		}
+
+		filter := domain.StockFilter{View: domain.StockViewByBrokerage, Limit: 50}
+		mockRepo.On("FindAll", filter).Return(expectedStocks, nil).Once()
+
+		stocks, err := useCase.GetStocks(context.Background(), filter)
+
+		assert.NoError(t, err)
+		assert.Len(t, stocks, 2)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Rejects unknown view", func(t *testing.T) {
+		_, err := useCase.GetStocks(context.Background(), domain.StockFilter{View: "by_analyst"})
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+	})
+
 	t.Run("Success with rating filters", func(t *testing.T) {
 		expectedStocks := []*domain.StockWithDetails{
 			{ID: 1, Ticker: "AAPL", Company: "Apple Inc.", RatingFromTerm: "Neutral", RatingToTerm: "Overweight", Time: time.Now()},
 		}
 
 		filter := domain.StockFilter{
-			RatingFrom: "Neutral",
-			RatingTo:   "Overweight",
+			RatingFrom: domain.ValueFilter{In: []string{"Neutral"}},
+			RatingTo:   domain.ValueFilter{In: []string{"Overweight"}},
 			Limit:      50,
 		}
 		mockRepo.On("FindAll", filter).Return(expectedStocks, nil).Once()
@@ -192,7 +272,7 @@ func TestStockUseCase_GetStocks(t *testing.T) {
 
 		filter := domain.StockFilter{
 			Company:   "Apple",
-			RatingTo:  "Overweight",
+			RatingTo:  domain.ValueFilter{In: []string{"Overweight"}},
 			SortBy:    "time",
 			SortOrder: "desc",
 			Limit:     10,
@@ -208,16 +288,54 @@ func TestStockUseCase_GetStocks(t *testing.T) {
 		assert.Equal(t, "Overweight", stocks[0].RatingToTerm)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("Success with multi-value and negated filters", func(t *testing.T) {
+		filter := domain.StockFilter{
+			Ticker:    domain.ValueFilter{In: []string{"AAPL", "MSFT", "NVDA"}},
+			RatingTo:  domain.ValueFilter{NotIn: []string{"Sell"}},
+			Brokerage: domain.ValueFilter{In: []string{"Morgan"}, NotIn: []string{"Morgan Stanley"}},
+			Limit:     50,
+		}
+		mockRepo.On("FindAll", filter).Return([]*domain.StockWithDetails{}, nil).Once()
+
+		_, err := useCase.GetStocks(context.Background(), filter)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Rejects invalid filter combinations", func(t *testing.T) {
+		tooMany := make([]string, domain.MaxFilterValues+1)
+		for i := range tooMany {
+			tooMany[i] = fmt.Sprintf("T%d", i)
+		}
+
+		invalid := map[string]domain.StockFilter{
+			"value both included and excluded":  {Brokerage: domain.ValueFilter{In: []string{"Citi"}, NotIn: []string{"citi"}}},
+			"exact field included and excluded": {Ticker: domain.ValueFilter{In: []string{"AAPL"}, NotIn: []string{"MSFT"}}},
+			"too many values":                   {Action: domain.ValueFilter{In: tooMany}},
+			"target bounds reversed":            {TargetToMin: floatPtr(200), TargetToMax: floatPtr(100)},
+		}
+
+		for name, filter := range invalid {
+			_, err := useCase.GetStocks(context.Background(), filter)
+			assert.ErrorIs(t, err, domain.ErrInvalidInput, name)
+
+			_, err = useCase.GetStockCount(context.Background(), filter)
+			assert.ErrorIs(t, err, domain.ErrInvalidInput, name)
+		}
+		mockRepo.AssertNotCalled(t, "FindAll", invalid["too many values"])
+	})
 }
 
 func TestStockUseCase_GetStockCount(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
 	mockRepo := new(MockStockRepository)
 
-	useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, logger)
+	useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
 
 	t.Run("Success", func(t *testing.T) {
-		filter := domain.StockFilter{Ticker: "AAPL"}
+		filter := domain.StockFilter{Ticker: domain.ValueFilter{In: []string{"AAPL"}}}
 		mockRepo.On("Count", filter).Return(int64(42), nil).Once()
 
 		count, err := useCase.GetStockCount(context.Background(), filter)
@@ -239,9 +357,262 @@ func TestStockUseCase_GetStockCount(t *testing.T) {
 	})
 }
 
+func TestStockUseCase_GetRecommendations_AsOf(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	asOf := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	stocks := func() []*domain.StockWithDetails {
+		return []*domain.StockWithDetails{{Ticker: "AAPL", Time: asOf.Add(-12 * time.Hour)}}
+	}
+
+	mockRepo := new(MockStockRepository)
+	useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+	mockRepo.On("FindAll", mock.MatchedBy(func(filter domain.StockFilter) bool {
+		return filter.AsOf != nil && filter.AsOf.Equal(asOf)
+	})).Return(stocks(), nil).Once()
+	mockRepo.On("FindAll", mock.MatchedBy(func(filter domain.StockFilter) bool {
+		return filter.AsOf == nil
+	})).Return(stocks(), nil).Once()
+
+	atAsOf, err := useCase.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 10, AsOf: &asOf})
+	assert.NoError(t, err)
+	now, err := useCase.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 10})
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+
+	if assert.Len(t, atAsOf, 1) && assert.Len(t, now, 1) {
+		// Half a day old at as_of scores full recency; years old today scores the minimum
+		assert.InDelta(t, (10.0-2.0)*0.15, atAsOf[0].Score-now[0].Score, 0.0001)
+	}
+}
+
+func TestStockUseCase_GetRecommendations_DefaultProfile(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	asOf := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	stockRepo := new(MockStockRepository)
+	priceRepo := new(MockPriceBarRepository)
+	stockRepo.On("FindAll", mock.Anything).Return([]*domain.StockWithDetails{{
+		Ticker: "AAPL", ActionCategory: domain.ActionCategoryUpgrade,
+		RatingFromScore: floatPtr(3), RatingToScore: floatPtr(4),
+		TargetFromAmount: floatPtr(100), TargetToAmount: floatPtr(110),
+		BrokerageName: "Barclays", Time: asOf.Add(-3 * 24 * time.Hour),
+	}}, nil).Once()
+	priceRepo.On("FindLatest", mock.Anything, []string{"AAPL"}, asOf.Add(-recentPriceAge), asOf).Return([]*domain.PriceBar{}, nil).Once()
+	useCase := NewStockUseCase(stockRepo, nil, nil, nil, nil, NewPriceUseCase(priceRepo, logger), nil, logger)
+
+	recommendations, err := useCase.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 10, AsOf: &asOf})
+
+	assert.NoError(t, err)
+	if assert.Len(t, recommendations, 1) {
+		// Without a close the default profile scores like the original 30/25/20/15/10 weights:
+		// action 10*0.30 + rating (8+2)*0.25 + target 5*0.20 + recency 8*0.15 + brokerage 10*0.10
+		assert.InDelta(t, 3+2.5+1+1.2+1, recommendations[0].Score, 0.01)
+	}
+}
+
+func TestStockUseCase_ExportStocks(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	mockRepo := new(MockStockRepository)
+
+	useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+	t.Run("Rejects invalid filters", func(t *testing.T) {
+		for _, filter := range []domain.StockFilter{
+			{View: "weekly"},
+			{Fields: []string{"ticker", "converted"}},
+		} {
+			err := useCase.ExportStocks(context.Background(), filter, func(*domain.StockWithDetails) error { return nil })
+
+			assert.ErrorIs(t, err, domain.ErrInvalidInput)
+		}
+		mockRepo.AssertNotCalled(t, "Stream", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Streams every stock without a default limit", func(t *testing.T) {
+		filter := domain.StockFilter{View: domain.StockViewHistory, Ticker: domain.ValueFilter{In: []string{"AAPL"}}}
+		mockRepo.On("Stream", mock.Anything, filter, mock.Anything).Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(*domain.StockWithDetails) error)
+			fn(&domain.StockWithDetails{ID: 1, Ticker: "AAPL"})
+			fn(&domain.StockWithDetails{ID: 2, Ticker: "AAPL"})
+		}).Return(nil).Once()
+
+		var ids []int64
+		err := useCase.ExportStocks(context.Background(), filter, func(stock *domain.StockWithDetails) error {
+			ids = append(ids, stock.ID)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{1, 2}, ids)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		filter := domain.StockFilter{}
+		mockRepo.On("Stream", mock.Anything, filter, mock.Anything).Return(errors.New("database error")).Once()
+
+		err := useCase.ExportStocks(context.Background(), filter, func(*domain.StockWithDetails) error { return nil })
+
+		assert.Error(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestStockUseCase_StreamEvents(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	mockRepo := new(MockStockRepository)
+
+	useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+	until := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Rejects a watermark that is not before the end of the window", func(t *testing.T) {
+		since := until
+		err := useCase.StreamEvents(context.Background(), domain.EventWindow{Since: &since, Until: until}, func(*domain.StockWithDetails) error { return nil })
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "StreamEvents", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		since := until.Add(-24 * time.Hour)
+		window := domain.EventWindow{Since: &since, Until: until}
+		mockRepo.On("StreamEvents", mock.Anything, window, mock.Anything).Return(nil).Once()
+
+		err := useCase.StreamEvents(context.Background(), window, func(*domain.StockWithDetails) error { return nil })
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestStockUseCase_ExportWatermark(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Trails the database clock", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		mockRepo.On("CurrentTime", mock.Anything).Return(now, nil).Once()
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		watermark, err := useCase.ExportWatermark(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, now.Add(-watermarkLag), watermark)
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		mockRepo.On("CurrentTime", mock.Anything).Return(time.Time{}, errors.New("database error")).Once()
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		_, err := useCase.ExportWatermark(context.Background())
+
+		assert.Error(t, err)
+	})
+}
+
 func TestStockUseCase_SyncStocksFromAPI(t *testing.T) {
 	// Skip this test since it requires mock use cases that are complex to set up
 	// The sync functionality now requires BrokerageUseCase, ActionUseCase, and RatingUseCase
 	// which would need proper mocking
 	t.Skip("Skipping SyncStocksFromAPI test - requires complex mocking of dependent use cases for normalized schema")
 }
+
+func TestStockUseCase_parsePriceTarget(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	useCase := NewStockUseCase(nil, nil, nil, nil, nil, nil, nil, logger)
+
+	tests := []struct {
+		raw      string
+		amount   float64
+		currency string
+	}{
+		{"$200.00", 200, "USD"},
+		{"$2,700.00", 2700, "USD"},
+		{"€85", 85, "EUR"},
+		{"£12.50", 12.5, "GBP"},
+		{"C$45", 45, "CAD"},
+		{"50 EUR", 50, "EUR"},
+		{"USD 75.25", 75.25, "USD"},
+		{"42", 42, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			amount, currency := useCase.parsePriceTarget(tt.raw)
+
+			assert.NotNil(t, amount)
+			assert.Equal(t, tt.amount, *amount)
+			assert.Equal(t, tt.currency, currency)
+		})
+	}
+
+	t.Run("Unparseable", func(t *testing.T) {
+		amount, _ := useCase.parsePriceTarget("n/a")
+		assert.Nil(t, amount)
+
+		amount, currency := useCase.parsePriceTarget("")
+		assert.Nil(t, amount)
+		assert.Empty(t, currency)
+	})
+}
+
+func TestStockUseCase_BackfillTargetAmounts(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	mockRepo := new(MockStockRepository)
+	useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+	pending := []*domain.Stock{
+		{ID: 10, TargetFrom: "$100", TargetTo: "$120"},
+		{ID: 11, TargetFrom: "", TargetTo: "€80"},
+		{ID: 12, TargetFrom: "n/a", TargetTo: "tbd"},
+	}
+	mockRepo.On("FindPendingTargetBackfill", mock.Anything, int64(0), 500).Return(pending, nil).Once()
+	mockRepo.On("UpdateTargetAmounts", mock.Anything, pending).Return(nil).Once()
+	mockRepo.On("FindPendingTargetBackfill", mock.Anything, int64(12), 500).Return([]*domain.Stock{}, nil).Once()
+
+	updated, err := useCase.BackfillTargetAmounts(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, updated, "unparseable targets are stored as parsed but not counted")
+	assert.Equal(t, 120.0, *pending[0].TargetToAmount)
+	assert.Equal(t, "USD", pending[0].TargetCurrency)
+	assert.Nil(t, pending[1].TargetFromAmount)
+	assert.Equal(t, "EUR", pending[1].TargetCurrency)
+	assert.Nil(t, pending[2].TargetToAmount)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestStockUseCase_SetTargetAmounts(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	useCase := NewStockUseCase(nil, nil, nil, nil, nil, nil, nil, logger)
+
+	t.Run("Same currency", func(t *testing.T) {
+		stock := &domain.Stock{TargetFrom: "€100", TargetTo: "€120"}
+
+		useCase.setTargetAmounts(stock)
+
+		assert.Equal(t, 100.0, *stock.TargetFromAmount)
+		assert.Equal(t, 120.0, *stock.TargetToAmount)
+		assert.Equal(t, "EUR", stock.TargetCurrency)
+	})
+
+	t.Run("Different currencies keep only the new target", func(t *testing.T) {
+		stock := &domain.Stock{TargetFrom: "€100", TargetTo: "$120"}
+
+		useCase.setTargetAmounts(stock)
+
+		assert.Nil(t, stock.TargetFromAmount)
+		assert.Equal(t, 120.0, *stock.TargetToAmount)
+		assert.Equal(t, "USD", stock.TargetCurrency)
+	})
+
+	t.Run("A target without a currency takes the other's", func(t *testing.T) {
+		stock := &domain.Stock{TargetFrom: "100", TargetTo: "£120"}
+
+		useCase.setTargetAmounts(stock)
+
+		assert.Equal(t, 100.0, *stock.TargetFromAmount)
+		assert.Equal(t, "GBP", stock.TargetCurrency)
+	})
+}
@@ -0,0 +1,289 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/company/stock-api/internal/domain"
+)
+
+// Score factor names
+const (
+	factorAction        = "action"
+	factorRating        = "rating_improvement"
+	factorTarget        = "target_change"
+	factorRecency       = "recency"
+	factorBrokerage     = "brokerage"
+	factorImpliedUpside = "implied_upside"
+	factorAgreement     = "agreement"
+	factorMeanRating    = "mean_rating"
+	factorBreadth       = "breadth"
+)
+
+// builtinStrategies returns the strategies every StockUseCase starts with, the default first
+func builtinStrategies() []ScoringStrategy {
+	return []ScoringStrategy{balancedStrategy{}, momentumStrategy{}, consensusStrategy{}, contrarianStrategy{}}
+}
+
+// balancedStrategy weighs action, rating, target, recency, brokerage and implied upside by the scoring profile
+type balancedStrategy struct{}
+
+func (balancedStrategy) Info() domain.ScoringStrategyInfo {
+	return domain.ScoringStrategyInfo{
+		Name:        "balanced",
+		Description: "Weighs the action, rating change, target change, recency, brokerage and implied upside of the latest event by the scoring profile",
+	}
+}
+
+func (balancedStrategy) NeedsCoverage() bool { return false }
+
+func (balancedStrategy) Score(input ScoringInput) (StockScore, bool) {
+	stock, profile, weights := input.Stock, input.Profile, input.Profile.Weights
+	var result StockScore
+
+	// 1. Action Score - upgrade is best
+	actionScore, actionRule := profile.ActionScore(stock.ActionCategory)
+	result.add(factorAction, string(stock.ActionCategory), actionScore, weights.Action, actionRule)
+	if actionScore > 3 {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("Recent %s", stock.ActionName))
+	}
+
+	// 2. Rating Improvement Score
+	ratingScore := getRatingImprovementScore(stock.RatingFromScore, stock.RatingToScore)
+	result.add(factorRating, ratingChange(stock), ratingScore, weights.Rating, ratingImprovementRule(stock))
+	if ratingScore > 3 {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("Rating improved to %s", stock.RatingToTerm))
+	}
+
+	// 3. Target Price Increase
+	result.TargetIncrease = calculateTargetPriceIncrease(stock.TargetFromAmount, stock.TargetToAmount)
+	result.add(factorTarget, roundTo(result.TargetIncrease, 2), targetChangeScore(result.TargetIncrease), weights.Target,
+		targetChangeRule(result.TargetIncrease))
+	result.Reasons = append(result.Reasons, targetChangeReasons(result.TargetIncrease)...)
+
+	// 4. Recency Score - more recent is better
+	addRecency(&result, input, weights.Recency)
+
+	// 5. Brokerage Reputation
+	brokerageScore, brokerageRule := profile.BrokerageScore(stock.BrokerageName)
+	result.add(factorBrokerage, stock.BrokerageName, brokerageScore, weights.Brokerage, brokerageRule)
+	if brokerageScore >= 8 && stock.BrokerageName != "" {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("Rated by %s", stock.BrokerageName))
+	}
+
+	// 6. Implied Upside - scores 0 without a recent close
+	addImpliedUpside(&result, input)
+
+	if len(result.Reasons) == 0 {
+		result.Reasons = []string{"Positive outlook"}
+	}
+	return result, true
+}
+
+// momentumStrategy only scores stocks whose latest event is a recent upgrade or target raise,
+// tuned by the momentum section of the scoring profile
+type momentumStrategy struct{}
+
+func (momentumStrategy) Info() domain.ScoringStrategyInfo {
+	return domain.ScoringStrategyInfo{
+		Name: "momentum",
+		Description: "Only stocks whose latest event is a recent upgrade or target raise, scored by action, target increase and recency. " +
+			"The window and weights are set in the momentum section of the scoring profile",
+	}
+}
+
+func (momentumStrategy) NeedsCoverage() bool { return false }
+
+func (momentumStrategy) Score(input ScoringInput) (StockScore, bool) {
+	stock, profile, momentum := input.Stock, input.Profile, input.Profile.Momentum
+	daysSince := input.Now.Sub(stock.Time).Hours() / 24
+	targetIncrease := calculateTargetPriceIncrease(stock.TargetFromAmount, stock.TargetToAmount)
+
+	rising := stock.ActionCategory == domain.ActionCategoryUpgrade ||
+		stock.ActionCategory == domain.ActionCategoryTargetRaised || targetIncrease > 0
+	if !rising || daysSince > momentum.WindowDays {
+		return StockScore{}, false
+	}
+
+	result := StockScore{TargetIncrease: targetIncrease}
+	actionScore, actionRule := profile.ActionScore(stock.ActionCategory)
+	result.add(factorAction, string(stock.ActionCategory), actionScore, momentum.Weights.Action, actionRule)
+	result.add(factorTarget, roundTo(targetIncrease, 2), clampScore(targetChangeScore(targetIncrease), 0), momentum.Weights.Target,
+		targetChangeRule(targetIncrease)+", floored at 0")
+	addRecency(&result, input, momentum.Weights.Recency)
+
+	if actionScore > 3 {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("Recent %s", stock.ActionName))
+	}
+	result.Reasons = append(result.Reasons, targetChangeReasons(targetIncrease)...)
+	result.ImpliedUpside = calculateImpliedUpside(stock, input.LastClose)
+	if len(result.Reasons) == 0 {
+		result.Reasons = []string{"Rising targets"}
+	}
+	return result, true
+}
+
+// consensusStrategy scores how many covering brokerages agree on buying a stock,
+// tuned by the consensus section of the scoring profile
+type consensusStrategy struct{}
+
+func (consensusStrategy) Info() domain.ScoringStrategyInfo {
+	return domain.ScoringStrategyInfo{
+		Name: "consensus",
+		Description: fmt.Sprintf("Stocks rated by several brokerages within the last %d days, scored by the share rating them buy or better, "+
+			"their mean rating and how many cover them. The thresholds and weights are set in the consensus section of the scoring profile",
+			int(strategyCoverageWindow.Hours()/24)),
+	}
+}
+
+func (consensusStrategy) NeedsCoverage() bool { return true }
+
+func (consensusStrategy) Score(input ScoringInput) (StockScore, bool) {
+	consensus := input.Profile.Consensus
+	var ratings []float64
+	bullish := 0
+	for _, event := range input.Coverage {
+		if event.RatingToScore == nil {
+			continue
+		}
+		ratings = append(ratings, *event.RatingToScore)
+		if *event.RatingToScore >= consensus.BullishRatingScore {
+			bullish++
+		}
+	}
+	if len(ratings) < consensus.MinBrokerages {
+		return StockScore{}, false
+	}
+
+	stock := input.Stock
+	rated := len(ratings)
+	meanRating := mean(ratings)
+	result := StockScore{
+		TargetIncrease: calculateTargetPriceIncrease(stock.TargetFromAmount, stock.TargetToAmount),
+		ImpliedUpside:  calculateImpliedUpside(stock, input.LastClose),
+		Reasons: []string{
+			fmt.Sprintf("%d of %d brokerages rate it buy or better", bullish, rated),
+			fmt.Sprintf("Mean rating %.1f of 5", meanRating),
+		},
+	}
+	result.add(factorAgreement, roundTo(float64(bullish)/float64(rated), 4), float64(bullish)/float64(rated)*10, consensus.Weights.Agreement,
+		fmt.Sprintf("%d of %d rating brokerages at %g or better, × 10", bullish, rated, consensus.BullishRatingScore))
+	result.add(factorMeanRating, roundTo(meanRating, 2), meanRating*2, consensus.Weights.MeanRating, "mean rating on the 1-5 scale × 2")
+	result.add(factorBreadth, rated, math.Min(10, float64(rated)*2), consensus.Weights.Breadth, "2 points per rating brokerage, capped at 10")
+	return result, true
+}
+
+// contrarianStrategy inverts the balanced strategy's signals: downgrades, rating cuts and target cuts
+// score high, while recency, brokerage and implied upside count as usual
+type contrarianStrategy struct{}
+
+func (contrarianStrategy) Info() domain.ScoringStrategyInfo {
+	return domain.ScoringStrategyInfo{
+		Name: "contrarian",
+		Description: "Favors stocks the street turned against: downgrades, rating cuts and target cuts score high. " +
+			"Uses the scoring profile's weights; recency, brokerage and implied upside count as in balanced",
+	}
+}
+
+func (contrarianStrategy) NeedsCoverage() bool { return false }
+
+func (contrarianStrategy) Score(input ScoringInput) (StockScore, bool) {
+	stock, profile, weights := input.Stock, input.Profile, input.Profile.Weights
+	var result StockScore
+
+	actionScore, actionRule := profile.ActionScore(stock.ActionCategory)
+	result.add(factorAction, string(stock.ActionCategory), 10-actionScore, weights.Action, "10 - "+actionRule)
+	if otherScore, _ := profile.ActionScore(domain.ActionCategoryOther); actionScore < otherScore {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("Against the recent %s", stock.ActionName))
+	}
+
+	ratingScore := getRatingImprovementScore(stock.RatingFromScore, stock.RatingToScore)
+	result.add(factorRating, ratingChange(stock), clampScore(10-ratingScore, 0), weights.Rating,
+		"10 - ("+ratingImprovementRule(stock)+"), floored at 0")
+	if stock.RatingFromScore != nil && stock.RatingToScore != nil && *stock.RatingToScore < *stock.RatingFromScore {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("Rating cut to %s", stock.RatingToTerm))
+	}
+
+	result.TargetIncrease = calculateTargetPriceIncrease(stock.TargetFromAmount, stock.TargetToAmount)
+	result.add(factorTarget, roundTo(result.TargetIncrease, 2), clampScore(-targetChangeScore(result.TargetIncrease), 0), weights.Target,
+		"-("+targetChangeRule(result.TargetIncrease)+"), floored at 0")
+	if result.TargetIncrease < 0 {
+		result.Reasons = append(result.Reasons, targetChangeReasons(result.TargetIncrease)...)
+	}
+
+	addRecency(&result, input, weights.Recency)
+	brokerageScore, brokerageRule := profile.BrokerageScore(stock.BrokerageName)
+	result.add(factorBrokerage, stock.BrokerageName, brokerageScore, weights.Brokerage, brokerageRule)
+	addImpliedUpside(&result, input)
+
+	if len(result.Reasons) == 0 {
+		result.Reasons = []string{"Out of favor"}
+	}
+	return result, true
+}
+
+// ratingChange is the change from rating_from to rating_to on the canonical scale, unmapped ratings counting as neutral
+func ratingChange(stock *domain.StockWithDetails) float64 {
+	return getRatingValue(stock.RatingToScore) - getRatingValue(stock.RatingFromScore)
+}
+
+// ratingImprovementRule spells out getRatingImprovementScore for a stock
+func ratingImprovementRule(stock *domain.StockWithDetails) string {
+	rule := fmt.Sprintf("rating_to %g × 2 + change %+g × 2", getRatingValue(stock.RatingToScore), ratingChange(stock))
+	if stock.RatingFromScore == nil || stock.RatingToScore == nil {
+		rule += fmt.Sprintf(" (unmapped ratings count as %g)", domain.NeutralRatingScore)
+	}
+	return rule
+}
+
+// targetChangeRule spells out targetChangeScore for a change
+func targetChangeRule(increase float64) string {
+	switch {
+	case increase == 0:
+		return "no change"
+	case math.Abs(increase/2) > 10:
+		return "change / 2, capped at ±10"
+	default:
+		return "change / 2"
+	}
+}
+
+// targetChangeReasons describes target changes of more than 5%
+func targetChangeReasons(increase float64) []string {
+	if increase > 5 {
+		return []string{fmt.Sprintf("%.1f%% price target increase", increase)}
+	} else if increase < -5 {
+		return []string{fmt.Sprintf("%.1f%% price target decrease", increase)}
+	}
+	return nil
+}
+
+// addRecency adds the recency factor of the stock's event at the given weight
+func addRecency(result *StockScore, input ScoringInput, weight float64) {
+	daysSince := input.Now.Sub(input.Stock.Time).Hours() / 24
+	score, rule := input.Profile.RecencyScore(daysSince)
+	result.add(factorRecency, roundTo(daysSince, 2), score, weight, rule)
+}
+
+// addImpliedUpside adds the implied upside of a stock at the profile's weight. Without a recent close in the
+// target's currency the factor scores 0, and is left out when the profile does not weigh it.
+func addImpliedUpside(result *StockScore, input ScoringInput) {
+	weight := input.Profile.Weights.ImpliedUpside
+	result.ImpliedUpside = calculateImpliedUpside(input.Stock, input.LastClose)
+	if result.ImpliedUpside == nil {
+		if weight > 0 {
+			result.add(factorImpliedUpside, nil, 0, weight, "no recent close in the target currency")
+		}
+		return
+	}
+
+	upside := *result.ImpliedUpside
+	// Normalized like the target increase: 20% upside = 10 points
+	result.add(factorImpliedUpside, roundTo(upside, 2), targetChangeScore(upside), weight,
+		"upside to target_to from the last close, "+targetChangeRule(upside))
+	if upside > 10 {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("%.1f%% implied upside to target", upside))
+	} else if upside < -5 {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("Target %.1f%% below last close", -upside))
+	}
+}
@@ -0,0 +1,354 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// strategyNow is the time strategy inputs are scored at
+var strategyNow = time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+
+// scoringInput scores a stock at strategyNow with the default profile
+func scoringInput(stock *domain.StockWithDetails) ScoringInput {
+	return ScoringInput{Stock: stock, Profile: DefaultScoringProfile(), Now: strategyNow}
+}
+
+func TestBalancedStrategy(t *testing.T) {
+	strategy := balancedStrategy{}
+
+	t.Run("Weighs every factor", func(t *testing.T) {
+		stock := &domain.StockWithDetails{
+			ActionName: "upgraded by", ActionCategory: domain.ActionCategoryUpgrade,
+			RatingFromScore: floatPtr(3), RatingToScore: floatPtr(4), RatingToTerm: "Buy",
+			TargetFromAmount: floatPtr(100), TargetToAmount: floatPtr(120),
+			BrokerageName: "Goldman Sachs", Time: strategyNow.Add(-time.Hour),
+		}
+
+		score, ok := strategy.Score(scoringInput(stock))
+
+		assert.True(t, ok)
+		// action 10*0.30 + rating (8+2)*0.25 + target 10*0.20 + recency 10*0.15 + brokerage 10*0.10
+		assert.InDelta(t, 3+2.5+2+1.5+1, score.Score, 1e-9)
+		assert.InDelta(t, 20.0, score.TargetIncrease, 1e-9)
+		assert.Equal(t, []string{"Recent upgraded by", "Rating improved to Buy", "20.0% price target increase", "Rated by Goldman Sachs"}, score.Reasons)
+
+		rules := make(map[string]string, len(score.Factors))
+		contributions := 0.0
+		for _, factor := range score.Factors {
+			rules[factor.Name] = factor.Rule
+			contributions += factor.Contribution
+		}
+		assert.Equal(t, map[string]string{
+			"action":             "action_scores.upgrade",
+			"rating_improvement": "rating_to 4 × 2 + change +1 × 2",
+			"target_change":      "change / 2",
+			"recency":            "recency_buckets[0]: at most 1 days",
+			"brokerage":          `brokerage_tiers.top: matches "goldman sachs"`,
+		}, rules)
+		assert.InDelta(t, score.Score, contributions, 1e-9, "contributions add up to the score")
+		if assert.Len(t, score.Factors, 5) {
+			assert.Equal(t, domain.ScoreFactor{
+				Name: "action", Value: "upgrade", Score: 10, Weight: 0.30, Contribution: 3, Rule: "action_scores.upgrade",
+			}, score.Factors[0])
+		}
+	})
+
+	t.Run("Scores implied upside as 0 without a close", func(t *testing.T) {
+		stock := &domain.StockWithDetails{
+			ActionCategory: domain.ActionCategoryUpgrade, TargetToAmount: floatPtr(120), TargetCurrency: "USD",
+			BrokerageName: "Goldman Sachs", Time: strategyNow.Add(-time.Hour),
+		}
+		weights := domain.ScoringWeights{Action: 0.255, Rating: 0.2125, Target: 0.17, Recency: 0.1275, Brokerage: 0.085, ImpliedUpside: 0.15}
+		input := scoringInput(stock)
+		input.Profile.Weights = weights
+		input.LastClose = &domain.PriceBar{Close: 100, Currency: "USD"}
+		withClose, _ := strategy.Score(input)
+		input.LastClose = nil
+		withoutClose, _ := strategy.Score(input)
+
+		// Both are scored against the same weights, so the close only adds its own contribution
+		if assert.Len(t, withClose.Factors, 6) && assert.Len(t, withoutClose.Factors, 6) {
+			assert.Equal(t, domain.ScoreFactor{
+				Name: "implied_upside", Score: 0, Weight: 0.15, Contribution: 0, Rule: "no recent close in the target currency",
+			}, withoutClose.Factors[5])
+			assert.InDelta(t, 10*0.15, withClose.Factors[5].Contribution, 1e-9)
+		}
+		assert.InDelta(t, withoutClose.Score+10*0.15, withClose.Score, 1e-9)
+		assert.LessOrEqual(t, withClose.Score, 10.0, "the implied upside does not add to a full score")
+	})
+
+	t.Run("Follows the profile weights", func(t *testing.T) {
+		stock := &domain.StockWithDetails{ActionCategory: domain.ActionCategoryUpgrade, Time: strategyNow.Add(-time.Hour)}
+		input := scoringInput(stock)
+		input.Profile.Weights = domain.ScoringWeights{Action: 1}
+
+		score, _ := strategy.Score(input)
+
+		assert.Equal(t, 10.0, score.Score)
+	})
+}
+
+func TestMomentumStrategy(t *testing.T) {
+	strategy := momentumStrategy{}
+
+	t.Run("Scores recent upgrades and target raises", func(t *testing.T) {
+		stock := &domain.StockWithDetails{
+			ActionName: "target raised by", ActionCategory: domain.ActionCategoryTargetRaised,
+			TargetFromAmount: floatPtr(100), TargetToAmount: floatPtr(110), Time: strategyNow.Add(-3 * 24 * time.Hour),
+		}
+
+		score, ok := strategy.Score(scoringInput(stock))
+
+		assert.True(t, ok)
+		// action 7*0.35 + target 5*0.35 + recency 8*0.30
+		assert.InDelta(t, 2.45+1.75+2.4, score.Score, 1e-9)
+		assert.Equal(t, []string{"Recent target raised by", "10.0% price target increase"}, score.Reasons)
+	})
+
+	t.Run("A raised target counts whatever the action", func(t *testing.T) {
+		stock := &domain.StockWithDetails{
+			ActionCategory: domain.ActionCategoryReiterate, TargetFromAmount: floatPtr(100), TargetToAmount: floatPtr(102), Time: strategyNow,
+		}
+
+		_, ok := strategy.Score(scoringInput(stock))
+
+		assert.True(t, ok)
+	})
+
+	t.Run("Skips other and older events", func(t *testing.T) {
+		for _, stock := range []*domain.StockWithDetails{
+			{ActionCategory: domain.ActionCategoryDowngrade, Time: strategyNow},
+			{ActionCategory: domain.ActionCategoryReiterate, Time: strategyNow},
+			{ActionCategory: domain.ActionCategoryUpgrade, Time: strategyNow.AddDate(0, 0, -31)},
+		} {
+			_, ok := strategy.Score(scoringInput(stock))
+
+			assert.False(t, ok, "%s at %s", stock.ActionCategory, stock.Time)
+		}
+	})
+
+	t.Run("Follows the momentum section of the profile", func(t *testing.T) {
+		stock := &domain.StockWithDetails{ActionCategory: domain.ActionCategoryUpgrade, Time: strategyNow.AddDate(0, 0, -45)}
+		input := scoringInput(stock)
+		input.Profile.Momentum = domain.MomentumProfile{WindowDays: 60, Weights: domain.MomentumWeights{Action: 1}}
+
+		score, ok := strategy.Score(input)
+
+		assert.True(t, ok)
+		assert.Equal(t, 10.0, score.Score)
+	})
+}
+
+func TestConsensusStrategy(t *testing.T) {
+	strategy := consensusStrategy{}
+	assert.True(t, strategy.NeedsCoverage())
+
+	t.Run("Scores agreement, mean rating and breadth", func(t *testing.T) {
+		input := scoringInput(&domain.StockWithDetails{Ticker: "AAPL", Time: strategyNow})
+		input.Coverage = []*domain.StockWithDetails{
+			{RatingToScore: floatPtr(5)},
+			{RatingToScore: floatPtr(4)},
+			{RatingToScore: floatPtr(4)},
+			{RatingToScore: floatPtr(3)},
+			{RatingToTerm: "Unmapped"},
+		}
+
+		score, ok := strategy.Score(input)
+
+		assert.True(t, ok)
+		// agreement 3/4*10*0.5 + mean rating 4*2*0.3 + breadth 8*0.2
+		assert.InDelta(t, 3.75+2.4+1.6, score.Score, 1e-9)
+		assert.Equal(t, []string{"3 of 4 brokerages rate it buy or better", "Mean rating 4.0 of 5"}, score.Reasons)
+	})
+
+	t.Run("Skips stocks rated by a single brokerage", func(t *testing.T) {
+		input := scoringInput(&domain.StockWithDetails{Ticker: "AAPL", Time: strategyNow})
+		input.Coverage = []*domain.StockWithDetails{{RatingToScore: floatPtr(5)}}
+
+		_, ok := strategy.Score(input)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("Follows the consensus section of the profile", func(t *testing.T) {
+		input := scoringInput(&domain.StockWithDetails{Ticker: "AAPL", Time: strategyNow})
+		input.Coverage = []*domain.StockWithDetails{{RatingToScore: floatPtr(4)}, {RatingToScore: floatPtr(5)}}
+		input.Profile.Consensus = domain.ConsensusProfile{
+			MinBrokerages: 3, BullishRatingScore: 5, Weights: domain.ConsensusWeights{Agreement: 1},
+		}
+
+		_, ok := strategy.Score(input)
+		assert.False(t, ok, "fewer brokerages than min_brokerages")
+
+		input.Profile.Consensus.MinBrokerages = 2
+		score, ok := strategy.Score(input)
+
+		assert.True(t, ok)
+		// only the strong buy counts as bullish
+		assert.InDelta(t, 5.0, score.Score, 1e-9)
+	})
+}
+
+func TestContrarianStrategy(t *testing.T) {
+	strategy := contrarianStrategy{}
+
+	t.Run("Inverts the signals", func(t *testing.T) {
+		stock := &domain.StockWithDetails{
+			ActionName: "downgraded by", ActionCategory: domain.ActionCategoryDowngrade,
+			RatingFromScore: floatPtr(4), RatingToScore: floatPtr(2), RatingToTerm: "Underweight",
+			TargetFromAmount: floatPtr(100), TargetToAmount: floatPtr(80),
+			BrokerageName: "Needham", Time: strategyNow.Add(-time.Hour),
+		}
+
+		score, ok := strategy.Score(scoringInput(stock))
+
+		assert.True(t, ok)
+		// action (10-2)*0.30 + rating (10-0)*0.25 + target 10*0.20 + recency 10*0.15 + brokerage 6*0.10
+		assert.InDelta(t, 2.4+2.5+2+1.5+0.6, score.Score, 1e-9)
+		assert.Equal(t, []string{"Against the recent downgraded by", "Rating cut to Underweight", "-20.0% price target decrease"}, score.Reasons)
+	})
+
+	t.Run("Ranks a downgrade above an upgrade", func(t *testing.T) {
+		downgrade, _ := strategy.Score(scoringInput(&domain.StockWithDetails{ActionCategory: domain.ActionCategoryDowngrade, Time: strategyNow}))
+		upgrade, _ := strategy.Score(scoringInput(&domain.StockWithDetails{ActionCategory: domain.ActionCategoryUpgrade, Time: strategyNow}))
+
+		assert.Greater(t, downgrade.Score, upgrade.Score)
+		assert.Equal(t, []string{"Out of favor"}, upgrade.Reasons)
+	})
+}
+
+func TestStockUseCase_Strategies(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	useCase := NewStockUseCase(new(MockStockRepository), nil, nil, nil, nil, nil, nil, logger)
+
+	strategies := useCase.Strategies()
+
+	var names []string
+	for _, strategy := range strategies {
+		names = append(names, strategy.Name)
+		assert.NotEmpty(t, strategy.Description)
+		assert.Equal(t, strategy.Name == DefaultStrategy, strategy.Default)
+	}
+	assert.Equal(t, []string{"balanced", "momentum", "consensus", "contrarian"}, names)
+
+	t.Run("Registered strategies replace built-in ones", func(t *testing.T) {
+		useCase := NewStockUseCase(new(MockStockRepository), nil, nil, nil, nil, nil, nil, logger)
+		useCase.RegisterStrategy(momentumStrategy{})
+		useCase.RegisterStrategy(fixedStrategy{})
+
+		assert.Len(t, useCase.Strategies(), 5)
+		strategy, err := useCase.findStrategy(" Fixed ")
+		assert.NoError(t, err)
+		assert.Equal(t, "fixed", strategy.Info().Name)
+	})
+}
+
+// fixedStrategy scores every stock 1
+type fixedStrategy struct{}
+
+func (fixedStrategy) Info() domain.ScoringStrategyInfo {
+	return domain.ScoringStrategyInfo{Name: "fixed", Description: "Scores every stock 1"}
+}
+
+func (fixedStrategy) NeedsCoverage() bool { return false }
+
+func (fixedStrategy) Score(ScoringInput) (StockScore, bool) {
+	return StockScore{Score: 1, Reasons: []string{"Fixed"}}, true
+}
+
+func TestStockUseCase_GetRecommendations_Strategy(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	asOf := strategyNow
+	stocks := func() []*domain.StockWithDetails {
+		return []*domain.StockWithDetails{
+			{Ticker: "AAPL", ActionCategory: domain.ActionCategoryUpgrade, Time: asOf.Add(-time.Hour)},
+			{Ticker: "MSFT", ActionCategory: domain.ActionCategoryDowngrade, Time: asOf.Add(-time.Hour)},
+			{Ticker: "NVDA", ActionCategory: domain.ActionCategoryReiterate, Time: asOf.Add(-time.Hour)},
+		}
+	}
+
+	t.Run("Consensus loads the brokerage coverage", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		mockRepo.On("FindAll", mock.Anything).Return(stocks(), nil).Once()
+		mockRepo.On("Stream", mock.Anything, mock.MatchedBy(func(filter domain.StockFilter) bool {
+			return filter.View == domain.StockViewByBrokerage && filter.AsOf.Equal(asOf) &&
+				filter.Time.From.Equal(asOf.Add(-strategyCoverageWindow))
+		}), mock.Anything).Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(*domain.StockWithDetails) error)
+			fn(&domain.StockWithDetails{Ticker: "MSFT", RatingToScore: floatPtr(5)})
+			fn(&domain.StockWithDetails{Ticker: "MSFT", RatingToScore: floatPtr(4)})
+			fn(&domain.StockWithDetails{Ticker: "NVDA", RatingToScore: floatPtr(2)})
+			fn(&domain.StockWithDetails{Ticker: "NVDA", RatingToScore: floatPtr(3)})
+			fn(&domain.StockWithDetails{Ticker: "AAPL", RatingToScore: floatPtr(5)})
+		}).Return(nil).Once()
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		recommendations, err := useCase.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 10, AsOf: &asOf, Strategy: "consensus"})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		// AAPL has a single rating and is left out; MSFT's brokerages agree on buying it
+		if assert.Len(t, recommendations, 2) {
+			assert.Equal(t, "MSFT", recommendations[0].Stock.Ticker)
+			assert.Equal(t, "NVDA", recommendations[1].Stock.Ticker)
+		}
+	})
+
+	t.Run("Momentum leaves out stocks without momentum", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		mockRepo.On("FindAll", mock.Anything).Return(stocks(), nil).Once()
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		recommendations, err := useCase.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 10, AsOf: &asOf, Strategy: "momentum"})
+
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "Stream", mock.Anything, mock.Anything, mock.Anything)
+		if assert.Len(t, recommendations, 1) {
+			assert.Equal(t, "AAPL", recommendations[0].Stock.Ticker)
+		}
+	})
+
+	t.Run("Rules are only shown when explaining", func(t *testing.T) {
+		for _, explain := range []bool{false, true} {
+			mockRepo := new(MockStockRepository)
+			mockRepo.On("FindAll", mock.Anything).Return(stocks(), nil).Once()
+			useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+			recommendations, err := useCase.GetRecommendations(context.Background(), domain.RecommendationQuery{Limit: 1, AsOf: &asOf, Explain: explain})
+
+			assert.NoError(t, err)
+			if assert.Len(t, recommendations, 1) && assert.NotEmpty(t, recommendations[0].Factors) {
+				assert.Equal(t, "action", recommendations[0].Factors[0].Name)
+				assert.Equal(t, explain, recommendations[0].Factors[0].Rule != "")
+			}
+		}
+	})
+
+	t.Run("Unknown strategy", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		_, err := useCase.GetRecommendations(context.Background(), domain.RecommendationQuery{Strategy: "yolo"})
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+		mockRepo.AssertNotCalled(t, "FindAll", mock.Anything)
+	})
+
+	t.Run("Coverage error", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		mockRepo.On("FindAll", mock.Anything).Return(stocks(), nil).Once()
+		mockRepo.On("Stream", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("database error")).Once()
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		_, err := useCase.GetRecommendations(context.Background(), domain.RecommendationQuery{Strategy: "consensus"})
+
+		assert.Error(t, err)
+	})
+}
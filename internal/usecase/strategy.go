@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+)
+
+// DefaultStrategy is the scoring strategy used when a request names none
+const DefaultStrategy = "balanced"
+
+// strategyCoverageWindow is how far back the latest event of each covering brokerage is loaded
+// for strategies that need it, matching the default consensus window
+const strategyCoverageWindow = 90 * 24 * time.Hour
+
+// coverageFields are the fields loaded for the coverage of a ticker
+var coverageFields = []string{"ticker", "brokerage_id", "brokerage", "rating_to", "rating_to_score", "rating_to_bucket", "time"}
+
+// ScoringStrategy scores the latest event of each ticker for recommendations
+type ScoringStrategy interface {
+	// Info names and describes the strategy
+	Info() domain.ScoringStrategyInfo
+	// NeedsCoverage reports whether Score reads ScoringInput.Coverage
+	NeedsCoverage() bool
+	// Score scores one stock; false leaves the stock out of the recommendations
+	Score(input ScoringInput) (StockScore, bool)
+}
+
+// ScoringInput is what a strategy scores a stock from
+type ScoringInput struct {
+	Stock *domain.StockWithDetails
+	// LastClose is the ticker's recent close; nil without one
+	LastClose *domain.PriceBar
+	// Coverage holds the latest event of every brokerage covering the ticker within the coverage window,
+	// newest first. It is only loaded for strategies that need it.
+	Coverage []*domain.StockWithDetails
+	// Profile holds the active weights and factor tables
+	Profile *domain.ScoringProfile
+	// Now is the time recency is measured from
+	Now time.Time
+}
+
+// StockScore is a strategy's score of one stock
+type StockScore struct {
+	Score float64
+	// Factors break Score down; their contributions add up to it
+	Factors        []domain.ScoreFactor
+	Reasons        []string
+	TargetIncrease float64
+	ImpliedUpside  *float64
+}
+
+// add adds a factor's weighted score to the total
+func (s *StockScore) add(name string, value interface{}, score, weight float64, rule string) {
+	contribution := score * weight
+	s.Score += contribution
+	s.Factors = append(s.Factors, domain.ScoreFactor{
+		Name:         name,
+		Value:        value,
+		Score:        score,
+		Weight:       weight,
+		Contribution: contribution,
+		Rule:         rule,
+	})
+}
+
+// RegisterStrategy adds a scoring strategy, replacing a built-in one of the same name.
+// It must be called before the use case serves requests.
+func (uc *StockUseCase) RegisterStrategy(strategy ScoringStrategy) {
+	name := strategy.Info().Name
+	for i, existing := range uc.strategies {
+		if existing.Info().Name == name {
+			uc.strategies[i] = strategy
+			return
+		}
+	}
+	uc.strategies = append(uc.strategies, strategy)
+}
+
+// Strategies describes the scoring strategies recommendations can be requested with
+func (uc *StockUseCase) Strategies() []domain.ScoringStrategyInfo {
+	infos := make([]domain.ScoringStrategyInfo, 0, len(uc.strategies))
+	for _, strategy := range uc.strategies {
+		info := strategy.Info()
+		info.Default = info.Name == DefaultStrategy
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// findStrategy returns the strategy with the given name, or the default strategy for an empty name
+func (uc *StockUseCase) findStrategy(name string) (ScoringStrategy, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = DefaultStrategy
+	}
+
+	names := make([]string, 0, len(uc.strategies))
+	for _, strategy := range uc.strategies {
+		if strategy.Info().Name == name {
+			return strategy, nil
+		}
+		names = append(names, strategy.Info().Name)
+	}
+	return nil, fmt.Errorf("%w: unknown strategy %q (allowed: %s)", domain.ErrInvalidInput, name, strings.Join(names, ", "))
+}
+
+// clampScore limits a factor score to the range from lower to 10
+func clampScore(score, lower float64) float64 {
+	return math.Max(lower, math.Min(10, score))
+}
+
+// targetChangeScore normalizes a target change: a 10% increase scores 5 points, 20% or more 10 points,
+// and decreases score as low as -10
+func targetChangeScore(increase float64) float64 {
+	return clampScore(increase/2.0, -10)
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+)
+
+// relativeTimePattern matches relative times like "7d", "24h" or "2w"
+var relativeTimePattern = regexp.MustCompile(`^(\d+)([hdw])$`)
+
+// relativeTimeUnits maps relative time suffixes to durations
+var relativeTimeUnits = map[string]time.Duration{
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+// dateLayout is the layout of date-only time bounds
+const dateLayout = "2006-01-02"
+
+// ParseTimeBound parses an RFC 3339 timestamp, a YYYY-MM-DD date, or a relative time
+// such as "7d" (7 days before now). Supported relative units are h, d and w.
+func ParseTimeBound(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if value == "now" {
+		return now, nil
+	}
+
+	if match := relativeTimePattern.FindStringSubmatch(value); match != nil {
+		n, err := strconv.Atoi(match[1])
+		if err == nil {
+			return now.Add(-time.Duration(n) * relativeTimeUnits[match[2]]), nil
+		}
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.Parse(dateLayout, value); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("%w: invalid time %q (use RFC 3339, YYYY-MM-DD or a relative time like 7d)", domain.ErrInvalidInput, value)
+}
+
+// ParseTimeRange parses optional time_from/time_to values into a TimeRange.
+// A date-only time_to includes that whole day, so time_from=D&time_to=D covers day D.
+func ParseTimeRange(from, to string, now time.Time) (domain.TimeRange, error) {
+	var timeRange domain.TimeRange
+
+	if from != "" {
+		t, err := ParseTimeBound(from, now)
+		if err != nil {
+			return timeRange, fmt.Errorf("time_from: %w", err)
+		}
+		timeRange.From = &t
+	}
+
+	if to != "" {
+		t, err := ParseTimeBound(to, now)
+		if err != nil {
+			return timeRange, fmt.Errorf("time_to: %w", err)
+		}
+		if _, err := time.Parse(dateLayout, strings.TrimSpace(to)); err == nil {
+			// The last instant of the day the database can store (timestamps have microsecond precision)
+			t = t.AddDate(0, 0, 1).Add(-time.Microsecond)
+		}
+		timeRange.To = &t
+	}
+
+	if timeRange.From != nil && timeRange.To != nil && timeRange.From.After(*timeRange.To) {
+		return timeRange, fmt.Errorf("%w: time_from must not be after time_to", domain.ErrInvalidInput)
+	}
+
+	return timeRange, nil
+}
+
+// ParseAsOf parses an optional as_of value into the point in time to query the data at.
+// An empty value returns nil, meaning now. Relative values are relative to now; future times are rejected.
+func ParseAsOf(value string, now time.Time) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	asOf, err := ParseTimeBound(value, now)
+	if err != nil {
+		return nil, fmt.Errorf("as_of: %w", err)
+	}
+	if asOf.After(now) {
+		return nil, fmt.Errorf("%w: as_of must not be in the future", domain.ErrInvalidInput)
+	}
+
+	return &asOf, nil
+}
+
+// ParseRelativeDuration parses a relative length such as "30d", "12w" or "36h" into a duration
+func ParseRelativeDuration(value string) (time.Duration, error) {
+	match := relativeTimePattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0, fmt.Errorf("%w: invalid duration %q (use a relative length like 30d, 12w or 36h)", domain.ErrInvalidInput, value)
+	}
+	n, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid duration %q", domain.ErrInvalidInput, value)
+	}
+	return time.Duration(n) * relativeTimeUnits[match[2]], nil
+}
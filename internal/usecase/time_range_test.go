@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeBound(t *testing.T) {
+	now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Time
+		wantErr  bool
+	}{
+		{name: "now", value: "now", expected: now},
+		{name: "hours", value: "24h", expected: now.Add(-24 * time.Hour)},
+		{name: "days", value: "7d", expected: now.AddDate(0, 0, -7)},
+		{name: "weeks", value: "2w", expected: now.AddDate(0, 0, -14)},
+		{name: "RFC 3339", value: "2025-01-15T09:30:00Z", expected: time.Date(2025, 1, 15, 9, 30, 0, 0, time.UTC)},
+		{name: "date", value: "2025-01-15", expected: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "unknown unit", value: "7y", wantErr: true},
+		{name: "garbage", value: "yesterday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTimeBound(tt.value, now)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tt.expected.Equal(got), "got %s", got)
+		})
+	}
+}
+
+func TestParseTimeRange(t *testing.T) {
+	now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("empty", func(t *testing.T) {
+		timeRange, err := ParseTimeRange("", "", now)
+		assert.NoError(t, err)
+		assert.Nil(t, timeRange.From)
+		assert.Nil(t, timeRange.To)
+	})
+
+	t.Run("both bounds", func(t *testing.T) {
+		timeRange, err := ParseTimeRange("2025-01-01", "now", now)
+		assert.NoError(t, err)
+		assert.True(t, timeRange.From.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+		assert.True(t, timeRange.To.Equal(now))
+	})
+
+	t.Run("date-only time_to includes the whole day", func(t *testing.T) {
+		timeRange, err := ParseTimeRange("", "2025-01-31", now)
+		assert.NoError(t, err)
+		if assert.NotNil(t, timeRange.To) {
+			lastEvent := time.Date(2025, 1, 31, 23, 59, 59, 999999000, time.UTC)
+			assert.False(t, timeRange.To.Before(lastEvent))
+			assert.True(t, timeRange.To.Before(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)))
+		}
+	})
+
+	t.Run("same-day range covers the day", func(t *testing.T) {
+		timeRange, err := ParseTimeRange("2025-01-31", "2025-01-31", now)
+		assert.NoError(t, err)
+		if assert.NotNil(t, timeRange.From) && assert.NotNil(t, timeRange.To) {
+			noon := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+			assert.False(t, noon.Before(*timeRange.From))
+			assert.False(t, noon.After(*timeRange.To))
+		}
+	})
+
+	t.Run("timestamp time_to is kept as is", func(t *testing.T) {
+		timeRange, err := ParseTimeRange("", "2025-01-31T00:00:00Z", now)
+		assert.NoError(t, err)
+		if assert.NotNil(t, timeRange.To) {
+			assert.True(t, timeRange.To.Equal(time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)))
+		}
+	})
+
+	t.Run("from after to", func(t *testing.T) {
+		_, err := ParseTimeRange("2025-02-01", "2025-01-01", now)
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+	})
+
+	t.Run("invalid bound names the parameter", func(t *testing.T) {
+		_, err := ParseTimeRange("", "soon", now)
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "time_to")
+	})
+}
+
+func TestParseAsOf(t *testing.T) {
+	now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	asOf, err := ParseAsOf("", now)
+	assert.NoError(t, err)
+	assert.Nil(t, asOf)
+
+	asOf, err = ParseAsOf("2025-01-15", now)
+	assert.NoError(t, err)
+	if assert.NotNil(t, asOf) {
+		assert.True(t, time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC).Equal(*asOf))
+	}
+
+	asOf, err = ParseAsOf("7d", now)
+	assert.NoError(t, err)
+	if assert.NotNil(t, asOf) {
+		assert.True(t, now.AddDate(0, 0, -7).Equal(*asOf))
+	}
+
+	_, err = ParseAsOf("2025-04-01", now)
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput), "future as_of")
+
+	_, err = ParseAsOf("last tuesday", now)
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+}
+
+func TestParseRelativeDuration(t *testing.T) {
+	horizon, err := ParseRelativeDuration("30d")
+	assert.NoError(t, err)
+	assert.Equal(t, 30*24*time.Hour, horizon)
+
+	horizon, err = ParseRelativeDuration(" 2w ")
+	assert.NoError(t, err)
+	assert.Equal(t, 14*24*time.Hour, horizon)
+
+	_, err = ParseRelativeDuration("2025-01-01")
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+}
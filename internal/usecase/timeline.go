@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"math"
+
+	"github.com/company/stock-api/internal/domain"
+)
+
+// diffEvents describes what changed from previous to current; nil previous means there is nothing to compare
+func diffEvents(previous, current *domain.StockWithDetails) *domain.EventChange {
+	if previous == nil {
+		return nil
+	}
+
+	change := &domain.EventChange{
+		PreviousID:        previous.ID,
+		PreviousTime:      previous.Time,
+		PreviousBrokerage: previous.BrokerageName,
+		RatingFrom:        previous.RatingToTerm,
+		RatingTo:          current.RatingToTerm,
+		RatingChanged:     previous.RatingToTerm != current.RatingToTerm,
+		RatingMovement:    domain.RatingMovementUnknown,
+		TargetFrom:        previous.TargetToAmount,
+		TargetTo:          current.TargetToAmount,
+		PreviousAction:    previous.ActionName,
+		ActionChanged:     previous.ActionName != current.ActionName,
+	}
+
+	if previous.RatingToScore != nil && current.RatingToScore != nil {
+		delta := *current.RatingToScore - *previous.RatingToScore
+		change.RatingScoreDelta = &delta
+		switch {
+		case delta > 0:
+			change.RatingMovement = domain.RatingMovementUpgrade
+		case delta < 0:
+			change.RatingMovement = domain.RatingMovementDowngrade
+		default:
+			change.RatingMovement = domain.RatingMovementUnchanged
+		}
+	} else if !change.RatingChanged {
+		change.RatingMovement = domain.RatingMovementUnchanged
+	}
+
+	// Targets in different currencies cannot be compared without conversion
+	if previous.TargetToAmount != nil && current.TargetToAmount != nil && previous.TargetCurrency == current.TargetCurrency {
+		difference := *current.TargetToAmount - *previous.TargetToAmount
+		delta := roundTo(difference, 2)
+		change.TargetDelta = &delta
+		if *previous.TargetToAmount > 0 {
+			pct := roundTo(difference / *previous.TargetToAmount * 100, 2)
+			change.TargetChangePct = &pct
+		}
+	}
+
+	return change
+}
+
+// roundPtr rounds an optional value to two decimal places
+func roundPtr(value *float64) *float64 {
+	if value == nil {
+		return nil
+	}
+	rounded := roundTo(*value, 2)
+	return &rounded
+}
+
+// roundTo rounds value to the given number of decimal places
+func roundTo(value float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(value*scale) / scale
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/company/stock-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestDiffEvents(t *testing.T) {
+	previous := &domain.StockWithDetails{
+		ID: 1, Time: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), BrokerageName: "Barclays",
+		ActionName: "reiterated by", RatingToTerm: "Hold", RatingToScore: floatPtr(3),
+		TargetToAmount: floatPtr(200), TargetCurrency: "USD",
+	}
+
+	tests := []struct {
+		name     string
+		current  *domain.StockWithDetails
+		movement domain.RatingMovement
+		changed  bool
+		delta    *float64
+		pct      *float64
+	}{
+		{
+			name:     "Upgrade with a raised target",
+			current:  &domain.StockWithDetails{ActionName: "upgraded by", RatingToTerm: "Buy", RatingToScore: floatPtr(4), TargetToAmount: floatPtr(250), TargetCurrency: "USD"},
+			movement: domain.RatingMovementUpgrade, changed: true, delta: floatPtr(50), pct: floatPtr(25),
+		},
+		{
+			name:     "Downgrade with a lowered target",
+			current:  &domain.StockWithDetails{ActionName: "downgraded by", RatingToTerm: "Sell", RatingToScore: floatPtr(2), TargetToAmount: floatPtr(150), TargetCurrency: "USD"},
+			movement: domain.RatingMovementDowngrade, changed: true, delta: floatPtr(-50), pct: floatPtr(-25),
+		},
+		{
+			name:     "Different term on the same score",
+			current:  &domain.StockWithDetails{ActionName: "reiterated by", RatingToTerm: "Neutral", RatingToScore: floatPtr(3), TargetToAmount: floatPtr(200), TargetCurrency: "USD"},
+			movement: domain.RatingMovementUnchanged, changed: true, delta: floatPtr(0), pct: floatPtr(0),
+		},
+		{
+			name:     "Unscored rating and a target in another currency",
+			current:  &domain.StockWithDetails{ActionName: "reiterated by", RatingToTerm: "Top Pick", TargetToAmount: floatPtr(180), TargetCurrency: "EUR"},
+			movement: domain.RatingMovementUnknown, changed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change := diffEvents(previous, tt.current)
+
+			assert.Equal(t, int64(1), change.PreviousID)
+			assert.Equal(t, "Barclays", change.PreviousBrokerage)
+			assert.Equal(t, "Hold", change.RatingFrom)
+			assert.Equal(t, tt.current.RatingToTerm, change.RatingTo)
+			assert.Equal(t, tt.movement, change.RatingMovement)
+			assert.Equal(t, tt.changed, change.RatingChanged)
+			assert.Equal(t, tt.delta, change.TargetDelta)
+			assert.Equal(t, tt.pct, change.TargetChangePct)
+			assert.Equal(t, tt.current.ActionName != "reiterated by", change.ActionChanged)
+		})
+	}
+
+	t.Run("Same unscored rating is unchanged", func(t *testing.T) {
+		change := diffEvents(&domain.StockWithDetails{RatingToTerm: "Top Pick"}, &domain.StockWithDetails{RatingToTerm: "Top Pick"})
+
+		assert.Equal(t, domain.RatingMovementUnchanged, change.RatingMovement)
+		assert.Nil(t, change.RatingScoreDelta)
+		assert.Nil(t, change.TargetDelta)
+	})
+
+	t.Run("No previous event", func(t *testing.T) {
+		assert.Nil(t, diffEvents(nil, previous))
+	})
+}
+
+func TestStockUseCase_GetTimeline(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	t.Run("Diffs each event against the previous events", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		first := &domain.StockWithDetails{ID: 1, BrokerageName: "Barclays", RatingToTerm: "Hold", RatingToScore: floatPtr(3)}
+		second := &domain.StockWithDetails{ID: 2, BrokerageName: "Citigroup", RatingToTerm: "Buy", RatingToScore: floatPtr(4)}
+		third := &domain.StockWithDetails{ID: 3, BrokerageName: "Barclays", RatingToTerm: "Sell", RatingToScore: floatPtr(2)}
+		filter := domain.TimelineFilter{Brokerage: domain.ValueFilter{In: []string{"barclays"}}}
+		mockRepo.On("FindTimeline", mock.Anything, "AAPL", domain.TimelineFilter{Brokerage: filter.Brokerage, Limit: 50}).Return([]*domain.TimelineEntry{
+			{Stock: third, Previous: second, PreviousByBrokerage: first},
+			{Stock: first},
+		}, nil).Once()
+
+		events, err := useCase.GetTimeline(context.Background(), "AAPL", filter)
+
+		assert.NoError(t, err)
+		if assert.Len(t, events, 2) {
+			assert.Equal(t, int64(3), events[0].ID)
+			assert.Equal(t, int64(2), events[0].SincePrevious.PreviousID)
+			assert.Equal(t, domain.RatingMovementDowngrade, events[0].SincePrevious.RatingMovement)
+			assert.Equal(t, int64(1), events[0].SinceBrokerage.PreviousID)
+			assert.Equal(t, "Hold", events[0].SinceBrokerage.RatingFrom)
+			assert.Nil(t, events[1].SincePrevious)
+			assert.Nil(t, events[1].SinceBrokerage)
+		}
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid filter", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		_, err := useCase.GetTimeline(context.Background(), "AAPL", domain.TimelineFilter{
+			Brokerage: domain.ValueFilter{In: []string{"citi"}, NotIn: []string{"Citi"}},
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "FindTimeline", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+		mockRepo.On("FindTimeline", mock.Anything, "AAPL", mock.Anything).Return(nil, errors.New("database error")).Once()
+
+		events, err := useCase.GetTimeline(context.Background(), "AAPL", domain.TimelineFilter{})
+
+		assert.Error(t, err)
+		assert.Nil(t, events)
+	})
+}
+
+func TestStockUseCase_GetTimelineCount(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	filter := domain.TimelineFilter{Brokerage: domain.ValueFilter{In: []string{"barclays"}}}
+	tickerFilter := domain.StockFilter{Ticker: domain.ValueFilter{In: []string{"AAPL"}}}
+
+	t.Run("Counts matching events", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		mockRepo.On("CountTimeline", mock.Anything, "AAPL", filter).Return(int64(3), nil).Once()
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		count, err := useCase.GetTimelineCount(context.Background(), "AAPL", filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+		mockRepo.AssertNotCalled(t, "Count", mock.Anything)
+	})
+
+	t.Run("Known ticker without matching events", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		mockRepo.On("CountTimeline", mock.Anything, "AAPL", filter).Return(int64(0), nil).Once()
+		mockRepo.On("Count", tickerFilter).Return(int64(1), nil).Once()
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		count, err := useCase.GetTimelineCount(context.Background(), "AAPL", filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("Unknown ticker", func(t *testing.T) {
+		mockRepo := new(MockStockRepository)
+		mockRepo.On("CountTimeline", mock.Anything, "AAPL", filter).Return(int64(0), nil).Once()
+		mockRepo.On("Count", tickerFilter).Return(int64(0), nil).Once()
+		useCase := NewStockUseCase(mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		_, err := useCase.GetTimelineCount(context.Background(), "AAPL", filter)
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}